@@ -30,6 +30,122 @@ const (
 
 	// OCPRAGCondition Status=True condition which indicates the OCP RAG version resolution status
 	OCPRAGCondition condition.Type = "OCPRAGReady"
+
+	// DependencyReadyCondition Status=True condition which indicates that the resource referenced by
+	// WaitFor reports its condition as True. Only set when WaitFor is set.
+	DependencyReadyCondition condition.Type = "DependencyReady"
+
+	// ConsoleAvailableCondition Status=True condition which indicates that the availability of the
+	// OpenShift console was checked. A cluster without the console is reported in the condition
+	// message, OLS readiness does not wait for the console plugin then.
+	ConsoleAvailableCondition condition.Type = "ConsoleAvailable"
+
+	// RAGImageCompatibilityCondition Status=True condition which indicates that the RAG image is
+	// compatible with the OpenShift Lightspeed operator version. An incompatible pairing is reported
+	// as a False condition with Warning severity.
+	RAGImageCompatibilityCondition condition.Type = "RAGImageCompatibility"
+
+	// RAGImageArchitectureCondition Status=True condition which indicates that the architectures of
+	// the RAG image were checked against the cluster nodes. A mismatch is reported as a warning in
+	// the condition message.
+	RAGImageArchitectureCondition condition.Type = "RAGImageArchitecture"
+
+	// RAGImageReadyCondition Status=True condition which indicates that the RAG image was pulled for
+	// the OLS pods
+	RAGImageReadyCondition condition.Type = "RAGImageReady"
+
+	// ConversationCacheCondition Status=True condition which indicates that the OLSConfig is
+	// refreshed while its conversation cache is kept in memory. The loss of the conversation history
+	// is reported as a warning in the condition message.
+	ConversationCacheCondition condition.Type = "ConversationCache"
+
+	// OLSConfigSyncedCondition Status=True condition which indicates that OLS has processed the latest
+	// generation of the OLSConfig. Only set when the OLSConfig reports its observedGeneration.
+	OLSConfigSyncedCondition condition.Type = "OLSConfigSynced"
+
+	// RAGlessFallbackCondition Status=True condition which indicates that the RAGless fallback was
+	// evaluated. Running OLS without the RAG sources is reported as a warning in the condition message.
+	RAGlessFallbackCondition condition.Type = "RAGlessFallback"
+
+	// OLSConfigAPIVersionCondition Status=True condition which indicates that the OLSConfig CRD serves
+	// the OLSConfig API version we write. A version converted to another storage version is reported
+	// as a warning in the condition message.
+	OLSConfigAPIVersionCondition condition.Type = "OLSConfigAPIVersion"
+
+	// ModelDiscoveryCondition Status=True condition which indicates that the model and its endpoint
+	// were discovered from an InferenceService
+	ModelDiscoveryCondition condition.Type = "ModelDiscovery"
+
+	// UsageBudgetCondition Status=True condition which indicates that the PrometheusRule alerting on
+	// the token usage of OLS against the daily budget is in place
+	UsageBudgetCondition condition.Type = "UsageBudget"
+
+	// ReconcileProgressCondition Status=True condition which indicates that the instance was
+	// successfully reconciled recently. False when no reconcile succeeded for too long.
+	ReconcileProgressCondition condition.Type = "ReconcileProgress"
+)
+
+// Common Reasons used by API objects.
+const (
+	// OpenShiftLightspeedOperatorCSVForbiddenReason (Severity=Error) documents that the OpenShift
+	// Lightspeed operator CSV lives in a namespace where we are not allowed to update it
+	OpenShiftLightspeedOperatorCSVForbiddenReason condition.Reason = "CSVForbidden"
+
+	// OpenShiftLightspeedOperatorDowngradeReason (Severity=Error) documents that the installed
+	// OpenShift Lightspeed operator is newer than the recommended version
+	OpenShiftLightspeedOperatorDowngradeReason condition.Reason = "DowngradeRefused"
+
+	// OpenShiftLightspeedOperatorInstallPlanFailedReason (Severity=Error) documents that OLM failed to
+	// execute the InstallPlan of the OpenShift Lightspeed operator
+	OpenShiftLightspeedOperatorInstallPlanFailedReason condition.Reason = "InstallPlanFailed"
+
+	// OpenShiftLightspeedOperatorCSVStuckReason (Severity=Warning) documents that the OpenShift
+	// Lightspeed operator CSV has not left the Replacing or Pending phase in time
+	OpenShiftLightspeedOperatorCSVStuckReason condition.Reason = "CSVStuck"
+
+	// OpenShiftLightspeedOperatorCatalogReason (Severity=Warning) documents that the configured
+	// catalog does not offer the OpenShift Lightspeed operator the Subscription asks for
+	OpenShiftLightspeedOperatorCatalogReason condition.Reason = "CatalogMismatch"
+
+	// OpenShiftLightspeedOperatorCatalogSourceNotReadyReason (Severity=Warning) documents that the
+	// configured CatalogSource does not exist or is not READY, so the OLS operator is not installed yet.
+	OpenShiftLightspeedOperatorCatalogSourceNotReadyReason condition.Reason = "CatalogSourceNotReady"
+
+	// OLSConfigBehindReason (Severity=Warning) documents that OLS has not processed the latest
+	// generation of the OLSConfig in time
+	OLSConfigBehindReason condition.Reason = "OLSBehind"
+
+	// OLSConfigAPIVersionMismatchReason (Severity=Warning) documents that the OLSConfig CRD does not
+	// serve the OLSConfig API version we write
+	OLSConfigAPIVersionMismatchReason condition.Reason = "APIVersionMismatch"
+
+	// ReconcileStalledReason (Severity=Warning) documents that the instance was not successfully
+	// reconciled for too long
+	ReconcileStalledReason condition.Reason = "ReconcileStalled"
+
+	// WaitingForDependencyReason (Severity=Info) documents that the reconcile is paused until the
+	// resource referenced by WaitFor reports its condition as True
+	WaitingForDependencyReason condition.Reason = "WaitingForDependency"
+
+	// ModelNotFoundReason (Severity=Warning) documents that the model discovery found no ready
+	// InferenceService serving a model
+	ModelNotFoundReason condition.Reason = "ModelNotFound"
+
+	// LLMCredentialsMissingSecretReason (Severity=Warning) documents that the credentials secret of
+	// an LLM provider does not exist or lacks the keys its provider type expects
+	LLMCredentialsMissingSecretReason condition.Reason = "MissingSecret"
+
+	// ImageNotPinnedReason (Severity=Error) documents that the RAG image is not pinned by digest
+	// although the spec requires digest pinned images
+	ImageNotPinnedReason condition.Reason = "ImageNotPinned"
+
+	// OLSConfigConflictingReason (Severity=Error) documents that the OLSConfig is managed by another
+	// OpenStackLightspeed instance
+	OLSConfigConflictingReason condition.Reason = "Conflicting"
+
+	// RAGImageIncompatibleReason (Severity=Warning) documents that the RAG image is not compatible
+	// with the installed OpenShift Lightspeed operator version
+	RAGImageIncompatibleReason condition.Reason = "RAGImageIncompatible"
 )
 
 // Common Messages used by API objects.
@@ -37,15 +153,175 @@ const (
 	// OpenStackLightspeedReadyInitMessage
 	OpenStackLightspeedReadyInitMessage = "OpenStack Lightspeed not started"
 
+	// OpenStackLightspeedInvalidOLSConfigDefaultsMessage
+	OpenStackLightspeedInvalidOLSConfigDefaultsMessage = "Invalid OLSConfig defaults: %s"
+
+	// OpenStackLightspeedNoRAGSourcesMessage
+	OpenStackLightspeedNoRAGSourcesMessage = "Refusing to write the OLSConfig: %s"
+
+	// OpenStackLightspeedRegisteringFinalizerMessage
+	OpenStackLightspeedRegisteringFinalizerMessage = "Initializing: registering finalizer"
+
 	// OpenStackLightspeedReadyMessage
 	OpenStackLightspeedReadyMessage = "OpenStack Lightspeed created"
 
+	// OpenStackLightspeedDeletingMessage
+	OpenStackLightspeedDeletingMessage = "Deleting"
+
+	// OpenStackLightspeedForceDeletedMessage
+	OpenStackLightspeedForceDeletedMessage = "Deletion forced by the %s annotation, the OLSConfig " +
+		"and the OpenShift Lightspeed operator may be left behind"
+
+	// OpenStackLightspeedInvalidSpecMessage
+	OpenStackLightspeedInvalidSpecMessage = "Invalid OpenStackLightspeed spec: %s"
+
+	// OpenStackLightspeedImageNotPinnedMessage
+	OpenStackLightspeedImageNotPinnedMessage = "RAG image %s is not pinned by digest, " +
+		"requireDigestPinnedImages only accepts references of the form repo@sha256:<digest>"
+
+	// OpenStackLightspeedWaitingOLSConfigCRDMessage
+	OpenStackLightspeedWaitingOLSConfigCRDMessage = "Waiting for the OLSConfig CRD to be established"
+
+	// OpenStackLightspeedOLSConfigRejectedMessage
+	OpenStackLightspeedOLSConfigRejectedMessage = "OLSConfig was rejected by the OLSConfig CRD validation: %s"
+
+	// OpenStackLightspeedOLSConfigPrunedMessage
+	OpenStackLightspeedOLSConfigPrunedMessage = "OLSConfig fields were dropped by the API server: %s"
+
+	// OpenStackLightspeedOLSConfigConflictingMessage
+	OpenStackLightspeedOLSConfigConflictingMessage = "Waiting for the OLSConfig to be released: %s"
+
+	// OpenStackLightspeedOLSConfigWriteConflictMessage
+	OpenStackLightspeedOLSConfigWriteConflictMessage = "OLSConfig was modified concurrently, retrying the update"
+
 	// OpenStackLightspeedWaitingVectorDBMessage
 	OpenStackLightspeedWaitingVectorDBMessage = "Waiting for OpenStackLightspeed vector DB pod to become ready"
 
 	// OpenShiftLightspeedOperatorWaiting
 	OpenShiftLightspeedOperatorWaiting = "Waiting for the OpenShift Lightspeed operator to deploy."
 
+	// OpenShiftLightspeedOperatorCSVForbiddenMessage
+	OpenShiftLightspeedOperatorCSVForbiddenMessage = "%s. OpenStack Lightspeed operator can only manage an OpenShift Lightspeed operator installed in the %s namespace"
+
+	// OpenShiftLightspeedOperatorDowngradeMessage
+	OpenShiftLightspeedOperatorDowngradeMessage = "%s. Uninstall the OpenShift Lightspeed operator to install an older version"
+
+	// OpenShiftLightspeedOperatorInstallPlanFailedMessage
+	OpenShiftLightspeedOperatorInstallPlanFailedMessage = "%s. Delete the InstallPlan or the Subscription to let OLM retry the installation"
+
+	// OpenShiftLightspeedOperatorCSVStuckMessage
+	OpenShiftLightspeedOperatorCSVStuckMessage = "OpenShift Lightspeed operator CSV %s has been in the %s phase for more than %s. Enable deleteStuckOLSOperatorCSV to let OLM retry the upgrade"
+
+	// OpenShiftLightspeedOperatorCSVStuckDeletedMessage
+	OpenShiftLightspeedOperatorCSVStuckDeletedMessage = "OpenShift Lightspeed operator CSV %s has been in the %s phase for more than %s and was deleted to let OLM retry the upgrade"
+
+	// ConversationCacheInMemoryMessage
+	ConversationCacheInMemoryMessage = "OLS is restarted to apply the model change and its in-memory " +
+		"conversation cache will lose the conversation history. Use a persistent conversation cache to keep it"
+
+	// OLSConfigSyncedMessage
+	OLSConfigSyncedMessage = "OLS has processed the latest OLSConfig changes"
+
+	// OLSConfigSyncingMessage
+	OLSConfigSyncingMessage = "Waiting for OLS to process the latest OLSConfig changes"
+
+	// OLSConfigBehindMessage
+	OLSConfigBehindMessage = "OLS has not processed the latest OLSConfig changes for more than %s"
+
+	// RAGlessFallbackInactiveMessage
+	RAGlessFallbackInactiveMessage = "OLS uses the RAG sources"
+
+	// RAGlessFallbackActiveMessage
+	RAGlessFallbackActiveMessage = "The vector DB of RAG image %s did not become ready within %s. " +
+		"OLS answers without the OpenStack documentation until the RAG image is changed"
+
+	// OLSConfigAPIVersionMatchMessage
+	OLSConfigAPIVersionMatchMessage = "OLSConfig API version %s is the storage version of the OLSConfig CRD"
+
+	// OLSConfigAPIVersionConvertedMessage
+	OLSConfigAPIVersionConvertedMessage = "OLSConfig API version %s is converted to the storage version %s " +
+		"of the OLSConfig CRD. Fields that %s does not know might be dropped by the conversion"
+
+	// OLSConfigAPIVersionMismatchMessage
+	OLSConfigAPIVersionMismatchMessage = "OLSConfig API version %s is not served by the OLSConfig CRD, " +
+		"which serves %v. Set OLS_CONFIG_API_VERSION to one of the served versions"
+
+	// DependencyReadyMessage
+	DependencyReadyMessage = "%s %s reports %s"
+
+	// DependencyNotFoundMessage
+	DependencyNotFoundMessage = "Waiting for %s %s to be created"
+
+	// DependencyNotReadyMessage
+	DependencyNotReadyMessage = "Waiting for %s %s to report %s"
+
+	// DependencyErrorMessage
+	DependencyErrorMessage = "Unable to check %s %s: %s"
+
+	// ModelDiscoveredMessage
+	ModelDiscoveredMessage = "Model %s served at %s was discovered from InferenceService %s"
+
+	// ModelNotFoundMessage
+	ModelNotFoundMessage = "No ready InferenceService serving a model was found. Deploy a model or set " +
+		"modelName and llmEndpoint"
+
+	// OpenStackLightspeedLLMCredentialsMissingMessage
+	OpenStackLightspeedLLMCredentialsMissingMessage = "LLM credentials secret %s of provider %s not found in namespace %s"
+
+	// OpenStackLightspeedLLMCredentialsKeysMissingMessage
+	OpenStackLightspeedLLMCredentialsKeysMissingMessage = "LLM credentials secret %s of provider %s in namespace %s is missing the keys %v"
+
+	// OpenStackLightspeedConversationCacheSecretMissingMessage
+	OpenStackLightspeedConversationCacheSecretMissingMessage = "Conversation cache credentials secret %s not found " +
+		"in namespace %s"
+
+	// OpenStackLightspeedAPITLSSecretMissingMessage
+	OpenStackLightspeedAPITLSSecretMissingMessage = "API TLS secret %s not found in namespace %s"
+
+	// OpenStackLightspeedAPITLSSecretKeysMissingMessage
+	OpenStackLightspeedAPITLSSecretKeysMissingMessage = "API TLS secret %s in namespace %s is missing the keys %v"
+
+	// ReconcileProgressingMessage
+	ReconcileProgressingMessage = "Reconciled successfully"
+
+	// ReconcileStalledMessage
+	ReconcileStalledMessage = "No successful reconcile since %s"
+
+	// OpenStackLightspeedOpenStackContextMissingMessage
+	OpenStackLightspeedOpenStackContextMissingMessage = "OpenStack context ConfigMap %s not found in namespace %s"
+
+	// OpenStackLightspeedOpenStackContextKeysMissingMessage
+	OpenStackLightspeedOpenStackContextKeysMissingMessage = "OpenStack context ConfigMap %s in namespace %s is missing " +
+		"the keys %v"
+
+	// UsageBudgetMessage
+	UsageBudgetMessage = "Alerting when OLS uses more than %d of the %d tokens of the daily budget"
+
+	// UsageBudgetMonitoringMissingMessage
+	UsageBudgetMonitoringMissingMessage = "The usage budget is not alerted on, the cluster does not serve " +
+		"the PrometheusRule API"
+
+	// OpenShiftLightspeedOperatorSubscriptionFailedMessage
+	OpenShiftLightspeedOperatorSubscriptionFailedMessage = "OpenShift Lightspeed operator Subscription %s reports %s: %s"
+
+	// OpenShiftLightspeedOperatorCatalogMessage
+	OpenShiftLightspeedOperatorCatalogMessage = "OpenShift Lightspeed operator cannot be installed from the catalog: %s"
+
+	// OLSPackageNotFoundMessage
+	OLSPackageNotFoundMessage = "package %s not found in catalog %s"
+
+	// OLSPackageChannelNotFoundMessage
+	OLSPackageChannelNotFoundMessage = "channel %s not found in catalog %s"
+
+	// OLSPackageVersionNotFoundMessage
+	OLSPackageVersionNotFoundMessage = "recommended version %s not found in channel %s of catalog %s"
+
+	// OpenShiftLightspeedOperatorExternalWaiting
+	OpenShiftLightspeedOperatorExternalWaiting = "Waiting for the externally managed OpenShift Lightspeed operator to deploy."
+
+	// OpenShiftLightspeedOperatorExternalReady
+	OpenShiftLightspeedOperatorExternalReady = "Externally managed OpenShift Lightspeed operator is ready."
+
 	// OpenShiftLightspeedOperatorReady
 	OpenShiftLightspeedOperatorReady = "OpenShift Lightspeed operator is ready."
 
@@ -55,12 +331,58 @@ const (
 	// OCPRAGVersionResolvedMessage
 	OCPRAGVersionResolvedMessage = "OCP RAG version resolved: %s"
 
+	// OCPRAGVersionUnsupportedMessage
+	OCPRAGVersionUnsupportedMessage = "Cluster version %s is not supported by the OCP documentation and ocpRAGFallbackBehavior is Reject. Supported versions: %v"
+
 	// OCPRAGVersionFallbackMessage
 	OCPRAGVersionFallbackMessage = "Cluster version %s is not explicitly supported. Using 'latest' OCP documentation. Supported versions: %v"
 
+	// OCPRAGVersionUnparsableMessage
+	OCPRAGVersionUnparsableMessage = "Cluster version %q could not be parsed. Using 'latest' OCP documentation"
+
+	// OCPRAGPreReleaseSkippedMessage
+	OCPRAGPreReleaseSkippedMessage = "OCP RAG is disabled for the pre-release cluster version %s"
+
 	// OCPRAGDetectionFailedMessage
 	OCPRAGDetectionFailedMessage = "Failed to detect OCP cluster version"
 
+	// ConsoleAvailableMessage
+	ConsoleAvailableMessage = "OpenShift console is available"
+
+	// ConsoleUnavailableMessage
+	ConsoleUnavailableMessage = "OpenShift console is not available, OLS readiness does not wait for " +
+		"the console plugin"
+
+	// ConsoleDetectionFailedMessage
+	ConsoleDetectionFailedMessage = "Unable to check the OpenShift console availability, assuming it is " +
+		"available: %s"
+
+	// RAGImageCompatibleMessage
+	RAGImageCompatibleMessage = "RAG image %s is compatible with OpenShift Lightspeed operator %s"
+
+	// RAGImageIncompatibleMessage
+	RAGImageIncompatibleMessage = "RAG image %s is not compatible with OpenShift Lightspeed operator %s. " +
+		"Compatible OpenShift Lightspeed operator versions: %s"
+
+	// RAGImageCompatibilityUnknownMessage
+	RAGImageCompatibilityUnknownMessage = "Unable to check the compatibility of the RAG image %s: %s"
+
+	// RAGImageArchitectureSupportedMessage
+	RAGImageArchitectureSupportedMessage = "RAG image %s supports the architectures of the cluster nodes"
+
+	// RAGImageArchitectureMismatchMessage
+	RAGImageArchitectureMismatchMessage = "RAG image %s is built for %v and will likely not run on the " +
+		"cluster nodes with architecture %v"
+
+	// RAGImageArchitectureUnknownMessage
+	RAGImageArchitectureUnknownMessage = "Unable to check the architectures of the RAG image %s: %s"
+
+	// RAGImageReadyMessage
+	RAGImageReadyMessage = "RAG image is ready"
+
+	// RAGImagePullErrorMessage
+	RAGImagePullErrorMessage = "RAG image %s cannot be pulled for pod %s: %s"
+
 	// OCPRAGOverrideInvalidMessage
 	OCPRAGOverrideInvalidMessage = "Invalid OCP RAG version override"
 )
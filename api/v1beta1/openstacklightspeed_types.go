@@ -17,9 +17,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
+
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
@@ -30,6 +34,60 @@ const (
 	MaxTokensForResponseDefault       = 2048
 )
 
+const (
+	// LLMShorthandProviderName - name of the provider described by the deprecated LLMEndpoint,
+	// LLMEndpointType, ModelName and LLMCredentials fields
+	LLMShorthandProviderName = "openstack-lightspeed-provider"
+)
+
+const (
+	// OCPRAGFallbackBehaviorFallback - use the latest OCP documentation for unsupported OCP versions
+	OCPRAGFallbackBehaviorFallback = "Fallback"
+
+	// OCPRAGFallbackBehaviorReject - reject the OCP RAG configuration for unsupported OCP versions
+	OCPRAGFallbackBehaviorReject = "Reject"
+
+	// OpenStackContextDefaultKey - key of the OpenStack context used when the reference sets none
+	OpenStackContextDefaultKey = "context"
+
+	// ConversationCacheTypeMemory and ConversationCacheTypePostgres - backends of the conversation
+	// cache
+	ConversationCacheTypeMemory   = "memory"
+	ConversationCacheTypePostgres = "postgres"
+
+	// DependencyDefaultConditionType - condition type of the WaitFor dependency used when the
+	// reference sets none
+	DependencyDefaultConditionType = "Ready"
+
+	// LogLevelDebug, LogLevelInfo, LogLevelWarning and LogLevelError - verbosities of the OLS logs
+	LogLevelDebug   = "debug"
+	LogLevelInfo    = "info"
+	LogLevelWarning = "warning"
+	LogLevelError   = "error"
+)
+
+const (
+	// OLSLifecycleModeManage - the operator installs, upgrades and uninstalls the OLS operator
+	OLSLifecycleModeManage = "Manage"
+
+	// OLSLifecycleModeExternal - the OLS operator is installed and removed by someone else, only the
+	// OLSConfig is managed
+	OLSLifecycleModeExternal = "External"
+)
+
+const (
+	// InstallPlanApprovalImmediate - approve the OLS operator InstallPlan as soon as the Subscription
+	// references one
+	InstallPlanApprovalImmediate = "Immediate"
+
+	// InstallPlanApprovalWaitForStableRef - approve the OLS operator InstallPlan only once the
+	// Subscription references the same InstallPlan in two consecutive reconciles
+	InstallPlanApprovalWaitForStableRef = "WaitForStableRef"
+)
+
+// OLSConditionTypes lists the status condition types reported by OLS in the OLSConfig
+var OLSConditionTypes = []string{"ApiReady", "CacheReady", "ConsolePluginReady", "Reconciled"}
+
 // OpenStackLightspeedSpec defines the desired state of OpenStackLightspeed
 type OpenStackLightspeedSpec struct {
 	OpenStackLightspeedCore `json:",inline"`
@@ -38,6 +96,17 @@ type OpenStackLightspeedSpec struct {
 	// ContainerImage for the OpenStack Lightspeed RAG container (will be set to environmental default if empty)
 	RAGImage string `json:"ragImage"`
 
+	// +kubebuilder:validation:Optional
+	// RAGImagePullSecret is the name of a secret in the openshift-lightspeed namespace holding the
+	// credentials to pull the RAG image, e.g. from the internal registry of a disconnected cluster.
+	RAGImagePullSecret string `json:"ragImagePullSecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RequireDigestPinnedImages rejects a RAGImage that is not referenced by its sha256 digest, e.g.
+	// quay.io/openstack-lightspeed/rag-content@sha256:<digest>, so that a retagged image is never
+	// deployed. Mutable tags are accepted when false.
+	RequireDigestPinnedImages bool `json:"requireDigestPinnedImages,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	// Enables automatic OCP documentation based on cluster version
@@ -47,31 +116,385 @@ type OpenStackLightspeedSpec struct {
 	// Allows forcing a specific OCP version instead of auto-detection.
 	// Format should be like "4.15", "4.16", etc.
 	OCPRAGVersionOverride string `json:"ocpVersionOverride,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Fallback;Reject
+	// +kubebuilder:default=Fallback
+	// OCPRAGFallbackBehavior defines what happens when OCP RAG is enabled on a cluster whose OCP
+	// version has no documentation in the RAG image. "Fallback" uses the latest OCP documentation
+	// and warns on admission, "Reject" refuses the OpenStackLightspeed instance on admission and
+	// disables the OCP documentation when the cluster is upgraded to an unsupported version later.
+	OCPRAGFallbackBehavior string `json:"ocpRAGFallbackBehavior,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OCPRAGSkipPreRelease disables the OCP documentation on clusters running a pre-release OCP build
+	// (e.g. nightly, ec or rc builds), whose documentation rarely matches any published version.
+	// Ignored when OCPRAGVersionOverride is set.
+	OCPRAGSkipPreRelease bool `json:"ocpRAGSkipPreRelease,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// RequiredOLSConditions lists the OLSConfig status conditions ("ApiReady", "CacheReady",
+	// "ConsolePluginReady" or "Reconciled") that must be True for OLS to be considered ready. The
+	// overall status reported by OLS is used when empty. ConsolePluginReady is left out on clusters
+	// without the OpenShift console.
+	RequiredOLSConditions []string `json:"requiredOLSConditions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^\S+$`
+	// OLSSubscriptionChannel is the Subscription channel the OLS operator is installed from, e.g.
+	// "candidate" or "fast" to install a pre-release OLS operator. Defaults to "stable".
+	OLSSubscriptionChannel string `json:"olsSubscriptionChannel,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// ManageOLSConfigFinalizer controls whether the operator adds its finalizer to the OLSConfig and
+	// deletes the OLSConfig together with the OpenStackLightspeed instance. Disable it when the
+	// OLSConfig lifecycle is managed externally (e.g. by a GitOps tool).
+	ManageOLSConfigFinalizer *bool `json:"manageOLSConfigFinalizer,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// DeleteStuckOLSOperatorCSV allows the operator to delete the OLS operator CSV it installed when
+	// an upgrade leaves it in the Replacing or Pending phase for too long, so that OLM retries the
+	// upgrade. The stuck CSV is only reported in the status conditions when disabled.
+	DeleteStuckOLSOperatorCSV bool `json:"deleteStuckOLSOperatorCSV,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// AllowRAGlessFallback lets OLS answer without the OpenStack documentation when the pods loading
+	// the vector DB from the RAG image do not become ready in time. The RAG sources are removed from
+	// the OLSConfig until the RAG image is changed or the fallback is disabled.
+	AllowRAGlessFallback bool `json:"allowRAGlessFallback,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// DisableRAG makes OLS answer without any RAG source. Without it, the operator refuses to write
+	// an OLSConfig without RAG sources. Mutually exclusive with RAGImage and EnableOCPRAG.
+	DisableRAG bool `json:"disableRAG,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NodeSelector restricts the OLS API pods to the nodes with these labels
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Tolerations let the OLS API pods run on tainted nodes, e.g. nodes dedicated to OLS
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=debug;info;warning;error
+	// +kubebuilder:default=info
+	// LogLevel is the verbosity of the OLS logs, e.g. "debug" while troubleshooting OLS
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Replicas is the number of OLS API pods. Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// APIResources defines the compute resources of the OLS API containers. OLS applies its own
+	// defaults when unset.
+	APIResources *corev1.ResourceRequirements `json:"apiResources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ConsoleResources defines the compute resources of the OLS console plugin containers. OLS
+	// applies its own defaults when unset.
+	ConsoleResources *corev1.ResourceRequirements `json:"consoleResources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AdditionalModels lists further models served by the LLM provider next to ModelName. A model
+	// can override the provider URL when the gateway exposes it at its own path.
+	AdditionalModels []ProviderModel `json:"additionalModels,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Providers lists the LLM providers written into the OLSConfig, e.g. a primary and a fallback
+	// provider. The provider described by the deprecated LLMEndpoint, LLMEndpointType, ModelName and
+	// LLMCredentials fields is kept as the first provider when ModelName is set.
+	Providers []ProviderSpec `json:"providers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DefaultProvider is the name of the provider that answers the queries. Defaults to the first
+	// provider.
+	DefaultProvider string `json:"defaultProvider,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// QueryFilters redact the user queries before OLS logs them or sends them to the LLM, e.g. to
+	// keep personal data out of the logs. The queries are left as they are when empty.
+	QueryFilters []QueryFilter `json:"queryFilters,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ConversationCache selects the backend of the conversation history kept by OLS. OLS applies its
+	// own defaults when unset.
+	ConversationCache *ConversationCache `json:"conversationCache,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// UsageBudget is the number of tokens OLS is expected to use per day. The operator installs a
+	// PrometheusRule alerting when the usage crosses the alert threshold, the usage is not limited.
+	// No alert is installed when unset.
+	UsageBudget *UsageBudget `json:"usageBudget,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// APITLS serves the OLS API with a custom certificate instead of the service serving certificate.
+	// It is unrelated to TLSCACertBundle, which is trusted when connecting to the LLM endpoint.
+	APITLS *APITLS `json:"apiTLS,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// OpenStackContextRef points at a ConfigMap in the namespace of the instance describing the
+	// OpenStack deployment, e.g. the services it runs. The description is added to the system prompt
+	// of OLS, which anyone allowed to read the OLSConfig can see, so it must not hold secrets.
+	OpenStackContextRef *OpenStackContextRef `json:"openStackContextRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// OLSConfigOverlay is deep-merged into the OLSConfig to set OLSConfig fields the operator does not
+	// expose. Only the spec of the OLSConfig can be set. The overlay is skipped for the fields set by
+	// this instance. Fields removed from the overlay are removed from the OLSConfig.
+	OLSConfigOverlay *runtime.RawExtension `json:"olsConfigOverlay,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AutoDiscoverModel lets the operator pick the model and its endpoint from the ready RHOAI
+	// InferenceServices of the cluster when ModelName or LLMEndpoint is empty. An InferenceService
+	// annotated with openstack.org/lightspeed-default-model=true is preferred.
+	AutoDiscoverModel bool `json:"autoDiscoverModel,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// WaitFor pauses the reconcile until the referenced resource reports a condition as True, e.g.
+	// until the InferenceService serving the model is ready. OLS is neither installed nor configured
+	// before. Only InferenceServices in the namespace of the instance can be referenced.
+	WaitFor *DependencyRef `json:"waitFor,omitempty"`
+}
+
+// APITLS defines the certificate the OLS API is served with
+type APITLS struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// SecretName is the name of the secret in the openshift-lightspeed namespace holding the
+	// certificate and its key under the "tls.crt" and "tls.key" keys. When the certificate is not
+	// signed by a CA the clients trust, "tls.crt" can hold the full chain, the certificate followed
+	// by the intermediate CA certificates.
+	SecretName string `json:"secretName"`
+}
+
+// UsageBudget defines the daily token budget OLS usage is alerted against
+type UsageBudget struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// TokensPerDay is the number of tokens OLS is expected to use per day
+	TokensPerDay int64 `json:"tokensPerDay"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=80
+	// AlertThresholdPercent is the percentage of TokensPerDay above which the usage is alerted on
+	AlertThresholdPercent int32 `json:"alertThresholdPercent,omitempty"`
+}
+
+// QueryFilter replaces the parts of the user queries matching Pattern with ReplaceWith
+type QueryFilter struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Name of the filter
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Regular expression (RE2 syntax) matched against the query
+	Pattern string `json:"pattern"`
+
+	// +kubebuilder:validation:Optional
+	// Text replacing the matches, e.g. "[REDACTED]". The matches are removed when empty.
+	ReplaceWith string `json:"replaceWith,omitempty"`
+}
+
+// ConversationCache configures the backend of the conversation history
+type ConversationCache struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=memory;postgres
+	// Type selects the backend keeping the conversation history: the memory of the OLS pods
+	// ("memory") or PostgreSQL ("postgres"). The backend is left to OLS when unset.
+	Type string `json:"type,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// CredentialsSecretRef is the name of the secret in the openshift-lightspeed namespace holding the
+	// PostgreSQL credentials. Required when Type is "postgres".
+	CredentialsSecretRef string `json:"credentialsSecretRef,omitempty"`
+}
+
+// ProviderModel is a model served by the LLM provider
+type ProviderModel struct {
+	// +kubebuilder:validation:Required
+	// Name of the model
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// URL of the model endpoint. Defaults to LLMEndpoint when empty.
+	URL string `json:"url,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Parameters of the model. OLS applies its own defaults to the parameters that are not set.
+	Parameters *ModelParameters `json:"parameters,omitempty"`
+}
+
+// ModelParameters defines the parameters of an LLM model. Unset fields keep the OLS defaults.
+type ModelParameters struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Number of tokens the context window of the model holds
+	ContextWindowSize *int32 `json:"contextWindowSize,omitempty"`
+}
+
+// ProviderSpec defines an LLM provider and the models it serves
+type ProviderSpec struct {
+	// +kubebuilder:validation:Required
+	// Name of the provider, referenced by DefaultProvider
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=azure_openai;bam;openai;watsonx;rhoai_vllm;rhelai_vllm;fake_provider
+	// Type of the provider serving the models
+	Type string `json:"type"`
+
+	// +kubebuilder:validation:Required
+	// URL pointing to the provider
+	URL string `json:"url"`
+
+	// +kubebuilder:validation:Required
+	// Secret name containing API token for the provider. The secret must contain a field named
+	// "apitoken" which holds the token value.
+	CredentialsSecret string `json:"credentialsSecret"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// Models served by the provider. The first model is the default model of the provider.
+	Models []ProviderModel `json:"models"`
+
+	// +kubebuilder:validation:Optional
+	// Project ID for providers that require it (e.g., WatsonX)
+	ProjectID string `json:"projectID,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Deployment name for providers that require it (e.g., Microsoft Azure OpenAI)
+	DeploymentName string `json:"deploymentName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// API Version for providers that require it (e.g., Microsoft Azure OpenAI)
+	APIVersion string `json:"apiVersion,omitempty"`
+}
+
+// ProviderNames returns the names of the LLM providers configured in the spec, starting with the
+// provider described by the deprecated LLM fields when ModelName is set
+func (spec *OpenStackLightspeedSpec) ProviderNames() []string {
+	names := []string{}
+	if spec.ModelName != "" {
+		names = append(names, LLMShorthandProviderName)
+	}
+
+	for _, provider := range spec.Providers {
+		names = append(names, provider.Name)
+	}
+
+	return names
+}
+
+// GetOLSConfigOverlay returns the OLSConfig overlay as an unstructured object. It returns nil when
+// no overlay is set.
+func (spec *OpenStackLightspeedSpec) GetOLSConfigOverlay() (map[string]interface{}, error) {
+	if spec.OLSConfigOverlay == nil || len(spec.OLSConfigOverlay.Raw) == 0 {
+		return nil, nil
+	}
+
+	overlay := map[string]interface{}{}
+	if err := json.Unmarshal(spec.OLSConfigOverlay.Raw, &overlay); err != nil {
+		return nil, err
+	}
+
+	return overlay, nil
+}
+
+// OpenStackContextRef references the ConfigMap describing the OpenStack deployment
+type OpenStackContextRef struct {
+	// +kubebuilder:validation:Required
+	// Name of the referenced ConfigMap
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=context
+	// Key holding the description of the OpenStack deployment
+	Key string `json:"key,omitempty"`
+}
+
+// GetKey returns the key holding the description of the OpenStack deployment
+func (ref *OpenStackContextRef) GetKey() string {
+	if ref.Key == "" {
+		return OpenStackContextDefaultKey
+	}
+	return ref.Key
+}
+
+// DependencyRef references a resource in the namespace of the OpenStackLightspeed instance whose
+// condition must be True before OLS is configured
+type DependencyRef struct {
+	// +kubebuilder:validation:Required
+	// APIVersion of the referenced resource, e.g. "serving.kserve.io/v1beta1"
+	APIVersion string `json:"apiVersion"`
+
+	// +kubebuilder:validation:Required
+	// Kind of the referenced resource, e.g. "InferenceService"
+	Kind string `json:"kind"`
+
+	// +kubebuilder:validation:Required
+	// Name of the referenced resource
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Ready
+	// ConditionType is the type of the status condition that must be True
+	ConditionType string `json:"conditionType,omitempty"`
+}
+
+// GetConditionType returns the type of the status condition the dependency must report as True
+func (ref *DependencyRef) GetConditionType() string {
+	if ref.ConditionType == "" {
+		return DependencyDefaultConditionType
+	}
+	return ref.ConditionType
 }
 
 // OpenStackLightspeedCore defines the desired state of OpenStackLightspeed
 type OpenStackLightspeedCore struct {
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="LLM Endpoint"
-	// URL pointing to the LLM
+	// URL pointing to the LLM. Required unless AutoDiscoverModel is set or the LLM providers are set
+	// in Providers.
+	// Prefer Providers, this field is kept as a shorthand for the first provider.
 	LLMEndpoint string `json:"llmEndpoint"`
 
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Enum=azure_openai;bam;openai;watsonx;rhoai_vllm;rhelai_vllm;fake_provider
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Provider Type"
-	// Type of the provider serving the LLM
-	LLMEndpointType string `json:"llmEndpointType"`
+	// Type of the provider serving the LLM. Required unless the LLM providers are set in Providers.
+	// Prefer Providers, this field is kept as a shorthand for the first provider.
+	LLMEndpointType string `json:"llmEndpointType,omitempty"`
 
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Model Name"
-	// Name of the model to use at the API endpoint provided in LLMEndpoint
+	// Name of the model to use at the API endpoint provided in LLMEndpoint. Required unless
+	// AutoDiscoverModel is set or the LLM providers are set in Providers.
+	// Prefer Providers, this field is kept as a shorthand for the first provider.
 	ModelName string `json:"modelName"`
 
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="LLM Credentials Secret"
 	// Secret name containing API token for the LLMEndpoint. The secret must contain
-	// a field named "apitoken" which holds the token value.
-	LLMCredentials string `json:"llmCredentials"`
+	// a field named "apitoken" which holds the token value. Required unless the LLM providers are
+	// set in Providers.
+	// Prefer Providers, this field is kept as a shorthand for the first provider.
+	LLMCredentials string `json:"llmCredentials,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS CA Certificate Bundle"
@@ -79,18 +502,41 @@ type OpenStackLightspeedCore struct {
 	TLSCACertBundle string `json:"tlsCACertBundle"`
 
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
 	// MaxTokensForResponse defines the maximum number of tokens to be used for the response generation
 	MaxTokensForResponse int `json:"maxTokensForResponse,omitempty"`
 
-	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:default="openshift-marketplace"
-	// Namespace where the CatalogSource containing the OLS operator is located
-	CatalogSourceNamespace string `json:"catalogSourceNamespace"`
+	// Namespace where the CatalogSource containing the OLS operator is located. Point it to the
+	// namespace of the mirrored catalog in disconnected clusters.
+	CatalogSourceNamespace string `json:"catalogSourceNamespace,omitempty"`
 
-	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:default="redhat-operators"
-	// Name of the CatalogSource that contains the OLS Operator
-	CatalogSourceName string `json:"catalogSourceName"`
+	// Name of the CatalogSource that contains the OLS Operator. Point it to the mirrored catalog in
+	// disconnected clusters. The CatalogSource has to be READY before the OLS operator is installed.
+	CatalogSourceName string `json:"catalogSourceName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Manage;External
+	// +kubebuilder:default=Manage
+	// OLSLifecycleMode defines who manages the OLS operator. "Manage" installs and uninstalls it
+	// through OLM. "External" leaves the OLS operator to another operator and only configures the
+	// OLSConfig. An OLS operator CSV annotated with openstack.org/ols-externally-managed=true is
+	// treated as "External" as well.
+	OLSLifecycleMode string `json:"olsLifecycleMode,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Immediate;WaitForStableRef
+	// +kubebuilder:default=Immediate
+	// InstallPlanApprovalStrategy defines when the OLS operator InstallPlan is approved. "Immediate"
+	// approves it as soon as the Subscription references one. "WaitForStableRef" defers the
+	// approval until the Subscription references the same InstallPlan in two consecutive
+	// reconciles, i.e. while OLM is still replacing the referenced InstallPlan.
+	InstallPlanApprovalStrategy string `json:"installPlanApprovalStrategy,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	// Project ID for LLM providers that require it (e.g., WatsonX)
@@ -104,6 +550,11 @@ type OpenStackLightspeedCore struct {
 	// LLM API Version for LLM providers that require it (e.g., Microsoft Azure OpenAI)
 	LLMAPIVersion string `json:"llmAPIVersion,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// ModelParameters defines the parameters of ModelName. OLS applies its own defaults to the
+	// parameters that are not set.
+	ModelParameters *ModelParameters `json:"modelParameters,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// Disable feedback collection
 	FeedbackDisabled bool `json:"feedbackDisabled,omitempty"`
@@ -111,6 +562,12 @@ type OpenStackLightspeedCore struct {
 	// +kubebuilder:validation:Optional
 	// Disable conversation transcripts collection
 	TranscriptsDisabled bool `json:"transcriptsDisabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DisableDataCollection disables the collection of both the feedback and the conversation
+	// transcripts, e.g. in regulated environments that must not ship usage data. It takes
+	// precedence over FeedbackDisabled and TranscriptsDisabled.
+	DisableDataCollection bool `json:"disableDataCollection,omitempty"`
 }
 
 // OpenStackLightspeedStatus defines the observed state of OpenStackLightspeed
@@ -118,6 +575,11 @@ type OpenStackLightspeedStatus struct {
 	// Conditions
 	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
 
+	// +optional
+	// Message summarizes the status of the instance: the message of the sub-condition that is not
+	// ready yet, or the ready message
+	Message string `json:"message,omitempty"`
+
 	// ObservedGeneration - the most recent generation observed for this object.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
@@ -125,12 +587,69 @@ type OpenStackLightspeedStatus struct {
 	// ActiveOCPRAGVersion contains the OCP version being used for RAG configuration
 	// Will be one of: "4.16", "4.18", "latest", or empty if OCP RAG is disabled
 	ActiveOCPRAGVersion string `json:"activeOCPRAGVersion,omitempty"`
+
+	// +optional
+	// CurrentModel contains the name of the model that was last written into the OLSConfig
+	CurrentModel string `json:"currentModel,omitempty"`
+
+	// +optional
+	// ObservedInstallPlanRef contains the name of the OLS operator InstallPlan the Subscription
+	// referenced in the last reconcile
+	ObservedInstallPlanRef string `json:"observedInstallPlanRef,omitempty"`
+
+	// +optional
+	// OLSConfigGeneration contains the generation of the OLSConfig after our last patch
+	OLSConfigGeneration int64 `json:"olsConfigGeneration,omitempty"`
+
+	// +optional
+	// OLSConfigObservedGeneration contains the most recent OLSConfig generation processed by OLS,
+	// as reported in the OLSConfig status
+	OLSConfigObservedGeneration int64 `json:"olsConfigObservedGeneration,omitempty"`
+
+	// +optional
+	// RAGlessFallbackImage contains the RAG image whose vector DB could not be loaded in time. OLS
+	// runs without the RAG sources while it matches the RAG image and AllowRAGlessFallback is set.
+	RAGlessFallbackImage string `json:"ragLessFallbackImage,omitempty"`
+
+	// +optional
+	// ManagedResourceCount contains the number of resources the operator created for this instance
+	// (Subscription, CSV, OLSConfig and ConfigMaps)
+	ManagedResourceCount int `json:"managedResourceCount,omitempty"`
+
+	// +optional
+	// LastSuccessfulReconcileTime contains the time of the last reconcile that completed without
+	// waiting for anything. Refreshed at most once a minute.
+	LastSuccessfulReconcileTime *metav1.Time `json:"lastSuccessfulReconcileTime,omitempty"`
+
+	// +optional
+	// RAGBuildInfo contains the build metadata of the RAG image, read from its image labels. Unset
+	// when no RAG image is used or its labels could not be retrieved.
+	RAGBuildInfo *RAGBuildInfo `json:"ragBuildInfo,omitempty"`
+}
+
+// RAGBuildInfo describes the build of the RAG image, i.e. the vintage of the documentation in its
+// vector DB
+type RAGBuildInfo struct {
+	// Image is the RAG image the build metadata was read from
+	Image string `json:"image"`
+
+	// +optional
+	// Version of the RAG content
+	Version string `json:"version,omitempty"`
+
+	// +optional
+	// Revision is the source commit the RAG image was built from
+	Revision string `json:"revision,omitempty"`
+
+	// +optional
+	// BuiltAt is the time the RAG image was built
+	BuiltAt *metav1.Time `json:"builtAt,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
-// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Ready"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Message"
 // +operator-sdk:csv:customresourcedefinitions:resources={{OLSConfig,v1alpha1,cluster}}
 // +operator-sdk:csv:customresourcedefinitions:resources={{Subscription,v1alpha1}}
 // +operator-sdk:csv:customresourcedefinitions:resources={{ClusterServiceVersion,v1alpha1}}
@@ -0,0 +1,506 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// imageReferenceRegexp matches container image references of the form
+// [registry[:port]/]repository[:tag][@digest].
+var imageReferenceRegexp = regexp.MustCompile(
+	`^(?:(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*(?::[0-9]+)?/)?` +
+		`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+		`(?::[\w][\w.-]{0,127})?` +
+		`(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?$`)
+
+// ocpVersionOverrideRegexp matches the OCP documentation versions that can be forced: a
+// major.minor version or "latest".
+var ocpVersionOverrideRegexp = regexp.MustCompile(`^(?:[0-9]+\.[0-9]+|latest)$`)
+
+// KnownDependencyKinds lists the kinds WaitFor can reference. The operator is only allowed to get
+// these kinds.
+var KnownDependencyKinds = []schema.GroupKind{
+	{Group: "serving.kserve.io", Kind: "InferenceService"},
+}
+
+// KnownLLMEndpointTypes lists the types of the providers serving the LLM
+var KnownLLMEndpointTypes = []string{
+	"azure_openai",
+	"bam",
+	"openai",
+	"watsonx",
+	"rhoai_vllm",
+	"rhelai_vllm",
+	"fake_provider",
+}
+
+// KnownLogLevels lists the verbosities of the OLS logs
+var KnownLogLevels = []string{LogLevelDebug, LogLevelInfo, LogLevelWarning, LogLevelError}
+
+// specMutualExclusions lists the pairs of spec fields that cannot be set together. Each field is
+// given as its path below the spec.
+var specMutualExclusions = []struct {
+	field []string
+	other []string
+	isSet func(spec *OpenStackLightspeedSpec) (bool, bool)
+}{
+	{
+		field: []string{"disableRAG"},
+		other: []string{"ragImage"},
+		isSet: func(spec *OpenStackLightspeedSpec) (bool, bool) {
+			return spec.DisableRAG, spec.RAGImage != ""
+		},
+	},
+	{
+		field: []string{"disableRAG"},
+		other: []string{"enableOCPRAG"},
+		isSet: func(spec *OpenStackLightspeedSpec) (bool, bool) {
+			return spec.DisableRAG, spec.EnableOCPRAG
+		},
+	},
+}
+
+// ValidateMutualExclusions - validates that no pair of mutually exclusive spec fields is set. See
+// specMutualExclusions.
+func (spec *OpenStackLightspeedSpec) ValidateMutualExclusions(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, exclusion := range specMutualExclusions {
+		if fieldSet, otherSet := exclusion.isSet(spec); fieldSet && otherSet {
+			fieldPath := basePath.Child(exclusion.field[0], exclusion.field[1:]...)
+			allErrs = append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("%s cannot be combined with %s",
+				strings.Join(exclusion.field, "."), strings.Join(exclusion.other, "."))))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateAdmission - validates the parts of the spec that the admission webhook rejects: the mutual
+// exclusions, the LLM endpoint and its type, the response token limit and the OCP documentation
+// version override. They are also part of ValidateSpec for the clusters without the webhook.
+func (spec *OpenStackLightspeedSpec) ValidateAdmission(basePath *field.Path) field.ErrorList {
+	allErrs := spec.ValidateMutualExclusions(basePath)
+
+	if spec.LLMEndpoint != "" {
+		allErrs = append(allErrs, validateHTTPURL(spec.LLMEndpoint, basePath.Child("llmEndpoint"))...)
+	}
+
+	if spec.LLMEndpointType != "" && !slices.Contains(KnownLLMEndpointTypes, spec.LLMEndpointType) {
+		allErrs = append(allErrs, field.NotSupported(basePath.Child("llmEndpointType"),
+			spec.LLMEndpointType, KnownLLMEndpointTypes))
+	}
+
+	if spec.MaxTokensForResponse < 0 {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("maxTokensForResponse"),
+			spec.MaxTokensForResponse, "must not be negative"))
+	}
+
+	if spec.OCPRAGVersionOverride != "" && !ocpVersionOverrideRegexp.MatchString(spec.OCPRAGVersionOverride) {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("ocpVersionOverride"),
+			spec.OCPRAGVersionOverride, `must be a major.minor version such as "4.18" or "latest"`))
+	}
+
+	return allErrs
+}
+
+// ValidateSpec - validates the parts of the OpenStackLightspeed spec that cannot be expressed
+// through kubebuilder validation markers.
+func (spec *OpenStackLightspeedSpec) ValidateSpec(basePath *field.Path) field.ErrorList {
+	allErrs := spec.ValidateAdmission(basePath)
+
+	if spec.LogLevel != "" && !slices.Contains(KnownLogLevels, spec.LogLevel) {
+		allErrs = append(allErrs, field.NotSupported(basePath.Child("logLevel"), spec.LogLevel, KnownLogLevels))
+	}
+
+	allErrs = append(allErrs, validateResources(spec.APIResources, basePath.Child("apiResources"))...)
+	allErrs = append(allErrs, validateResources(spec.ConsoleResources, basePath.Child("consoleResources"))...)
+
+	if cache := spec.ConversationCache; cache != nil {
+		cachePath := basePath.Child("conversationCache")
+		cacheTypes := []string{ConversationCacheTypeMemory, ConversationCacheTypePostgres}
+		if cache.Type != "" && !slices.Contains(cacheTypes, cache.Type) {
+			allErrs = append(allErrs, field.NotSupported(cachePath.Child("type"), cache.Type, cacheTypes))
+		}
+
+		if cache.Type == ConversationCacheTypePostgres && cache.CredentialsSecretRef == "" {
+			allErrs = append(allErrs, field.Required(cachePath.Child("credentialsSecretRef"),
+				"must be set when the conversation cache type is postgres"))
+		} else if cache.CredentialsSecretRef != "" {
+			for _, msg := range validation.IsDNS1123Subdomain(cache.CredentialsSecretRef) {
+				allErrs = append(allErrs, field.Invalid(cachePath.Child("credentialsSecretRef"),
+					cache.CredentialsSecretRef, msg))
+			}
+		}
+	}
+
+	for i, conditionType := range spec.RequiredOLSConditions {
+		conditionPath := basePath.Child("requiredOLSConditions").Index(i)
+		if !slices.Contains(OLSConditionTypes, conditionType) {
+			allErrs = append(allErrs, field.NotSupported(conditionPath, conditionType, OLSConditionTypes))
+		} else if slices.Contains(spec.RequiredOLSConditions[:i], conditionType) {
+			allErrs = append(allErrs, field.Duplicate(conditionPath, conditionType))
+		}
+	}
+
+	if spec.WaitFor != nil {
+		allErrs = append(allErrs, validateDependencyRef(spec.WaitFor, basePath.Child("waitFor"))...)
+	}
+
+	allErrs = append(allErrs, validateModelParameters(spec.ModelParameters, basePath.Child("modelParameters"))...)
+	allErrs = append(allErrs, spec.validateAdditionalModels(basePath)...)
+	allErrs = append(allErrs, spec.validateProviders(basePath)...)
+
+	for i, filter := range spec.QueryFilters {
+		patternPath := basePath.Child("queryFilters").Index(i).Child("pattern")
+		if filter.Pattern == "" {
+			allErrs = append(allErrs, field.Required(patternPath, ""))
+		} else if _, err := regexp.Compile(filter.Pattern); err != nil {
+			allErrs = append(allErrs, field.Invalid(patternPath, filter.Pattern, err.Error()))
+		}
+	}
+
+	allErrs = append(allErrs, spec.validateOLSConfigOverlay(basePath)...)
+
+	if spec.APITLS != nil {
+		allErrs = append(allErrs, validateAPITLS(spec.APITLS, basePath.Child("apiTLS"))...)
+	}
+
+	if ref := spec.OpenStackContextRef; ref != nil {
+		refPath := basePath.Child("openStackContextRef")
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), ""))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+				allErrs = append(allErrs, field.Invalid(refPath.Child("name"), ref.Name, msg))
+			}
+		}
+
+		if ref.Key != "" {
+			for _, msg := range validation.IsConfigMapKey(ref.Key) {
+				allErrs = append(allErrs, field.Invalid(refPath.Child("key"), ref.Key, msg))
+			}
+		}
+	}
+
+	if spec.UsageBudget != nil {
+		if spec.UsageBudget.TokensPerDay < 1 {
+			allErrs = append(allErrs, field.Invalid(basePath.Child("usageBudget", "tokensPerDay"),
+				spec.UsageBudget.TokensPerDay, "must be greater than 0"))
+		}
+
+		if spec.UsageBudget.AlertThresholdPercent < 0 || spec.UsageBudget.AlertThresholdPercent > 100 {
+			allErrs = append(allErrs, field.Invalid(basePath.Child("usageBudget", "alertThresholdPercent"),
+				spec.UsageBudget.AlertThresholdPercent, "must be between 1 and 100"))
+		}
+	}
+
+	if spec.RAGImagePullSecret != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.RAGImagePullSecret) {
+			allErrs = append(allErrs, field.Invalid(basePath.Child("ragImagePullSecret"),
+				spec.RAGImagePullSecret, msg))
+		}
+	}
+
+	// The CatalogSource fields are required and defaulted by the CRD, only their format is checked
+	if spec.CatalogSourceName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.CatalogSourceName) {
+			allErrs = append(allErrs, field.Invalid(basePath.Child("catalogSourceName"),
+				spec.CatalogSourceName, msg))
+		}
+	}
+	if spec.CatalogSourceNamespace != "" {
+		for _, msg := range validation.IsDNS1123Label(spec.CatalogSourceNamespace) {
+			allErrs = append(allErrs, field.Invalid(basePath.Child("catalogSourceNamespace"),
+				spec.CatalogSourceNamespace, msg))
+		}
+	}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabels(spec.NodeSelector, basePath.Child("nodeSelector"))...)
+
+	return allErrs
+}
+
+// ValidateModel - validates that the model and its endpoint are set. They can be left empty only
+// when the operator discovers them, or when the LLM providers are set in Providers.
+func (spec *OpenStackLightspeedSpec) ValidateModel(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(spec.Providers) > 0 && !spec.AutoDiscoverModel && spec.ModelName == "" && spec.LLMEndpoint == "" {
+		return allErrs
+	}
+
+	if spec.LLMEndpointType == "" {
+		allErrs = append(allErrs, field.Required(basePath.Child("llmEndpointType"),
+			"must be set unless providers is set"))
+	}
+
+	if spec.LLMCredentials == "" {
+		allErrs = append(allErrs, field.Required(basePath.Child("llmCredentials"),
+			"must be set unless providers is set"))
+	}
+
+	if spec.AutoDiscoverModel {
+		return allErrs
+	}
+
+	if spec.ModelName == "" {
+		allErrs = append(allErrs, field.Required(basePath.Child("modelName"),
+			"must be set unless autoDiscoverModel is enabled"))
+	}
+
+	if spec.LLMEndpoint == "" {
+		allErrs = append(allErrs, field.Required(basePath.Child("llmEndpoint"),
+			"must be set unless autoDiscoverModel is enabled"))
+	}
+
+	return allErrs
+}
+
+// validateOLSConfigOverlay - validates that the OLSConfig overlay is a JSON object that only sets
+// the spec of the OLSConfig. The metadata (e.g. the owner labels) is managed by the operator.
+func (spec *OpenStackLightspeedSpec) validateOLSConfigOverlay(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	overlayPath := basePath.Child("olsConfigOverlay")
+
+	overlay, err := spec.GetOLSConfigOverlay()
+	if err != nil {
+		return append(allErrs, field.Invalid(overlayPath, string(spec.OLSConfigOverlay.Raw), err.Error()))
+	}
+
+	for key, value := range overlay {
+		if key != "spec" {
+			allErrs = append(allErrs, field.Forbidden(overlayPath.Child(key),
+				"only the spec of the OLSConfig can be overlaid"))
+		} else if _, ok := value.(map[string]interface{}); !ok {
+			allErrs = append(allErrs, field.Invalid(overlayPath.Child(key), value, "must be an object"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAPITLS - validates the name of the secret serving the OLS API certificate.
+func validateAPITLS(apiTLS *APITLS, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if apiTLS.SecretName == "" {
+		allErrs = append(allErrs, field.Required(path.Child("secretName"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(apiTLS.SecretName) {
+			allErrs = append(allErrs, field.Invalid(path.Child("secretName"), apiTLS.SecretName, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAdditionalModels - validates that the additional models are named uniquely and that
+// their URL overrides and concurrency limits are valid.
+func (spec *OpenStackLightspeedSpec) validateAdditionalModels(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	modelNames := []string{spec.ModelName}
+
+	for i, model := range spec.AdditionalModels {
+		modelPath := basePath.Child("additionalModels").Index(i)
+		if model.Name == "" {
+			allErrs = append(allErrs, field.Required(modelPath.Child("name"), ""))
+		} else if slices.Contains(modelNames, model.Name) {
+			allErrs = append(allErrs, field.Duplicate(modelPath.Child("name"), model.Name))
+		} else {
+			modelNames = append(modelNames, model.Name)
+		}
+
+		if model.URL != "" {
+			allErrs = append(allErrs, validateHTTPURL(model.URL, modelPath.Child("url"))...)
+		}
+
+		allErrs = append(allErrs, validateModelParameters(model.Parameters, modelPath.Child("parameters"))...)
+	}
+
+	return allErrs
+}
+
+// validateProviders - validates that the LLM providers are named uniquely, that each of them serves
+// uniquely named models and that the default provider is one of them.
+func (spec *OpenStackLightspeedSpec) validateProviders(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	providerNames := []string{}
+	if spec.ModelName != "" {
+		providerNames = append(providerNames, LLMShorthandProviderName)
+	}
+
+	for i, provider := range spec.Providers {
+		providerPath := basePath.Child("providers").Index(i)
+		if provider.Name == "" {
+			allErrs = append(allErrs, field.Required(providerPath.Child("name"), ""))
+		} else if slices.Contains(providerNames, provider.Name) {
+			allErrs = append(allErrs, field.Duplicate(providerPath.Child("name"), provider.Name))
+		} else {
+			providerNames = append(providerNames, provider.Name)
+		}
+
+		if provider.Type == "" {
+			allErrs = append(allErrs, field.Required(providerPath.Child("type"), ""))
+		}
+
+		if provider.URL == "" {
+			allErrs = append(allErrs, field.Required(providerPath.Child("url"), ""))
+		} else {
+			allErrs = append(allErrs, validateHTTPURL(provider.URL, providerPath.Child("url"))...)
+		}
+
+		if provider.CredentialsSecret == "" {
+			allErrs = append(allErrs, field.Required(providerPath.Child("credentialsSecret"), ""))
+		}
+
+		if len(provider.Models) == 0 {
+			allErrs = append(allErrs, field.Required(providerPath.Child("models"),
+				"must list at least one model"))
+		}
+
+		modelNames := []string{}
+		for j, model := range provider.Models {
+			modelPath := providerPath.Child("models").Index(j)
+			if model.Name == "" {
+				allErrs = append(allErrs, field.Required(modelPath.Child("name"), ""))
+			} else if slices.Contains(modelNames, model.Name) {
+				allErrs = append(allErrs, field.Duplicate(modelPath.Child("name"), model.Name))
+			} else {
+				modelNames = append(modelNames, model.Name)
+			}
+
+			if model.URL != "" {
+				allErrs = append(allErrs, validateHTTPURL(model.URL, modelPath.Child("url"))...)
+			}
+
+			allErrs = append(allErrs, validateModelParameters(model.Parameters, modelPath.Child("parameters"))...)
+		}
+	}
+
+	if spec.DefaultProvider != "" && !slices.Contains(providerNames, spec.DefaultProvider) {
+		allErrs = append(allErrs, field.NotSupported(basePath.Child("defaultProvider"),
+			spec.DefaultProvider, providerNames))
+	}
+
+	return allErrs
+}
+
+// validateDependencyRef - validates that the dependency reference names a resource of one of the
+// KnownDependencyKinds
+func validateDependencyRef(ref *DependencyRef, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if ref.APIVersion == "" {
+		allErrs = append(allErrs, field.Required(path.Child("apiVersion"), ""))
+	} else if err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("apiVersion"), ref.APIVersion, err.Error()))
+	}
+
+	if ref.Kind == "" {
+		allErrs = append(allErrs, field.Required(path.Child("kind"), ""))
+	} else if err == nil && !slices.Contains(KnownDependencyKinds, gv.WithKind(ref.Kind).GroupKind()) {
+		knownKinds := make([]string, 0, len(KnownDependencyKinds))
+		for _, gk := range KnownDependencyKinds {
+			knownKinds = append(knownKinds, gk.String())
+		}
+		allErrs = append(allErrs, field.NotSupported(path.Child("kind"),
+			gv.WithKind(ref.Kind).GroupKind().String(), knownKinds))
+	}
+
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("name"), ""))
+	}
+
+	return allErrs
+}
+
+// validateModelParameters - validates that the model parameters, when set, are within the ranges
+// accepted by OLS
+func validateModelParameters(parameters *ModelParameters, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if parameters == nil {
+		return allErrs
+	}
+
+	if parameters.ContextWindowSize != nil && *parameters.ContextWindowSize < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("contextWindowSize"), *parameters.ContextWindowSize,
+			"must be greater than 0"))
+	}
+
+	return allErrs
+}
+
+// validateHTTPURL - validates that value is an http or https URL with a host.
+func validateHTTPURL(value string, path *field.Path) field.ErrorList {
+	if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return field.ErrorList{field.Invalid(path, value, "must be an http or https URL")}
+	}
+
+	return nil
+}
+
+// validateResources - validates that the resource quantities are not negative and that the requests
+// do not exceed the limits.
+func validateResources(resources *corev1.ResourceRequirements, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if resources == nil {
+		return allErrs
+	}
+
+	for name, quantity := range resources.Limits {
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("limits").Key(string(name)), quantity.String(),
+				"must not be negative"))
+		}
+	}
+
+	for name, quantity := range resources.Requests {
+		requestPath := path.Child("requests").Key(string(name))
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(requestPath, quantity.String(), "must not be negative"))
+		}
+
+		if limit, found := resources.Limits[name]; found && quantity.Cmp(limit) > 0 {
+			allErrs = append(allErrs, field.Invalid(requestPath, quantity.String(),
+				"must be less than or equal to the "+string(name)+" limit"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateImageReference - validates that image is a well-formed container image reference.
+func ValidateImageReference(image string, path *field.Path) field.ErrorList {
+	if !imageReferenceRegexp.MatchString(image) {
+		return field.ErrorList{field.Invalid(path, image, "must be a valid container image reference")}
+	}
+
+	return nil
+}
@@ -0,0 +1,982 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateImageReference(t *testing.T) {
+	tests := []struct {
+		name        string
+		image       string
+		shouldError bool
+	}{
+		{
+			name:        "Repository only",
+			image:       "lightspeed-console",
+			shouldError: false,
+		},
+		{
+			name:        "Registry with port and tag",
+			image:       "registry.example.com:5000/openshift/lightspeed-console:v1.0.0",
+			shouldError: false,
+		},
+		{
+			name:        "Digest",
+			image:       "quay.io/example/lightspeed-console@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			shouldError: false,
+		},
+		{
+			name:        "Uppercase repository",
+			image:       "quay.io/Example/console:latest",
+			shouldError: true,
+		},
+		{
+			name:        "Whitespace",
+			image:       "quay.io/example/console :latest",
+			shouldError: true,
+		},
+		{
+			name:        "Empty tag",
+			image:       "quay.io/example/console:",
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateImageReference(tt.image, field.NewPath("spec", "image"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateImageReference(%s) expected error, got nil", tt.image)
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateImageReference(%s) unexpected error: %v", tt.image, errs)
+			}
+		})
+	}
+}
+
+func TestValidateMutualExclusions(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          OpenStackLightspeedSpec
+		expectedField string
+	}{
+		{
+			name: "RAG disabled",
+			spec: OpenStackLightspeedSpec{DisableRAG: true},
+		},
+		{
+			name: "RAG disabled and RAG image",
+			spec: OpenStackLightspeedSpec{
+				DisableRAG: true,
+				RAGImage:   "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2",
+			},
+			expectedField: "spec.disableRAG",
+		},
+		{
+			name: "RAG disabled and OCP RAG",
+			spec: OpenStackLightspeedSpec{
+				DisableRAG:   true,
+				EnableOCPRAG: true,
+			},
+			expectedField: "spec.disableRAG",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.spec.ValidateMutualExclusions(field.NewPath("spec"))
+			if tt.expectedField == "" {
+				if len(errs) != 0 {
+					t.Errorf("ValidateMutualExclusions unexpected error: %v", errs)
+				}
+				return
+			}
+
+			if len(errs) != 1 {
+				t.Fatalf("ValidateMutualExclusions expected exactly one error, got %v", errs)
+			}
+			if errs[0].Type != field.ErrorTypeForbidden || errs[0].Field != tt.expectedField {
+				t.Errorf("ValidateMutualExclusions expected a Forbidden error on %s, got %v", tt.expectedField, errs[0])
+			}
+		})
+	}
+}
+
+func TestValidateSpecRequiredOLSConditions(t *testing.T) {
+	tests := []struct {
+		name               string
+		requiredConditions []string
+		shouldError        bool
+	}{
+		{
+			name:        "Unset",
+			shouldError: false,
+		},
+		{
+			name:               "Without ConsolePluginReady",
+			requiredConditions: []string{"ApiReady", "CacheReady", "Reconciled"},
+			shouldError:        false,
+		},
+		{
+			name:               "Unknown condition type",
+			requiredConditions: []string{"ApiReady", "Available"},
+			shouldError:        true,
+		},
+		{
+			name:               "Duplicate condition type",
+			requiredConditions: []string{"ApiReady", "ApiReady"},
+			shouldError:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OpenStackLightspeedSpec{RequiredOLSConditions: tt.requiredConditions}
+			errs := spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec expected error, got nil")
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec unexpected error: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecConversationCache(t *testing.T) {
+	tests := []struct {
+		name        string
+		cache       *ConversationCache
+		shouldError bool
+	}{
+		{
+			name:        "Unset",
+			shouldError: false,
+		},
+		{
+			name:        "Memory backend",
+			cache:       &ConversationCache{Type: ConversationCacheTypeMemory},
+			shouldError: false,
+		},
+		{
+			name:        "Postgres backend",
+			cache:       &ConversationCache{Type: ConversationCacheTypePostgres, CredentialsSecretRef: "ols-postgres-credentials"},
+			shouldError: false,
+		},
+		{
+			name:        "Postgres backend without credentials",
+			cache:       &ConversationCache{Type: ConversationCacheTypePostgres},
+			shouldError: true,
+		},
+		{
+			name:        "Invalid credentials secret name",
+			cache:       &ConversationCache{Type: ConversationCacheTypePostgres, CredentialsSecretRef: "OLS_Postgres"},
+			shouldError: true,
+		},
+		{
+			name:        "Unknown backend",
+			cache:       &ConversationCache{Type: "redis"},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OpenStackLightspeedSpec{ConversationCache: tt.cache}
+			errs := spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec expected error, got nil")
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec unexpected error: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecWaitFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		waitFor     *DependencyRef
+		shouldError bool
+	}{
+		{
+			name:        "Unset",
+			shouldError: false,
+		},
+		{
+			name: "InferenceService",
+			waitFor: &DependencyRef{
+				APIVersion: "serving.kserve.io/v1beta1",
+				Kind:       "InferenceService",
+				Name:       "granite",
+			},
+			shouldError: false,
+		},
+		{
+			name:        "Kind not allowed",
+			waitFor:     &DependencyRef{APIVersion: "v1", Kind: "Secret", Name: "llm-credentials"},
+			shouldError: true,
+		},
+		{
+			name:        "Kind of another group",
+			waitFor:     &DependencyRef{APIVersion: "example.com/v1", Kind: "InferenceService", Name: "granite"},
+			shouldError: true,
+		},
+		{
+			name:        "Missing fields",
+			waitFor:     &DependencyRef{},
+			shouldError: true,
+		},
+		{
+			name:        "Invalid API version",
+			waitFor:     &DependencyRef{APIVersion: "serving.kserve.io/v1beta1/extra", Kind: "InferenceService", Name: "granite"},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OpenStackLightspeedSpec{WaitFor: tt.waitFor}
+			errs := spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec expected error, got nil")
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec unexpected error: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecCatalogSource(t *testing.T) {
+	tests := []struct {
+		name                   string
+		catalogSourceName      string
+		catalogSourceNamespace string
+		ragImagePullSecret     string
+		shouldError            bool
+	}{
+		{
+			name:                   "Mirrored catalog",
+			catalogSourceName:      "cs-redhat-operator-index",
+			catalogSourceNamespace: "openshift-marketplace",
+			ragImagePullSecret:     "internal-registry",
+			shouldError:            false,
+		},
+		{
+			name:              "Invalid catalog name",
+			catalogSourceName: "Redhat_Operators",
+			shouldError:       true,
+		},
+		{
+			name:                   "Invalid catalog namespace",
+			catalogSourceNamespace: "openshift.marketplace",
+			shouldError:            true,
+		},
+		{
+			name:               "Invalid pull secret name",
+			ragImagePullSecret: "Internal_Registry",
+			shouldError:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OpenStackLightspeedSpec{
+				OpenStackLightspeedCore: OpenStackLightspeedCore{
+					CatalogSourceName:      tt.catalogSourceName,
+					CatalogSourceNamespace: tt.catalogSourceNamespace,
+				},
+				RAGImagePullSecret: tt.ragImagePullSecret,
+			}
+			errs := spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec() expected error, got nil")
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec() unexpected error: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecOpenStackContextRef(t *testing.T) {
+	tests := []struct {
+		name        string
+		ref         *OpenStackContextRef
+		shouldError bool
+	}{
+		{
+			name:        "Unset",
+			shouldError: false,
+		},
+		{
+			name:        "ConfigMap",
+			ref:         &OpenStackContextRef{Name: "openstack-context"},
+			shouldError: false,
+		},
+		{
+			name:        "ConfigMap with key",
+			ref:         &OpenStackContextRef{Name: "openstack-context", Key: "services.md"},
+			shouldError: false,
+		},
+		{
+			name:        "Missing name",
+			ref:         &OpenStackContextRef{},
+			shouldError: true,
+		},
+		{
+			name:        "Invalid key",
+			ref:         &OpenStackContextRef{Name: "openstack-context", Key: "services/list"},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OpenStackLightspeedSpec{OpenStackContextRef: tt.ref}
+			errs := spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec expected error, got nil")
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec unexpected error: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecModelParameters(t *testing.T) {
+	tests := []struct {
+		name        string
+		parameters  ModelParameters
+		shouldError bool
+	}{
+		{
+			name:        "Unset",
+			shouldError: false,
+		},
+		{
+			name:        "Context window size",
+			parameters:  ModelParameters{ContextWindowSize: ptr.To[int32](128000)},
+			shouldError: false,
+		},
+		{
+			name:        "Zero context window size",
+			parameters:  ModelParameters{ContextWindowSize: ptr.To[int32](0)},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs := map[string]OpenStackLightspeedSpec{
+				"modelParameters": {
+					OpenStackLightspeedCore: OpenStackLightspeedCore{
+						ModelName:       "granite",
+						ModelParameters: tt.parameters.DeepCopy(),
+					},
+				},
+				"additionalModels": {
+					OpenStackLightspeedCore: OpenStackLightspeedCore{ModelName: "granite"},
+					AdditionalModels:        []ProviderModel{{Name: "llama", Parameters: tt.parameters.DeepCopy()}},
+				},
+				"providers": {
+					Providers: []ProviderSpec{{
+						Name:              "primary",
+						Type:              "openai",
+						URL:               "https://primary.example.com/v1",
+						CredentialsSecret: "primary-credentials",
+						Models:            []ProviderModel{{Name: "granite", Parameters: tt.parameters.DeepCopy()}},
+					}},
+				},
+			}
+			for location, spec := range specs {
+				errs := spec.ValidateSpec(field.NewPath("spec"))
+				if tt.shouldError && len(errs) == 0 {
+					t.Errorf("ValidateSpec expected error in %s, got nil", location)
+				} else if !tt.shouldError && len(errs) != 0 {
+					t.Errorf("ValidateSpec unexpected error in %s: %v", location, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSpecAdditionalModels(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        OpenStackLightspeedSpec
+		shouldError bool
+	}{
+		{
+			name: "Models with and without URL override",
+			spec: OpenStackLightspeedSpec{
+				OpenStackLightspeedCore: OpenStackLightspeedCore{ModelName: "granite"},
+				AdditionalModels: []ProviderModel{
+					{Name: "granite-code", URL: "https://gateway.example.com/granite-code/v1"},
+					{Name: "llama"},
+				},
+			},
+			shouldError: false,
+		},
+		{
+			name: "Model without name",
+			spec: OpenStackLightspeedSpec{
+				OpenStackLightspeedCore: OpenStackLightspeedCore{ModelName: "granite"},
+				AdditionalModels:        []ProviderModel{{URL: "https://gateway.example.com/llama/v1"}},
+			},
+			shouldError: true,
+		},
+		{
+			name: "Model duplicating ModelName",
+			spec: OpenStackLightspeedSpec{
+				OpenStackLightspeedCore: OpenStackLightspeedCore{ModelName: "granite"},
+				AdditionalModels:        []ProviderModel{{Name: "granite"}},
+			},
+			shouldError: true,
+		},
+		{
+			name: "Invalid URL override",
+			spec: OpenStackLightspeedSpec{
+				OpenStackLightspeedCore: OpenStackLightspeedCore{ModelName: "granite"},
+				AdditionalModels:        []ProviderModel{{Name: "llama", URL: "gateway.example.com/llama"}},
+			},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec expected error, got nil")
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec unexpected error: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecProviders(t *testing.T) {
+	primary := ProviderSpec{
+		Name:              "primary",
+		Type:              "openai",
+		URL:               "https://primary.example.com/v1",
+		CredentialsSecret: "primary-credentials",
+		Models:            []ProviderModel{{Name: "granite"}},
+	}
+	fallback := ProviderSpec{
+		Name:              "fallback",
+		Type:              "rhoai_vllm",
+		URL:               "https://fallback.example.com/v1",
+		CredentialsSecret: "fallback-credentials",
+		Models:            []ProviderModel{{Name: "llama"}, {Name: "granite"}},
+	}
+	withProvider := func(mutate func(*ProviderSpec)) ProviderSpec {
+		provider := *fallback.DeepCopy()
+		mutate(&provider)
+		return provider
+	}
+
+	tests := []struct {
+		name        string
+		spec        OpenStackLightspeedSpec
+		shouldError bool
+	}{
+		{
+			name:        "Two providers",
+			spec:        OpenStackLightspeedSpec{Providers: []ProviderSpec{primary, fallback}},
+			shouldError: false,
+		},
+		{
+			name: "Providers next to the shorthand provider",
+			spec: OpenStackLightspeedSpec{
+				OpenStackLightspeedCore: OpenStackLightspeedCore{ModelName: "granite"},
+				Providers:               []ProviderSpec{fallback},
+				DefaultProvider:         LLMShorthandProviderName,
+			},
+			shouldError: false,
+		},
+		{
+			name:        "Duplicate provider name",
+			spec:        OpenStackLightspeedSpec{Providers: []ProviderSpec{primary, primary}},
+			shouldError: true,
+		},
+		{
+			name: "Provider duplicating the shorthand provider",
+			spec: OpenStackLightspeedSpec{
+				OpenStackLightspeedCore: OpenStackLightspeedCore{ModelName: "granite"},
+				Providers: []ProviderSpec{withProvider(func(p *ProviderSpec) {
+					p.Name = LLMShorthandProviderName
+				})},
+			},
+			shouldError: true,
+		},
+		{
+			name: "Provider without name",
+			spec: OpenStackLightspeedSpec{Providers: []ProviderSpec{withProvider(func(p *ProviderSpec) {
+				p.Name = ""
+			})}},
+			shouldError: true,
+		},
+		{
+			name: "Provider without type",
+			spec: OpenStackLightspeedSpec{Providers: []ProviderSpec{withProvider(func(p *ProviderSpec) {
+				p.Type = ""
+			})}},
+			shouldError: true,
+		},
+		{
+			name: "Provider with invalid URL",
+			spec: OpenStackLightspeedSpec{Providers: []ProviderSpec{withProvider(func(p *ProviderSpec) {
+				p.URL = "fallback.example.com/v1"
+			})}},
+			shouldError: true,
+		},
+		{
+			name: "Provider without credentials",
+			spec: OpenStackLightspeedSpec{Providers: []ProviderSpec{withProvider(func(p *ProviderSpec) {
+				p.CredentialsSecret = ""
+			})}},
+			shouldError: true,
+		},
+		{
+			name: "Provider without models",
+			spec: OpenStackLightspeedSpec{Providers: []ProviderSpec{withProvider(func(p *ProviderSpec) {
+				p.Models = nil
+			})}},
+			shouldError: true,
+		},
+		{
+			name: "Provider with duplicate model",
+			spec: OpenStackLightspeedSpec{Providers: []ProviderSpec{withProvider(func(p *ProviderSpec) {
+				p.Models = []ProviderModel{{Name: "llama"}, {Name: "llama"}}
+			})}},
+			shouldError: true,
+		},
+		{
+			name: "Default provider override",
+			spec: OpenStackLightspeedSpec{
+				Providers:       []ProviderSpec{primary, fallback},
+				DefaultProvider: "fallback",
+			},
+			shouldError: false,
+		},
+		{
+			name: "Unknown default provider",
+			spec: OpenStackLightspeedSpec{
+				Providers:       []ProviderSpec{primary, fallback},
+				DefaultProvider: "backup",
+			},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec expected error, got nil")
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec unexpected error: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecComponentResources(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        OpenStackLightspeedSpec
+		shouldError bool
+	}{
+		{
+			name:        "Unset",
+			spec:        OpenStackLightspeedSpec{},
+			shouldError: false,
+		},
+		{
+			name: "Requests within the limits",
+			spec: OpenStackLightspeedSpec{
+				APIResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+				},
+				ConsoleResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("100Mi")},
+				},
+			},
+			shouldError: false,
+		},
+		{
+			name: "Request above the limit",
+			spec: OpenStackLightspeedSpec{
+				APIResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+				},
+			},
+			shouldError: true,
+		},
+		{
+			name: "CPU limit below the request",
+			spec: OpenStackLightspeedSpec{
+				APIResources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			},
+			shouldError: true,
+		},
+		{
+			name: "Limits only",
+			spec: OpenStackLightspeedSpec{
+				APIResources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			},
+			shouldError: false,
+		},
+		{
+			name: "Negative limit",
+			spec: OpenStackLightspeedSpec{
+				ConsoleResources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("-100m")},
+				},
+			},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec expected error, got nil")
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec unexpected error: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecQueryFilters(t *testing.T) {
+	tests := []struct {
+		name        string
+		patterns    []string
+		shouldError bool
+	}{
+		{
+			name:        "No filters",
+			patterns:    nil,
+			shouldError: false,
+		},
+		{
+			name:        "Valid patterns",
+			patterns:    []string{`[\w.+-]+@[\w-]+\.[\w.]+`, `\b\d{3}-\d{2}-\d{4}\b`},
+			shouldError: false,
+		},
+		{
+			name:        "Invalid pattern",
+			patterns:    []string{`password=(\S+`},
+			shouldError: true,
+		},
+		{
+			name:        "Empty pattern",
+			patterns:    []string{""},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OpenStackLightspeedSpec{}
+			for i, pattern := range tt.patterns {
+				spec.QueryFilters = append(spec.QueryFilters, QueryFilter{
+					Name:        fmt.Sprintf("filter-%d", i),
+					Pattern:     pattern,
+					ReplaceWith: "[REDACTED]",
+				})
+			}
+			errs := spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec(%v) expected error, got nil", tt.patterns)
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec(%v) unexpected error: %v", tt.patterns, errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecLogLevel(t *testing.T) {
+	tests := []struct {
+		name        string
+		logLevel    string
+		shouldError bool
+	}{
+		{
+			name:        "Unset",
+			logLevel:    "",
+			shouldError: false,
+		},
+		{
+			name:        "Debug",
+			logLevel:    LogLevelDebug,
+			shouldError: false,
+		},
+		{
+			name:        "Info",
+			logLevel:    LogLevelInfo,
+			shouldError: false,
+		},
+		{
+			name:        "Warning",
+			logLevel:    LogLevelWarning,
+			shouldError: false,
+		},
+		{
+			name:        "Error",
+			logLevel:    LogLevelError,
+			shouldError: false,
+		},
+		{
+			name:        "Unknown level",
+			logLevel:    "trace",
+			shouldError: true,
+		},
+		{
+			name:        "Upper case level",
+			logLevel:    "DEBUG",
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OpenStackLightspeedSpec{LogLevel: tt.logLevel}
+			errs := spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec(%s) expected error, got nil", tt.logLevel)
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec(%s) unexpected error: %v", tt.logLevel, errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecOLSConfigOverlay(t *testing.T) {
+	tests := []struct {
+		name        string
+		overlay     string
+		shouldError bool
+	}{
+		{
+			name:        "Spec overlay",
+			overlay:     `{"spec": {"ols": {"logLevel": "DEBUG"}}}`,
+			shouldError: false,
+		},
+		{
+			name:        "Malformed overlay",
+			overlay:     `{"spec": {"ols": }`,
+			shouldError: true,
+		},
+		{
+			name:        "Overlay is not an object",
+			overlay:     `["spec"]`,
+			shouldError: true,
+		},
+		{
+			name:        "Owner label overlay",
+			overlay:     `{"metadata": {"labels": {"openstack.org/lightspeed-owner-id": "other"}}}`,
+			shouldError: true,
+		},
+		{
+			name:        "Spec is not an object",
+			overlay:     `{"spec": "ols"}`,
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OpenStackLightspeedSpec{OLSConfigOverlay: &runtime.RawExtension{Raw: []byte(tt.overlay)}}
+			errs := spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec(%s) expected error, got nil", tt.overlay)
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec(%s) unexpected error: %v", tt.overlay, errs)
+			}
+		})
+	}
+}
+
+func TestValidateModel(t *testing.T) {
+	tests := []struct {
+		name              string
+		modelName         string
+		llmEndpoint       string
+		autoDiscoverModel bool
+		providers         []ProviderSpec
+		shouldError       bool
+	}{
+		{
+			name:        "Model and endpoint set",
+			modelName:   "granite",
+			llmEndpoint: "https://llm.example.com/v1",
+			shouldError: false,
+		},
+		{
+			name:        "Model missing",
+			llmEndpoint: "https://llm.example.com/v1",
+			shouldError: true,
+		},
+		{
+			name:        "Endpoint missing",
+			modelName:   "granite",
+			shouldError: true,
+		},
+		{
+			name:              "Model discovered",
+			autoDiscoverModel: true,
+			shouldError:       false,
+		},
+		{
+			name:        "Providers set",
+			providers:   []ProviderSpec{{Name: "primary"}},
+			shouldError: false,
+		},
+		{
+			name:        "Providers set with model missing",
+			llmEndpoint: "https://llm.example.com/v1",
+			providers:   []ProviderSpec{{Name: "primary"}},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OpenStackLightspeedSpec{AutoDiscoverModel: tt.autoDiscoverModel, Providers: tt.providers}
+			spec.ModelName = tt.modelName
+			spec.LLMEndpoint = tt.llmEndpoint
+			spec.LLMEndpointType = "openai"
+			spec.LLMCredentials = "llm-credentials"
+			errs := spec.ValidateModel(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateModel expected error, got nil")
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateModel unexpected error: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecUsageBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		budget      UsageBudget
+		shouldError bool
+	}{
+		{
+			name:        "Valid budget",
+			budget:      UsageBudget{TokensPerDay: 100000, AlertThresholdPercent: 80},
+			shouldError: false,
+		},
+		{
+			name:        "Default threshold",
+			budget:      UsageBudget{TokensPerDay: 100000},
+			shouldError: false,
+		},
+		{
+			name:        "No tokens",
+			budget:      UsageBudget{TokensPerDay: 0},
+			shouldError: true,
+		},
+		{
+			name:        "Negative threshold",
+			budget:      UsageBudget{TokensPerDay: 100000, AlertThresholdPercent: -10},
+			shouldError: true,
+		},
+		{
+			name:        "Threshold above 100",
+			budget:      UsageBudget{TokensPerDay: 100000, AlertThresholdPercent: 120},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OpenStackLightspeedSpec{UsageBudget: &tt.budget}
+			errs := spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec(%+v) expected error, got nil", tt.budget)
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec(%+v) unexpected error: %v", tt.budget, errs)
+			}
+		})
+	}
+}
+
+func TestValidateSpecAPITLS(t *testing.T) {
+	tests := []struct {
+		name        string
+		apiTLS      APITLS
+		shouldError bool
+	}{
+		{
+			name:        "Certificate secret",
+			apiTLS:      APITLS{SecretName: "ols-api-cert"},
+			shouldError: false,
+		},
+		{
+			name:        "Missing certificate secret",
+			apiTLS:      APITLS{},
+			shouldError: true,
+		},
+		{
+			name:        "Invalid certificate secret name",
+			apiTLS:      APITLS{SecretName: "OLS_API_Cert"},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := OpenStackLightspeedSpec{APITLS: &tt.apiTLS}
+			errs := spec.ValidateSpec(field.NewPath("spec"))
+			if tt.shouldError && len(errs) == 0 {
+				t.Errorf("ValidateSpec(%+v) expected error, got nil", tt.apiTLS)
+			} else if !tt.shouldError && len(errs) != 0 {
+				t.Errorf("ValidateSpec(%+v) unexpected error: %v", tt.apiTLS, errs)
+			}
+		})
+	}
+}
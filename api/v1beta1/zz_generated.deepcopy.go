@@ -22,15 +22,96 @@ package v1beta1
 
 import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APITLS) DeepCopyInto(out *APITLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APITLS.
+func (in *APITLS) DeepCopy() *APITLS {
+	if in == nil {
+		return nil
+	}
+	out := new(APITLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversationCache) DeepCopyInto(out *ConversationCache) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConversationCache.
+func (in *ConversationCache) DeepCopy() *ConversationCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ConversationCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyRef) DeepCopyInto(out *DependencyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyRef.
+func (in *DependencyRef) DeepCopy() *DependencyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelParameters) DeepCopyInto(out *ModelParameters) {
+	*out = *in
+	if in.ContextWindowSize != nil {
+		in, out := &in.ContextWindowSize, &out.ContextWindowSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelParameters.
+func (in *ModelParameters) DeepCopy() *ModelParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenStackContextRef) DeepCopyInto(out *OpenStackContextRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackContextRef.
+func (in *OpenStackContextRef) DeepCopy() *OpenStackContextRef {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenStackContextRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenStackLightspeed) DeepCopyInto(out *OpenStackLightspeed) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -55,6 +136,11 @@ func (in *OpenStackLightspeed) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenStackLightspeedCore) DeepCopyInto(out *OpenStackLightspeedCore) {
 	*out = *in
+	if in.ModelParameters != nil {
+		in, out := &in.ModelParameters, &out.ModelParameters
+		*out = new(ModelParameters)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackLightspeedCore.
@@ -117,7 +203,95 @@ func (in *OpenStackLightspeedList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenStackLightspeedSpec) DeepCopyInto(out *OpenStackLightspeedSpec) {
 	*out = *in
-	out.OpenStackLightspeedCore = in.OpenStackLightspeedCore
+	in.OpenStackLightspeedCore.DeepCopyInto(&out.OpenStackLightspeedCore)
+	if in.RequiredOLSConditions != nil {
+		in, out := &in.RequiredOLSConditions, &out.RequiredOLSConditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManageOLSConfigFinalizer != nil {
+		in, out := &in.ManageOLSConfigFinalizer, &out.ManageOLSConfigFinalizer
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.APIResources != nil {
+		in, out := &in.APIResources, &out.APIResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConsoleResources != nil {
+		in, out := &in.ConsoleResources, &out.ConsoleResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalModels != nil {
+		in, out := &in.AdditionalModels, &out.AdditionalModels
+		*out = make([]ProviderModel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]ProviderSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QueryFilters != nil {
+		in, out := &in.QueryFilters, &out.QueryFilters
+		*out = make([]QueryFilter, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConversationCache != nil {
+		in, out := &in.ConversationCache, &out.ConversationCache
+		*out = new(ConversationCache)
+		**out = **in
+	}
+	if in.UsageBudget != nil {
+		in, out := &in.UsageBudget, &out.UsageBudget
+		*out = new(UsageBudget)
+		**out = **in
+	}
+	if in.APITLS != nil {
+		in, out := &in.APITLS, &out.APITLS
+		*out = new(APITLS)
+		**out = **in
+	}
+	if in.OpenStackContextRef != nil {
+		in, out := &in.OpenStackContextRef, &out.OpenStackContextRef
+		*out = new(OpenStackContextRef)
+		**out = **in
+	}
+	if in.OLSConfigOverlay != nil {
+		in, out := &in.OLSConfigOverlay, &out.OLSConfigOverlay
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WaitFor != nil {
+		in, out := &in.WaitFor, &out.WaitFor
+		*out = new(DependencyRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackLightspeedSpec.
@@ -140,6 +314,15 @@ func (in *OpenStackLightspeedStatus) DeepCopyInto(out *OpenStackLightspeedStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastSuccessfulReconcileTime != nil {
+		in, out := &in.LastSuccessfulReconcileTime, &out.LastSuccessfulReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RAGBuildInfo != nil {
+		in, out := &in.RAGBuildInfo, &out.RAGBuildInfo
+		*out = new(RAGBuildInfo)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenStackLightspeedStatus.
@@ -151,3 +334,94 @@ func (in *OpenStackLightspeedStatus) DeepCopy() *OpenStackLightspeedStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderModel) DeepCopyInto(out *ProviderModel) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(ModelParameters)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderModel.
+func (in *ProviderModel) DeepCopy() *ProviderModel {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderModel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
+	*out = *in
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]ProviderModel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSpec.
+func (in *ProviderSpec) DeepCopy() *ProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryFilter) DeepCopyInto(out *QueryFilter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryFilter.
+func (in *QueryFilter) DeepCopy() *QueryFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAGBuildInfo) DeepCopyInto(out *RAGBuildInfo) {
+	*out = *in
+	if in.BuiltAt != nil {
+		in, out := &in.BuiltAt, &out.BuiltAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAGBuildInfo.
+func (in *RAGBuildInfo) DeepCopy() *RAGBuildInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(RAGBuildInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageBudget) DeepCopyInto(out *UsageBudget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageBudget.
+func (in *UsageBudget) DeepCopy() *UsageBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageBudget)
+	in.DeepCopyInto(out)
+	return out
+}
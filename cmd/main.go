@@ -17,21 +17,26 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -42,6 +47,7 @@ import (
 
 	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
 	"github.com/openstack-lightspeed/operator/internal/controller"
+	webhookv1beta1 "github.com/openstack-lightspeed/operator/internal/webhook/v1beta1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -65,7 +71,17 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var maxConcurrentReconciles int
+	var olsConfigPollInterval time.Duration
+	var installPollInterval time.Duration
 	var tlsOpts []func(*tls.Config)
+
+	defaultMaxConcurrentReconciles, err := getMaxConcurrentReconciles()
+	if err != nil {
+		setupLog.Error(err, "unable to get the maximum number of concurrent reconciles")
+		os.Exit(1)
+	}
+
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -76,6 +92,13 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", defaultMaxConcurrentReconciles,
+		"The maximum number of OpenStackLightspeed instances reconciled in parallel. "+
+			"Defaults to the value of the MAX_CONCURRENT_RECONCILES environment variable or 1 when it is unset.")
+	flag.DurationVar(&olsConfigPollInterval, "ols-config-poll-interval", controller.DefaultOLSConfigPollInterval,
+		"How often the OLSConfig is checked while waiting for OpenShift Lightspeed to become ready.")
+	flag.DurationVar(&installPollInterval, "install-poll-interval", controller.DefaultInstallPollInterval,
+		"How often an OpenShift Lightspeed operator installation or removal in progress is checked.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -84,6 +107,25 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if err := controller.ValidateOperatorEnv(); err != nil {
+		setupLog.Error(err, "invalid operator environment")
+		os.Exit(1)
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+
+	shutdownTracing, err := controller.SetupTracing(ctx)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
+	if maxConcurrentReconciles < 1 {
+		setupLog.Error(fmt.Errorf("got %d", maxConcurrentReconciles),
+			"max-concurrent-reconciles must be at least 1")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -149,6 +191,16 @@ func main() {
 		LeaderElectionID:       "c83b0a4f.lightspeed.openstack.org",
 		Cache: cache.Options{
 			DefaultNamespaces: defaultNamespaces,
+			ByObject: map[client.Object]cache.ByObject{
+				// The only ConfigMap we watch is the trusted CA bundle in the OLS namespace
+				&corev1.ConfigMap{}: {
+					Namespaces: map[string]cache.Config{controller.OLSOperatorNamespace: {}},
+				},
+				// Secrets are watched in the OLS namespace only, for the OLSConfig waiting for them
+				&corev1.Secret{}: {
+					Namespaces: map[string]cache.Config{controller.OLSOperatorNamespace: {}},
+				},
+			},
 		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
@@ -171,12 +223,23 @@ func main() {
 	apiv1beta1.SetupDefaults()
 
 	if err = (&controller.OpenStackLightspeedReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		Recorder:                mgr.GetEventRecorderFor("openstacklightspeed-controller"),
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		OLSConfigPollInterval:   olsConfigPollInterval,
+		InstallPollInterval:     installPollInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenStackLightspeed")
 		os.Exit(1)
 	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookv1beta1.SetupOpenStackLightspeedWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpenStackLightspeed")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -189,10 +252,15 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	// The manager context is cancelled at this point, flush the pending spans with a fresh one
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "unable to flush the tracing spans")
+	}
 }
 
 // getWatchNamespaces returns a list of namespaces the operator should be watching for changes.
@@ -210,3 +278,21 @@ func getWatchNamespaces() ([]string, error) {
 
 	return strings.Split(ns, ","), nil
 }
+
+// getMaxConcurrentReconciles returns the maximum number of concurrent reconciles configured through
+// the MAX_CONCURRENT_RECONCILES env var. It returns 1 when the env var is unset.
+func getMaxConcurrentReconciles() (int, error) {
+	var maxConcurrentReconcilesEnvVar = "MAX_CONCURRENT_RECONCILES"
+
+	value, found := os.LookupEnv(maxConcurrentReconcilesEnvVar)
+	if !found || value == "" {
+		return 1, nil
+	}
+
+	maxConcurrentReconciles, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer: %w", maxConcurrentReconcilesEnvVar, err)
+	}
+
+	return maxConcurrentReconciles, nil
+}
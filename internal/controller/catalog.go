@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+// OLSCatalogSourceReadyState - connection state OLM reports for a CatalogSource whose registry
+// can be queried
+const OLSCatalogSourceReadyState = "READY"
+
+// ErrOLSCatalogSourceNotReady is returned when the CatalogSource configured in the instance does not
+// exist or OLM cannot connect to its registry. A Subscription created at this point would not
+// resolve, which is common in disconnected clusters before the mirrored catalog is available.
+var ErrOLSCatalogSourceNotReady = errors.New("OLS operator CatalogSource is not ready")
+
+// CheckOLSCatalogSourceReady returns ErrOLSCatalogSourceNotReady when the CatalogSource configured
+// in the instance does not exist or is not in the READY connection state.
+func CheckOLSCatalogSourceReady(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) error {
+	// Use raw client as the catalog namespace might not be among the watched namespaces
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return err
+	}
+
+	catalog := fmt.Sprintf("%s/%s", instance.Spec.CatalogSourceNamespace, instance.Spec.CatalogSourceName)
+	catalogSource := &operatorsv1alpha1.CatalogSource{}
+	err = rawClient.Get(ctx, client.ObjectKey{
+		Name:      instance.Spec.CatalogSourceName,
+		Namespace: instance.Spec.CatalogSourceNamespace,
+	}, catalogSource)
+	if err != nil && k8s_errors.IsNotFound(err) {
+		return fmt.Errorf("%w: %s not found", ErrOLSCatalogSourceNotReady, catalog)
+	} else if err != nil {
+		return err
+	}
+
+	state := ""
+	if catalogSource.Status.GRPCConnectionState != nil {
+		state = catalogSource.Status.GRPCConnectionState.LastObservedState
+	}
+	if state == "" {
+		return fmt.Errorf("%w: %s has no connection state yet", ErrOLSCatalogSourceNotReady, catalog)
+	} else if state != OLSCatalogSourceReadyState {
+		return fmt.Errorf("%w: %s connection state is %s", ErrOLSCatalogSourceNotReady, catalog, state)
+	}
+
+	return nil
+}
+
+// GetPackageManifestGVK returns the GroupVersionKind of the PackageManifests that describe the
+// operator packages offered by the OLM catalogs
+func GetPackageManifestGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "packages.operators.coreos.com",
+		Version: "v1",
+		Kind:    "PackageManifest",
+	}
+}
+
+// CheckOLSPackageInCatalog verifies that the catalog configured in the instance offers the OLS
+// operator package in the Subscription channel, in the recommended version when one is set. A broken
+// catalog entry is a common reason for a stalled OLS operator installation. Returns a description
+// of the problem, or an empty string when the catalog offers what the Subscription asks for.
+func CheckOLSPackageInCatalog(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (string, error) {
+	recommendedVersion, err := GetRecommendedOLSVersion()
+	if err != nil {
+		return "", err
+	}
+
+	// Use raw client as the catalog namespace might not be among the watched namespaces
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return "", err
+	}
+
+	packageManifests := &uns.UnstructuredList{}
+	packageManifests.SetGroupVersionKind(GetPackageManifestGVK().GroupVersion().WithKind("PackageManifestList"))
+	err = rawClient.List(ctx, packageManifests,
+		client.InNamespace(instance.Spec.CatalogSourceNamespace),
+		client.MatchingLabels{"catalog": instance.Spec.CatalogSourceName})
+	if err != nil {
+		return "", err
+	}
+
+	catalog := fmt.Sprintf("%s/%s", instance.Spec.CatalogSourceNamespace, instance.Spec.CatalogSourceName)
+	subscriptionChannel := GetOLSSubscriptionChannel(instance)
+	for _, packageManifest := range packageManifests.Items {
+		catalogSource, _, _ := uns.NestedString(packageManifest.Object, "status", "catalogSource")
+		if packageManifest.GetName() != OLSOperatorName || catalogSource != instance.Spec.CatalogSourceName {
+			continue
+		}
+
+		channels, _, _ := uns.NestedSlice(packageManifest.Object, "status", "channels")
+		for _, c := range channels {
+			channel, ok := c.(map[string]interface{})
+			if !ok || channel["name"] != subscriptionChannel {
+				continue
+			}
+
+			if recommendedVersion == "" || slices.Contains(getChannelCSVNames(channel), GetOLSOperatorCSVName(recommendedVersion)) {
+				return "", nil
+			}
+
+			return fmt.Sprintf(apiv1beta1.OLSPackageVersionNotFoundMessage,
+				recommendedVersion, subscriptionChannel, catalog), nil
+		}
+
+		return fmt.Sprintf(apiv1beta1.OLSPackageChannelNotFoundMessage, subscriptionChannel, catalog), nil
+	}
+
+	return fmt.Sprintf(apiv1beta1.OLSPackageNotFoundMessage, OLSOperatorName, catalog), nil
+}
+
+// getChannelCSVNames returns the names of the CSVs offered by a PackageManifest channel
+func getChannelCSVNames(channel map[string]interface{}) []string {
+	var names []string
+	if currentCSV, _, _ := uns.NestedString(channel, "currentCSV"); currentCSV != "" {
+		names = append(names, currentCSV)
+	}
+
+	entries, _, _ := uns.NestedSlice(channel, "entries")
+	for _, e := range entries {
+		if entry, ok := e.(map[string]interface{}); ok {
+			if name, _, _ := uns.NestedString(entry, "name"); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+func TestCheckOLSPackageInCatalog(t *testing.T) {
+	const catalog = "openshift-marketplace/redhat-operators"
+
+	tests := []struct {
+		name            string
+		olsVersion      string
+		channel         string
+		packageManifest func(instance *apiv1beta1.OpenStackLightspeed) client.Object
+		expectedProblem string
+	}{
+		{
+			name:       "Recommended version offered",
+			olsVersion: testOLSVersion,
+			packageManifest: func(instance *apiv1beta1.OpenStackLightspeed) client.Object {
+				return newTestPackageManifest(instance, OLSOperatorChannel, testOLSCSVName)
+			},
+		},
+		{
+			name:       "Recommended version missing",
+			olsVersion: "1.0.7",
+			packageManifest: func(instance *apiv1beta1.OpenStackLightspeed) client.Object {
+				return newTestPackageManifest(instance, OLSOperatorChannel, testOLSCSVName)
+			},
+			expectedProblem: "recommended version 1.0.7 not found in channel stable of catalog " + catalog,
+		},
+		{
+			name:       "Latest version from the offered channel",
+			olsVersion: "latest",
+			packageManifest: func(instance *apiv1beta1.OpenStackLightspeed) client.Object {
+				return newTestPackageManifest(instance, OLSOperatorChannel)
+			},
+		},
+		{
+			name:       "Channel missing",
+			olsVersion: testOLSVersion,
+			packageManifest: func(instance *apiv1beta1.OpenStackLightspeed) client.Object {
+				return newTestPackageManifest(instance, "candidate", testOLSCSVName)
+			},
+			expectedProblem: "channel stable not found in catalog " + catalog,
+		},
+		{
+			name:       "Channel from the spec",
+			olsVersion: testOLSVersion,
+			channel:    "candidate",
+			packageManifest: func(instance *apiv1beta1.OpenStackLightspeed) client.Object {
+				return newTestPackageManifest(instance, "candidate", testOLSCSVName)
+			},
+		},
+		{
+			name:       "Channel from the spec missing",
+			olsVersion: testOLSVersion,
+			channel:    "fast",
+			packageManifest: func(instance *apiv1beta1.OpenStackLightspeed) client.Object {
+				return newTestPackageManifest(instance, OLSOperatorChannel, testOLSCSVName)
+			},
+			expectedProblem: "channel fast not found in catalog " + catalog,
+		},
+		{
+			name:       "Package only offered by another catalog",
+			olsVersion: testOLSVersion,
+			packageManifest: func(instance *apiv1beta1.OpenStackLightspeed) client.Object {
+				other := instance.DeepCopy()
+				other.Spec.CatalogSourceName = "community-operators"
+				return newTestPackageManifest(other, OLSOperatorChannel, testOLSCSVName)
+			},
+			expectedProblem: "package lightspeed-operator not found in catalog " + catalog,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", tt.olsVersion)
+
+			instance := newTestInstance()
+			instance.Spec.OLSSubscriptionChannel = tt.channel
+			cl := newTestClient(t, instance, tt.packageManifest(instance))
+			helper := newTestHelper(t, cl, instance)
+
+			problem, err := CheckOLSPackageInCatalog(context.Background(), helper, instance)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if problem != tt.expectedProblem {
+				t.Errorf("CheckOLSPackageInCatalog() = %q, want %q", problem, tt.expectedProblem)
+			}
+		})
+	}
+}
+
+func TestReconcileOLSPackageVersionNotInCatalog(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+	// The catalog only offers a newer version, OLM never creates the CSV.
+	objs := []client.Object{instance, newTestPackageManifest(instance, OLSOperatorChannel, OLSOperatorName+".v1.0.7")}
+	for _, obj := range newTestOLSOperatorObjects(instance) {
+		_, isCSV := obj.(*operatorsv1alpha1.ClusterServiceVersion)
+		if !isCSV && obj.GetObjectKind().GroupVersionKind() != testPackageManifestGVK {
+			objs = append(objs, obj)
+		}
+	}
+
+	cl := newTestClient(t, objs...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	_, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OpenShiftLightspeedOperatorReadyCondition)
+	if cond == nil || cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected False OpenShiftLightspeedOperatorReadyCondition, got %+v", cond)
+	}
+	if cond.Reason != apiv1beta1.OpenShiftLightspeedOperatorCatalogReason {
+		t.Errorf("Reason = %s, want %s", cond.Reason, apiv1beta1.OpenShiftLightspeedOperatorCatalogReason)
+	}
+
+	expectedMessage := "OpenShift Lightspeed operator cannot be installed from the catalog: recommended version " +
+		testOLSVersion + " not found in channel stable of catalog openshift-marketplace/redhat-operators"
+	if cond.Message != expectedMessage {
+		t.Errorf("Message = %q, want %q", cond.Message, expectedMessage)
+	}
+}
+
+func TestCheckOLSCatalogSourceReady(t *testing.T) {
+	const catalog = "openshift-marketplace/redhat-operators"
+
+	tests := []struct {
+		name          string
+		catalogSource func(instance *apiv1beta1.OpenStackLightspeed) client.Object
+		expectedError string
+	}{
+		{
+			name: "Catalog ready",
+			catalogSource: func(instance *apiv1beta1.OpenStackLightspeed) client.Object {
+				return newTestCatalogSource(instance, OLSCatalogSourceReadyState)
+			},
+		},
+		{
+			name:          "Catalog missing",
+			expectedError: "OLS operator CatalogSource is not ready: " + catalog + " not found",
+		},
+		{
+			name: "Catalog without connection state",
+			catalogSource: func(instance *apiv1beta1.OpenStackLightspeed) client.Object {
+				return newTestCatalogSource(instance, "")
+			},
+			expectedError: "OLS operator CatalogSource is not ready: " + catalog + " has no connection state yet",
+		},
+		{
+			name: "Catalog registry unreachable",
+			catalogSource: func(instance *apiv1beta1.OpenStackLightspeed) client.Object {
+				return newTestCatalogSource(instance, "TRANSIENT_FAILURE")
+			},
+			expectedError: "OLS operator CatalogSource is not ready: " + catalog + " connection state is TRANSIENT_FAILURE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			objs := []client.Object{instance}
+			if tt.catalogSource != nil {
+				objs = append(objs, tt.catalogSource(instance))
+			}
+			cl := newTestClient(t, objs...)
+			helper := newTestHelper(t, cl, instance)
+
+			err := CheckOLSCatalogSourceReady(context.Background(), helper, instance)
+			if tt.expectedError == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if tt.expectedError != "" && (err == nil || err.Error() != tt.expectedError) {
+				t.Fatalf("CheckOLSCatalogSourceReady() = %v, want %q", err, tt.expectedError)
+			}
+			if tt.expectedError != "" && !errors.Is(err, ErrOLSCatalogSourceNotReady) {
+				t.Errorf("expected ErrOLSCatalogSourceNotReady, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileOLSCatalogSourceNotReady(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+	// Nothing is installed yet and the mirrored catalog is not reachable
+	catalogSource := newTestCatalogSource(instance, "CONNECTING")
+	cl := newTestClient(t, instance, catalogSource)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	result, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Errorf("expected a requeue while the CatalogSource is not ready")
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OpenShiftLightspeedOperatorReadyCondition)
+	if cond == nil || cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected False OpenShiftLightspeedOperatorReadyCondition, got %+v", cond)
+	}
+	if cond.Reason != apiv1beta1.OpenShiftLightspeedOperatorCatalogSourceNotReadyReason {
+		t.Errorf("Reason = %s, want %s", cond.Reason, apiv1beta1.OpenShiftLightspeedOperatorCatalogSourceNotReadyReason)
+	}
+
+	subscription := &operatorsv1alpha1.Subscription{}
+	subscriptionKey := client.ObjectKey{Name: GetOLSSubscriptionName(instance), Namespace: instance.Namespace}
+	if err := cl.Get(context.Background(), subscriptionKey, subscription); !k8s_errors.IsNotFound(err) {
+		t.Fatalf("expected no Subscription before the CatalogSource is ready, got %v", err)
+	}
+
+	// The Subscription is created once OLM connected to the catalog
+	catalogSource.Status.GRPCConnectionState.LastObservedState = OLSCatalogSourceReadyState
+	if err := cl.Update(context.Background(), catalogSource); err != nil {
+		t.Fatalf("failed to update CatalogSource: %v", err)
+	}
+	if _, _, err := reconcileTestInstance(t, r); err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if err := cl.Get(context.Background(), subscriptionKey, subscription); err != nil {
+		t.Fatalf("expected the Subscription once the CatalogSource is ready, got %v", err)
+	}
+}
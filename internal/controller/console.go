@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+const (
+	// ConsoleCapability - cluster capability of the OpenShift console and its operator
+	ConsoleCapability = "Console"
+
+	// OLSConsolePluginReadyCondition - OLSConfig status condition reporting the console plugin
+	OLSConsolePluginReadyCondition = "ConsolePluginReady"
+)
+
+// DetectConsoleEnabled detects whether the OpenShift console is installed in the cluster
+func DetectConsoleEnabled(ctx context.Context, helper *common_helper.Helper) (bool, error) {
+	// Use raw client to access cluster-scoped resources
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return false, fmt.Errorf("failed to get raw client: %w", err)
+	}
+
+	return GetClusterConsoleEnabled(ctx, rawClient)
+}
+
+// GetClusterConsoleEnabled reads from the enabled capabilities of the ClusterVersion object whether
+// the console operator is installed. Clusters that predate the capabilities always have the console.
+// The reader must not be restricted to the watched namespaces.
+func GetClusterConsoleEnabled(ctx context.Context, reader client.Reader) (bool, error) {
+	clusterVersion := &uns.Unstructured{}
+	clusterVersion.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "ClusterVersion",
+	})
+
+	err := reader.Get(ctx, client.ObjectKey{Name: "version"}, clusterVersion)
+	if err != nil {
+		return false, fmt.Errorf("failed to get ClusterVersion: %w", err)
+	}
+
+	capabilities, found, err := uns.NestedStringSlice(
+		clusterVersion.Object, "status", "capabilities", "enabledCapabilities")
+	if err != nil {
+		return false, fmt.Errorf("failed to extract capabilities from ClusterVersion: %w", err)
+	}
+	if !found {
+		return true, nil
+	}
+
+	return slices.Contains(capabilities, ConsoleCapability), nil
+}
+
+// GetRequiredOLSConditions returns the OLSConfig status conditions gating the readiness of OLS. On
+// clusters without the console the ConsolePluginReady condition is left out, and as the overall
+// status reported by OLS includes it, all the other conditions are required when none is left.
+func GetRequiredOLSConditions(requiredConditions []string, consoleEnabled bool) []string {
+	if consoleEnabled {
+		return requiredConditions
+	}
+
+	withoutConsolePlugin := func(conditionTypes []string) []string {
+		return slices.DeleteFunc(slices.Clone(conditionTypes), func(conditionType string) bool {
+			return conditionType == OLSConsolePluginReadyCondition
+		})
+	}
+
+	if conditions := withoutConsolePlugin(requiredConditions); len(conditions) > 0 {
+		return conditions
+	}
+
+	return withoutConsolePlugin(apiv1beta1.OLSConditionTypes)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetClusterConsoleEnabled(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities []interface{}
+		expected     bool
+	}{
+		{
+			name:     "Cluster without capabilities",
+			expected: true,
+		},
+		{
+			name:         "Console capability enabled",
+			capabilities: []interface{}{"Console", "Insights"},
+			expected:     true,
+		},
+		{
+			name:         "Console capability disabled",
+			capabilities: []interface{}{"Insights"},
+			expected:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterVersion := newTestClusterVersion("4.18.0")
+			if tt.capabilities != nil {
+				_ = uns.SetNestedSlice(clusterVersion.Object, tt.capabilities,
+					"status", "capabilities", "enabledCapabilities")
+			}
+			cl := newTestClient(t, clusterVersion)
+
+			enabled, err := GetClusterConsoleEnabled(context.Background(), cl)
+			if err != nil {
+				t.Fatalf("GetClusterConsoleEnabled unexpected error: %v", err)
+			}
+			if enabled != tt.expected {
+				t.Errorf("GetClusterConsoleEnabled() = %v, want %v", enabled, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Missing ClusterVersion", func(t *testing.T) {
+		cl := newTestClient(t)
+		if _, err := GetClusterConsoleEnabled(context.Background(), cl); err == nil {
+			t.Errorf("GetClusterConsoleEnabled expected error, got nil")
+		}
+	})
+}
+
+func TestGetRequiredOLSConditions(t *testing.T) {
+	tests := []struct {
+		name               string
+		requiredConditions []string
+		consoleEnabled     bool
+		expected           []string
+	}{
+		{
+			name:           "Overall status with the console",
+			consoleEnabled: true,
+		},
+		{
+			name:               "Required conditions with the console",
+			requiredConditions: []string{"ApiReady", "ConsolePluginReady"},
+			consoleEnabled:     true,
+			expected:           []string{"ApiReady", "ConsolePluginReady"},
+		},
+		{
+			name:     "Overall status without the console",
+			expected: []string{"ApiReady", "CacheReady", "Reconciled"},
+		},
+		{
+			name:               "Required conditions without the console",
+			requiredConditions: []string{"ApiReady", "ConsolePluginReady"},
+			expected:           []string{"ApiReady"},
+		},
+		{
+			name:               "Only the console plugin without the console",
+			requiredConditions: []string{"ConsolePluginReady"},
+			expected:           []string{"ApiReady", "CacheReady", "Reconciled"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions := GetRequiredOLSConditions(tt.requiredConditions, tt.consoleEnabled)
+			if !slices.Equal(conditions, tt.expected) {
+				t.Errorf("GetRequiredOLSConditions() = %v, want %v", conditions, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+// DependencyRetryInterval - Time after which a WaitFor dependency that is not ready is checked again
+const DependencyRetryInterval = 15 * time.Second
+
+// GetDependency returns the resource referenced by the WaitFor of the instance, or nil when it does
+// not exist. The dependency is always looked up in the namespace of the instance.
+func GetDependency(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (*uns.Unstructured, error) {
+	ref := instance.Spec.WaitFor
+
+	// The kinds are restricted to apiv1beta1.KnownDependencyKinds by the spec validation
+	gvk := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+	if !slices.Contains(apiv1beta1.KnownDependencyKinds, gvk.GroupKind()) {
+		return nil, fmt.Errorf("unsupported dependency kind %s", gvk.GroupKind().String())
+	}
+
+	// Use raw client as the dependency kind is not watched, only get is allowed for it
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return nil, err
+	}
+
+	dependency := &uns.Unstructured{}
+	dependency.SetGroupVersionKind(gvk)
+	err = rawClient.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: instance.Namespace}, dependency)
+	if err != nil && k8s_errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return dependency, nil
+}
+
+// IsDependencyReady returns whether the dependency reports the condition type of the reference as
+// True. A missing dependency is not ready.
+func IsDependencyReady(dependency *uns.Unstructured, ref *apiv1beta1.DependencyRef) bool {
+	return dependency != nil && isStatusConditionTrue(dependency, ref.GetConditionType())
+}
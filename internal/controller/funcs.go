@@ -18,11 +18,13 @@ package controller
 
 import (
 	"context"
-	"crypto/rand"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"math"
-	"math/big"
-	"strconv"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
 	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,22 +33,38 @@ import (
 	_ "embed"
 
 	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
 	// OpenStackLightspeedDefaultProvider - contains default name for the provider created in OLSConfig
 	// by openstack-operator.
-	OpenStackLightspeedDefaultProvider = "openstack-lightspeed-provider"
+	OpenStackLightspeedDefaultProvider = apiv1beta1.LLMShorthandProviderName
 
 	// OpenStackLightspeedOwnerIDLabel - name of a label that contains ID of OpenStackLightspeed instance
 	// that manages the OLSConfig.
 	OpenStackLightspeedOwnerIDLabel = "openstack.org/lightspeed-owner-id"
 
+	// OpenStackLightspeedOwnerNameLabel and OpenStackLightspeedOwnerNamespaceLabel - names of the
+	// labels that contain the name and namespace of the OpenStackLightspeed instance that manages
+	// the OLSConfig. Unlike the owner ID they do not depend on the owner label scheme, so they let
+	// an upgraded operator recognize the OLSConfigs it managed before and re-adopt them.
+	OpenStackLightspeedOwnerNameLabel      = "openstack.org/lightspeed-owner-name"
+	OpenStackLightspeedOwnerNamespaceLabel = "openstack.org/lightspeed-owner-namespace"
+
+	// OLSConfigOverlayPathsAnnotation - name of the OLSConfig annotation that lists the paths the
+	// OLSConfig overlay of the instance set last time, so that the ones dropped from the overlay can
+	// be removed from the OLSConfig.
+	OLSConfigOverlayPathsAnnotation = "openstack.org/lightspeed-overlay-paths"
+
 	// OpenStackLightspeedVectorDBPath - path inside of the container image where the vector DB are
 	// located
 	OpenStackLightspeedVectorDBPath = "/rag/vector_db/os_product_docs"
@@ -57,6 +75,26 @@ const (
 
 	// OLSConfigName - OLS forbids other name for OLSConfig instance than OLSConfigName
 	OLSConfigName = "cluster"
+
+	// OLSConfigGroup - API group of the OLSConfig
+	OLSConfigGroup = "ols.openshift.io"
+
+	// OLSConfigDefaultAPIVersion - OLSConfig API version used when OLS_CONFIG_API_VERSION is unset
+	OLSConfigDefaultAPIVersion = "v1alpha1"
+
+	// OLSConfigInMemoryCacheType - OLS conversation cache type that keeps the conversation history in
+	// the memory of the OLS pods
+	OLSConfigInMemoryCacheType = "memory"
+
+	// OLSConfigCRDName - name of the CustomResourceDefinition that defines the OLSConfig
+	OLSConfigCRDName = "olsconfigs." + OLSConfigGroup
+
+	// OLSDefaultReplicas - number of OLS API pods when the instance does not set one
+	OLSDefaultReplicas = 1
+
+	// OLSConfigObservedGenerationTimeout - Time after which OLS is considered behind when it has not
+	// processed the latest generation of the OLSConfig.
+	OLSConfigObservedGenerationTimeout = 5 * time.Minute
 )
 
 // systemPrompt - system prompt tailored to the needs of OpenStack Lightspeed. It overwrites the default OLS prompt.
@@ -73,13 +111,20 @@ func GetSystemPrompt() string {
 // and is managed by the given OpenStackLightspeed instance. It first fetches the OLSConfig,
 // checks whether the current OpenStackLightspeed instance is the owner (via label check),
 // and if so, removes the finalizer and deletes the OLSConfig resource.
-// Returns (true, nil) if the OLSConfig is not found (indicating it has already been deleted).
+// Returns (true, nil) if the OLSConfig is not found (indicating it has already been deleted) or if
+// it is not managed by the instance, in which case it is left untouched.
 // Returns (true, nil) if the resource was deleted successfully, or (false, error) if any error occurs.
+// The OLSConfig is left untouched when the instance does not manage the OLSConfig finalizer.
 func RemoveOLSConfig(
 	ctx context.Context,
 	helper *common_helper.Helper,
 	instance *apiv1beta1.OpenStackLightspeed,
 ) (bool, error) {
+	if !ptr.Deref(instance.Spec.ManageOLSConfigFinalizer, true) {
+		helper.GetLogger().Info("Skipping OLSConfig deletion as its lifecycle is managed externally")
+		return true, nil
+	}
+
 	olsConfig, err := GetOLSConfig(ctx, helper)
 	if err != nil && !k8s_errors.IsNotFound(err) {
 		return false, err
@@ -87,19 +132,16 @@ func RemoveOLSConfig(
 		return true, nil
 	}
 
-	_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), &olsConfig, func() error {
-		ownerLabel := olsConfig.GetLabels()[OpenStackLightspeedOwnerIDLabel]
-		isInstanceOwnedOLSConfig := ownerLabel == string(instance.GetObjectMeta().GetUID())
-
-		if ownerLabel == "" || !isInstanceOwnedOLSConfig {
-			helper.GetLogger().Info("Skipping OLSConfig deletion as it is not managed by the OpenStackLightspeed instance")
-			return nil
-		}
-
-		if ok := controllerutil.RemoveFinalizer(&olsConfig, helper.GetFinalizer()); !ok {
-			return fmt.Errorf("remove finalizer failed")
-		}
+	// Never touch an OLSConfig that is not managed by the instance, it might be owned by the user
+	// or by another OpenStackLightspeed instance.
+	ownerLabel := olsConfig.GetLabels()[OpenStackLightspeedOwnerIDLabel]
+	if ownerLabel == "" || ownerLabel != string(instance.GetObjectMeta().GetUID()) {
+		helper.GetLogger().Info("Skipping OLSConfig deletion as it is not managed by the OpenStackLightspeed instance")
+		return true, nil
+	}
 
+	_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), &olsConfig, func() error {
+		controllerutil.RemoveFinalizer(&olsConfig, helper.GetFinalizer())
 		return nil
 	})
 	if err != nil {
@@ -121,16 +163,139 @@ func RemoveOLSConfig(
 	return false, nil
 }
 
-// GetOLSConfig returns OLSConfig if there is one present in the cluster.
-func GetOLSConfig(ctx context.Context, helper *common_helper.Helper) (uns.Unstructured, error) {
-	OLSConfigGVR := schema.GroupVersionResource{
-		Group:    "ols.openshift.io",
-		Version:  "v1alpha1",
-		Resource: "olsconfigs",
+// HasInMemoryConversationCache returns whether the OLSConfig keeps the conversation history in the
+// memory of the OLS pods. Such history does not survive the restart of the OLS pods.
+func HasInMemoryConversationCache(olsConfig *uns.Unstructured) bool {
+	cacheType, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "conversationCache", "type")
+	return cacheType == OLSConfigInMemoryCacheType
+}
+
+// GetOLSConfigGVK returns the GroupVersionKind used for all the OLSConfig reads and writes. The API
+// version is taken from the OLS_CONFIG_API_VERSION environment variable so that the operator can
+// follow the OLSConfig API graduation without a code change. It defaults to
+// OLSConfigDefaultAPIVersion.
+func GetOLSConfigGVK() schema.GroupVersionKind {
+	apiVersion := os.Getenv("OLS_CONFIG_API_VERSION")
+	if apiVersion == "" {
+		apiVersion = OLSConfigDefaultAPIVersion
+	}
+
+	return schema.GroupVersionKind{
+		Group:   OLSConfigGroup,
+		Version: apiVersion,
+		Kind:    "OLSConfig",
+	}
+}
+
+// IsOLSConfigCRDEstablished returns true once the OLSConfig CRD is established in the API server and
+// serves the OLSConfig API version we use. Right after the OLS operator CSV succeeds the CRD might not
+// be established yet, and any OLSConfig request would fail with a no-matches-for-kind error.
+func IsOLSConfigCRDEstablished(ctx context.Context, helper *common_helper.Helper) (bool, error) {
+	// Use raw client to access cluster-scoped resources
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return false, err
 	}
 
+	crd := &uns.Unstructured{}
+	crd.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "apiextensions.k8s.io",
+		Version: "v1",
+		Kind:    "CustomResourceDefinition",
+	})
+
+	err = rawClient.Get(ctx, client.ObjectKey{Name: OLSConfigCRDName}, crd)
+	if err != nil && k8s_errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	conditions, _, err := uns.NestedSlice(crd.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+
+	isEstablished := false
+	for _, c := range conditions {
+		crdCondition, ok := c.(map[string]interface{})
+		if ok && crdCondition["type"] == "Established" && crdCondition["status"] == "True" {
+			isEstablished = true
+			break
+		}
+	}
+
+	if !isEstablished {
+		return false, nil
+	}
+
+	versions, _, err := uns.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range versions {
+		crdVersion, ok := v.(map[string]interface{})
+		if ok && crdVersion["name"] == GetOLSConfigGVK().Version && crdVersion["served"] == true {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetOLSConfigCRDVersions returns the API versions served by the OLSConfig CRD and its storage
+// version. It returns no versions when the CRD does not exist.
+func GetOLSConfigCRDVersions(ctx context.Context, helper *common_helper.Helper) ([]string, string, error) {
+	// Use raw client to access cluster-scoped resources
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return nil, "", err
+	}
+
+	crd := &uns.Unstructured{}
+	crd.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "apiextensions.k8s.io",
+		Version: "v1",
+		Kind:    "CustomResourceDefinition",
+	})
+
+	err = rawClient.Get(ctx, client.ObjectKey{Name: OLSConfigCRDName}, crd)
+	if err != nil && k8s_errors.IsNotFound(err) {
+		return nil, "", nil
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	versions, _, err := uns.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return nil, "", err
+	}
+
+	var servedVersions []string
+	storageVersion := ""
+	for _, v := range versions {
+		crdVersion, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name, _ := crdVersion["name"].(string)
+		if crdVersion["served"] == true {
+			servedVersions = append(servedVersions, name)
+		}
+		if crdVersion["storage"] == true {
+			storageVersion = name
+		}
+	}
+
+	return servedVersions, storageVersion, nil
+}
+
+// GetOLSConfig returns OLSConfig if there is one present in the cluster.
+func GetOLSConfig(ctx context.Context, helper *common_helper.Helper) (uns.Unstructured, error) {
 	OLSConfigList := &uns.UnstructuredList{}
-	OLSConfigList.SetGroupVersionKind(OLSConfigGVR.GroupVersion().WithKind("OLSConfig"))
+	OLSConfigList.SetGroupVersionKind(GetOLSConfigGVK())
 	err := helper.GetClient().List(ctx, OLSConfigList)
 	if err != nil {
 		return uns.Unstructured{}, err
@@ -141,77 +306,859 @@ func GetOLSConfig(ctx context.Context, helper *common_helper.Helper) (uns.Unstru
 	}
 
 	return uns.Unstructured{}, k8s_errors.NewNotFound(
-		schema.GroupResource{Group: "ols.openshifg.io", Resource: "olsconfigs"},
+		schema.GroupResource{Group: OLSConfigGroup, Resource: "olsconfigs"},
 		"OLSConfig")
 }
 
+// patchOLSConfigConversationCache sets the backend of the conversation cache. The backend is left
+// untouched when the type is unset so that OLS keeps its default.
+func patchOLSConfigConversationCache(olsConfig *uns.Unstructured, cache *apiv1beta1.ConversationCache) error {
+	if cache != nil && cache.Type != "" {
+		err := uns.SetNestedField(olsConfig.Object, cache.Type, "spec", "ols", "conversationCache", "type")
+		if err != nil {
+			return err
+		}
+
+		if cache.Type == apiv1beta1.ConversationCacheTypePostgres {
+			err := uns.SetNestedField(olsConfig.Object, cache.CredentialsSecretRef,
+				"spec", "ols", "conversationCache", "postgres", "credentialsSecret")
+			if err != nil {
+				return err
+			}
+		} else {
+			uns.RemoveNestedField(olsConfig.Object, "spec", "ols", "conversationCache", "postgres")
+		}
+	}
+
+	return nil
+}
+
+// buildOLSConfigModel returns the OLSConfig entry of an LLM model. The model URL takes precedence
+// over the provider URL.
+func buildOLSConfigModel(
+	instance *apiv1beta1.OpenStackLightspeed,
+	model apiv1beta1.ProviderModel,
+) map[string]interface{} {
+	parameters := map[string]interface{}{
+		"maxTokensForResponse": float64(instance.Spec.MaxTokensForResponse), // unstructured JSON numbers default to float64
+	}
+
+	entry := map[string]interface{}{
+		"name":       model.Name,
+		"parameters": parameters,
+	}
+	if model.URL != "" {
+		entry["url"] = model.URL
+	}
+
+	setModelParameters(entry, model.Parameters)
+
+	return entry
+}
+
+// buildOLSConfigShorthandProvider returns the OLSConfig entry of the LLM provider described by the
+// shorthand LLMEndpoint, LLMEndpointType, ModelName and LLMCredentials fields
+func buildOLSConfigShorthandProvider(instance *apiv1beta1.OpenStackLightspeed) map[string]interface{} {
+	models := []apiv1beta1.ProviderModel{{
+		Name:       instance.Spec.ModelName,
+		Parameters: instance.Spec.ModelParameters,
+	}}
+
+	return buildOLSConfigProvider(instance, apiv1beta1.ProviderSpec{
+		Name:              OpenStackLightspeedDefaultProvider,
+		Type:              instance.Spec.LLMEndpointType,
+		URL:               instance.Spec.LLMEndpoint,
+		CredentialsSecret: instance.Spec.LLMCredentials,
+		Models:            append(models, instance.Spec.AdditionalModels...),
+		ProjectID:         instance.Spec.LLMProjectID,
+		DeploymentName:    instance.Spec.LLMDeploymentName,
+		APIVersion:        instance.Spec.LLMAPIVersion,
+	})
+}
+
+// buildOLSConfigProvider returns the OLSConfig entry of an LLM provider
+func buildOLSConfigProvider(
+	instance *apiv1beta1.OpenStackLightspeed,
+	provider apiv1beta1.ProviderSpec,
+) map[string]interface{} {
+	models := []interface{}{}
+	for _, model := range provider.Models {
+		models = append(models, buildOLSConfigModel(instance, model))
+	}
+
+	entry := map[string]interface{}{
+		"credentialsSecretRef": map[string]interface{}{
+			"name": provider.CredentialsSecret,
+		},
+		"models": models,
+		"name":   provider.Name,
+		"type":   provider.Type,
+		"url":    provider.URL,
+	}
+
+	optionalFields := map[string]string{
+		"projectID":      provider.ProjectID,
+		"deploymentName": provider.DeploymentName,
+		"apiVersion":     provider.APIVersion,
+	}
+	for name, value := range optionalFields {
+		if value != "" {
+			entry[name] = value
+		}
+	}
+
+	return entry
+}
+
+// getOLSConfigDefaults returns the default provider and the default model of the OLSConfig
+// providers. The default provider is the first provider unless overridden, the default model is the
+// first model of the default provider.
+func getOLSConfigDefaults(providers []interface{}, override string) (string, string) {
+	for _, p := range providers {
+		provider := p.(map[string]interface{})
+		name, _ := provider["name"].(string)
+		if override != "" && name != override {
+			continue
+		}
+
+		models, _ := provider["models"].([]interface{})
+		if len(models) == 0 {
+			return name, ""
+		}
+		model, _ := models[0].(map[string]interface{})["name"].(string)
+
+		return name, model
+	}
+
+	return override, ""
+}
+
+// setModelParameters sets the parameters of an OLSConfig model entry. Only the parameters that are
+// set are written so that OLS applies its defaults to the others. OLS holds the context window size
+// on the model entry itself, next to its parameters.
+func setModelParameters(model map[string]interface{}, modelParameters *apiv1beta1.ModelParameters) {
+	if modelParameters == nil {
+		return
+	}
+
+	if modelParameters.ContextWindowSize != nil {
+		model["contextWindowSize"] = int64(*modelParameters.ContextWindowSize)
+	}
+}
+
+// RepairOLSConfigDefaults makes the defaultProvider of the OLSConfig reference one of its providers
+// and the defaultModel reference one of the models of that provider. A dangling reference is
+// repaired to the first provider, respectively the first model of the provider. Returns whether a
+// reference was repaired, or ErrOLSConfigInvalidDefaults when there is no provider or model to
+// point at.
+func RepairOLSConfigDefaults(olsConfig *uns.Unstructured) (bool, error) {
+	providers, _, err := uns.NestedSlice(olsConfig.Object, "spec", "llm", "providers")
+	if err != nil {
+		return false, err
+	} else if len(providers) == 0 {
+		return false, fmt.Errorf("%w: no LLM provider is configured", ErrOLSConfigInvalidDefaults)
+	}
+
+	repaired := false
+	defaultProvider, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultProvider")
+	var provider map[string]interface{}
+	for _, p := range providers {
+		if p, ok := p.(map[string]interface{}); ok && p["name"] == defaultProvider {
+			provider = p
+			break
+		}
+	}
+
+	if provider == nil {
+		provider, _ = providers[0].(map[string]interface{})
+		defaultProvider, _ = provider["name"].(string)
+		if defaultProvider == "" {
+			return false, fmt.Errorf("%w: the first LLM provider has no name", ErrOLSConfigInvalidDefaults)
+		}
+
+		err = uns.SetNestedField(olsConfig.Object, defaultProvider, "spec", "ols", "defaultProvider")
+		if err != nil {
+			return false, err
+		}
+		repaired = true
+	}
+
+	models, _, err := uns.NestedSlice(provider, "models")
+	if err != nil {
+		return false, err
+	} else if len(models) == 0 {
+		return false, fmt.Errorf("%w: LLM provider %s has no model", ErrOLSConfigInvalidDefaults, defaultProvider)
+	}
+
+	defaultModel, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultModel")
+	for _, m := range models {
+		if m, ok := m.(map[string]interface{}); ok && m["name"] == defaultModel {
+			return repaired, nil
+		}
+	}
+
+	model, _ := models[0].(map[string]interface{})
+	defaultModel, _ = model["name"].(string)
+	if defaultModel == "" {
+		return false, fmt.Errorf("%w: the first model of LLM provider %s has no name",
+			ErrOLSConfigInvalidDefaults, defaultProvider)
+	}
+
+	err = uns.SetNestedField(olsConfig.Object, defaultModel, "spec", "ols", "defaultModel")
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// MergeOLSConfigOverlay deep-merges overlay into obj and returns the paths it set. Nested objects are
+// merged, any other value of the overlay (including lists) replaces the value in obj. The paths set
+// in managed are skipped.
+func MergeOLSConfigOverlay(
+	obj map[string]interface{},
+	overlay map[string]interface{},
+	managed map[string]interface{},
+	path ...string,
+) [][]string {
+	var applied [][]string
+	for key, value := range overlay {
+		keyPath := append(slices.Clone(path), key)
+		managedValue, isManaged := managed[key]
+
+		overlayMap, isOverlayMap := value.(map[string]interface{})
+		if isOverlayMap && len(overlayMap) > 0 {
+			managedMap, isManagedMap := managedValue.(map[string]interface{})
+			if isManaged && !isManagedMap {
+				continue
+			}
+
+			objMap, isObjMap := obj[key].(map[string]interface{})
+			if !isObjMap {
+				objMap = map[string]interface{}{}
+				obj[key] = objMap
+			}
+			applied = append(applied, MergeOLSConfigOverlay(objMap, overlayMap, managedMap, keyPath...)...)
+			continue
+		}
+
+		if isManaged {
+			continue
+		}
+		obj[key] = runtime.DeepCopyJSONValue(value)
+		applied = append(applied, keyPath)
+	}
+
+	return applied
+}
+
+// applyOLSConfigOverlay merges the OLSConfig overlay of the instance into olsConfig, skipping the
+// paths set in managed. The paths the overlay set last time are removed first, so that dropping a
+// field from the overlay drops it from the OLSConfig too.
+func applyOLSConfigOverlay(
+	instance *apiv1beta1.OpenStackLightspeed,
+	olsConfig *uns.Unstructured,
+	managed *uns.Unstructured,
+) error {
+	overlay, err := instance.Spec.GetOLSConfigOverlay()
+	if err != nil {
+		return err
+	}
+
+	annotations := olsConfig.GetAnnotations()
+	var previous [][]string
+	if value, ok := annotations[OLSConfigOverlayPathsAnnotation]; ok {
+		// A malformed annotation only means the stale overlay fields are left behind
+		_ = json.Unmarshal([]byte(value), &previous)
+	}
+
+	for _, path := range previous {
+		// Only the spec can be overlaid, and the managed paths hold what the instance set
+		if len(path) < 2 || path[0] != "spec" {
+			continue
+		}
+		if _, found, err := uns.NestedFieldNoCopy(managed.Object, path...); found || err != nil {
+			continue
+		}
+		uns.RemoveNestedField(olsConfig.Object, path...)
+	}
+
+	applied := MergeOLSConfigOverlay(olsConfig.Object, overlay, managed.Object)
+	if len(applied) == 0 {
+		delete(annotations, OLSConfigOverlayPathsAnnotation)
+		olsConfig.SetAnnotations(annotations)
+		return nil
+	}
+
+	// Sort the paths so that the annotation only changes along with the overlay
+	slices.SortFunc(applied, slices.Compare)
+	appliedJSON, err := json.Marshal(applied)
+	if err != nil {
+		return err
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[OLSConfigOverlayPathsAnnotation] = string(appliedJSON)
+	olsConfig.SetAnnotations(annotations)
+
+	return nil
+}
+
+// IsRAGlessFallbackActive returns whether OLS should run without the RAG sources because the vector
+// DB of the current RAG image could not be loaded and the instance allows the fallback.
+func IsRAGlessFallbackActive(instance *apiv1beta1.OpenStackLightspeed) bool {
+	return instance.Spec.AllowRAGlessFallback && instance.Spec.RAGImage != "" &&
+		instance.Status.RAGlessFallbackImage == instance.Spec.RAGImage
+}
+
 // BuildRAGConfigs builds the RAG configuration array.
 // OpenStack RAG is always included first.
 // OCP RAG is added if ocpVersion is provided.
+// The array is empty when RAG is disabled or no RAG image is set.
 func BuildRAGConfigs(instance *apiv1beta1.OpenStackLightspeed, ocpVersion string) []interface{} {
-	rags := []interface{}{
-		// OpenStack RAG
-		map[string]interface{}{
-			"image":     instance.Spec.RAGImage,
-			"indexPath": OpenStackLightspeedVectorDBPath,
-		},
+	if instance.Spec.DisableRAG {
+		return []interface{}{}
+	}
+
+	// Both the OpenStack and the OCP RAG are loaded from the RAG image
+	if instance.Spec.RAGImage == "" {
+		return []interface{}{}
+	}
+
+	// OpenStack RAG
+	openstackRAG := map[string]interface{}{
+		"image":     instance.Spec.RAGImage,
+		"indexPath": OpenStackLightspeedVectorDBPath,
 	}
+	rags := []interface{}{openstackRAG}
 
 	// Add OCP RAG if enabled
 	if ocpVersion != "" {
-		rags = append(rags, map[string]interface{}{
+		ocpRAG := map[string]interface{}{
 			"image":     instance.Spec.RAGImage,
 			"indexPath": GetOCPVectorDBPath(ocpVersion),
 			"indexID":   GetOCPIndexName(ocpVersion),
-		})
+		}
+		rags = append(rags, ocpRAG)
 	}
 
 	return rags
 }
 
-// PatchOLSConfig patches OLSConfig with information from OpenStackLightspeed instance.
-func PatchOLSConfig(
+// getResourcesWithDefaultRequests returns a copy of resources where the resources that only have a
+// limit request the limit, as Kubernetes does for a container. Otherwise the default request OLS
+// applies might exceed the limit and the OLS pods would be rejected.
+func getResourcesWithDefaultRequests(resources *corev1.ResourceRequirements) *corev1.ResourceRequirements {
+	withRequests := resources.DeepCopy()
+	for name, limit := range withRequests.Limits {
+		if _, found := withRequests.Requests[name]; found {
+			continue
+		}
+
+		if withRequests.Requests == nil {
+			withRequests.Requests = corev1.ResourceList{}
+		}
+		withRequests.Requests[name] = limit.DeepCopy()
+	}
+
+	return withRequests
+}
+
+// CreateOrPatchOLSConfig creates the OLSConfig or patches the existing one with information from
+// the OpenStackLightspeed instance. The OLSConfig is a cluster wide singleton, so an OLSConfig
+// managed by a different OpenStackLightspeed instance is left untouched and an error is returned.
+func CreateOrPatchOLSConfig(
+	ctx context.Context,
 	helper *common_helper.Helper,
 	instance *apiv1beta1.OpenStackLightspeed,
-	olsConfig *uns.Unstructured,
 ) error {
-	// Patch the Providers section
-	providersPatch := []interface{}{
-		map[string]interface{}{
-			"credentialsSecretRef": map[string]interface{}{
-				"name": instance.Spec.LLMCredentials,
-			},
-			"models": []interface{}{
-				map[string]interface{}{
-					"name": instance.Spec.ModelName,
-					"parameters": map[string]interface{}{
-						"maxTokensForResponse": float64(instance.Spec.MaxTokensForResponse), // unstructured JSON numbers default to float64
-					},
-				},
-			},
-			"name": OpenStackLightspeedDefaultProvider,
-			"type": instance.Spec.LLMEndpointType,
-			"url":  instance.Spec.LLMEndpoint,
-		},
+	// NOTE: We cannot consume the OLSConfig definition directly from the OLS operator's code due to
+	// a conflict in Go versions. When this comment was written, the min. required Go version for
+	// openstack-operator was 1.21 whereas OLS operator required at least Go version 1.23. Once the
+	// Go versions catch up with each other we should consider consuming OLSConfig directly from OLS
+	// operator and updating this code and any subsequent code that consumes this structure.
+	olsConfig := uns.Unstructured{}
+	olsConfig.SetGroupVersionKind(GetOLSConfigGVK())
+	olsConfig.SetName(OLSConfigName)
+
+	// OLS reaches the LLM through the cluster egress proxy, if there is one
+	proxyURL, err := GetClusterProxyURL(ctx, helper)
+	if err != nil {
+		return err
+	}
+
+	trustedCAInjected := false
+	if proxyURL != "" {
+		trustedCAInjected, err = EnsureTrustedCAConfigMap(ctx, helper)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The system prompt describes the OpenStack deployment, if there is a description. The
+	// reconcile checks that it exists before the OLSConfig is written.
+	openStackContext := ""
+	if instance.Spec.OpenStackContextRef != nil {
+		openStackContext, _, err = GetOpenStackContext(ctx, helper, instance)
+		if err != nil {
+			return err
+		}
 	}
 
-	provider := providersPatch[0].(map[string]interface{})
-	if instance.Spec.LLMProjectID != "" {
-		if err := uns.SetNestedField(provider, instance.Spec.LLMProjectID, "projectID"); err != nil {
+	mutate := func() error {
+		// Check if the OpenStackLightspeed instance that is being processed owns the OLSConfig. If
+		// it is owned by other OpenStackLightspeed instance stop the reconciliation.
+		olsConfigLabels := olsConfig.GetLabels()
+		ownerLabel := ""
+		if val, ok := olsConfigLabels[OpenStackLightspeedOwnerIDLabel]; ok {
+			ownerLabel = val
+		}
+
+		if ownerLabel != "" && ownerLabel != string(instance.GetObjectMeta().GetUID()) {
+			if IsOLSConfigManagedByInstanceName(&olsConfig, instance) {
+				// The owner ID was written under a different owner label scheme or the instance was
+				// recreated, the labels are rewritten by PatchOLSConfig below.
+				helper.GetLogger().Info("Re-adopting OLSConfig managed by the instance under a previous owner ID",
+					"previousOwnerID", ownerLabel)
+			} else {
+				isOrphaned, err := IsOLSConfigOrphaned(ctx, helper, &olsConfig, instance, ownerLabel)
+				if err != nil {
+					return err
+				} else if !isOrphaned {
+					return NewOLSConfigOwnershipConflictError(ctx, helper, ownerLabel)
+				}
+
+				helper.GetLogger().Info("Adopting OLSConfig of a deleted OpenStackLightspeed instance",
+					"previousOwnerID", ownerLabel)
+			}
+			delete(olsConfigLabels, OpenStackLightspeedOwnerIDLabel)
+			olsConfig.SetLabels(olsConfigLabels)
+		} else if ownerLabel == "" && olsConfig.GetResourceVersion() != "" {
+			// OLS re-creates the OLSConfig without our labels e.g. during its own upgrade. Adopt it
+			// unless another instance was managing it, the labels and the finalizer are restored by
+			// PatchOLSConfig below.
+			claimant, err := GetOtherOLSConfigClaimant(ctx, helper, instance)
+			if err != nil {
+				return err
+			} else if claimant != nil {
+				return fmt.Errorf("%w %s in namespace %s, which wrote it before it lost its owner label",
+					ErrOLSConfigOwnershipConflict, claimant.GetName(), claimant.GetNamespace())
+			}
+
+			helper.GetLogger().Info("Adopting OLSConfig without owner label")
+		}
+
+		original := olsConfig.DeepCopy()
+		if _, err := PatchOLSConfig(helper, instance, &olsConfig); err != nil {
+			return err
+		}
+
+		if err := PatchOLSConfigOpenStackContext(&olsConfig, openStackContext); err != nil {
 			return err
 		}
+
+		if err := PatchOLSConfigProxy(&olsConfig, proxyURL, trustedCAInjected); err != nil {
+			return err
+		}
+
+		// The OpenStack context and the proxy overwrite what PatchOLSConfig wrote, compare the result
+		if isEqual, err := isUnstructuredEqual(original, &olsConfig); err != nil {
+			return err
+		} else if isEqual {
+			helper.GetLogger().V(1).Info("OLSConfig already matches the instance")
+		}
+
+		return nil
 	}
 
-	if instance.Spec.LLMDeploymentName != "" {
-		if err := uns.SetNestedField(provider, instance.Spec.LLMDeploymentName, "deploymentName"); err != nil {
+	err = helper.GetClient().Get(ctx, client.ObjectKeyFromObject(&olsConfig), &olsConfig)
+	if k8s_errors.IsNotFound(err) {
+		if err := mutate(); err != nil {
 			return err
 		}
+
+		written := olsConfig.DeepCopy()
+		err = helper.GetClient().Create(ctx, &olsConfig)
+		if k8s_errors.IsAlreadyExists(err) {
+			return fmt.Errorf("%w: %w", ErrOLSConfigWriteConflict, err)
+		} else if err != nil {
+			return err
+		}
+		return checkOLSConfigPrunedPaths(written, &olsConfig)
+	} else if err != nil {
+		return err
+	}
+
+	// controllerutil.CreateOrPatch is not used here as it drops the status from unstructured
+	// objects, which makes every patch clear the status reported by the OLS operator. Sending only
+	// the fields that drifted also means that e.g. a removed owner label is restored with a
+	// metadata only patch that leaves the spec and the status timestamps alone.
+	original := olsConfig.DeepCopy()
+	if err := mutate(); err != nil {
+		return err
+	}
+
+	patchData, err := client.MergeFrom(original).Data(&olsConfig)
+	if err != nil {
+		return err
+	} else if string(patchData) == "{}" {
+		helper.GetLogger().V(1).Info("OLSConfig is up to date, nothing to patch")
+		return nil
 	}
 
-	if instance.Spec.LLMAPIVersion != "" {
-		if err := uns.SetNestedField(provider, instance.Spec.LLMAPIVersion, "apiVersion"); err != nil {
+	// Show precisely what is about to change to debug an OLSConfig that keeps being patched
+	if debugLog := helper.GetLogger().V(1); debugLog.Enabled() {
+		changedPaths, err := GetOLSConfigChangedPaths(patchData)
+		if err != nil {
 			return err
 		}
+		debugLog.Info("Patching OLSConfig", "changedPaths", changedPaths, "patch", string(patchData))
+	}
+
+	// The patch is conditioned on the resourceVersion we read, so when several instances write the
+	// OLSConfig at the same time only the first one succeeds and the others retry against the
+	// updated OLSConfig (and its owner label) instead of overwriting it.
+	patch := client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+	written := olsConfig.DeepCopy()
+	err = helper.GetClient().Patch(ctx, &olsConfig, patch)
+	if k8s_errors.IsConflict(err) {
+		return fmt.Errorf("%w: %w", ErrOLSConfigWriteConflict, err)
+	} else if err != nil {
+		return err
+	}
+	return checkOLSConfigPrunedPaths(written, &olsConfig)
+}
+
+// checkOLSConfigPrunedPaths returns ErrOLSConfigFieldsPruned listing the spec fields of the written
+// OLSConfig that the API server did not store
+func checkOLSConfigPrunedPaths(written *uns.Unstructured, stored *uns.Unstructured) error {
+	if prunedPaths := GetOLSConfigPrunedPaths(written, stored); len(prunedPaths) > 0 {
+		return fmt.Errorf("%w: %s", ErrOLSConfigFieldsPruned, strings.Join(prunedPaths, ", "))
+	}
+	return nil
+}
+
+// ErrOLSConfigOwnershipConflict is returned when the OLSConfig is managed by another
+// OpenStackLightspeed instance. OLS only accepts a single OLSConfig, so the instance cannot be
+// configured until the other instance releases it.
+var ErrOLSConfigOwnershipConflict = errors.New("OLSConfig is managed by different OpenStackLightspeed instance")
+
+// ErrOLSConfigWriteConflict is returned when the OLSConfig was written by someone else while we were
+// updating it. The update should be retried against the current OLSConfig.
+var ErrOLSConfigWriteConflict = errors.New("OLSConfig was modified concurrently")
+
+// ErrOLSConfigNoRAGSources is returned when the OLSConfig would be written without any RAG source
+// although RAG is not disabled. Retrying does not help until the spec changes.
+var ErrOLSConfigNoRAGSources = errors.New("OLSConfig has no RAG source")
+
+// ErrOLSConfigFieldsPruned is returned when the API server dropped fields of the written OLSConfig
+// because the OLSConfig CRD schema does not declare them. OLS never sees these fields, retrying does
+// not help until the spec changes.
+var ErrOLSConfigFieldsPruned = errors.New("OLSConfig CRD schema does not declare the fields")
+
+// ErrOLSConfigInvalidDefaults is returned when the default provider and model of the OLSConfig
+// cannot be pointed at a configured provider and model. Retrying does not help until the spec changes.
+var ErrOLSConfigInvalidDefaults = errors.New("OLSConfig has no valid default provider and model")
+
+// GetOLSConfigValidationFailures returns the field level failures of an OLSConfig write rejected
+// by the validation of the OLSConfig CRD schema, formatted as "<field>: <message>" and separated by
+// "; ". The error message itself is returned when the API server reported no field details.
+func GetOLSConfigValidationFailures(err error) string {
+	var statusErr k8s_errors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil ||
+		len(statusErr.Status().Details.Causes) == 0 {
+		return err.Error()
+	}
+
+	var failures []string
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Field == "" {
+			failures = append(failures, cause.Message)
+		} else {
+			failures = append(failures, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+		}
+	}
+
+	return strings.Join(failures, "; ")
+}
+
+// NewOLSConfigOwnershipConflictError returns the error reported when the OLSConfig is managed by
+// the OpenStackLightspeed instance with the ownerUID. OpenStackLightspeed instances are
+// namespaced while the OLSConfig is a cluster wide singleton, so the error names the namespace
+// and name of the owner to let the user know which instance holds the OLSConfig.
+func NewOLSConfigOwnershipConflictError(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	ownerUID string,
+) error {
+	owner, err := GetOpenStackLightspeedByUID(ctx, helper, ownerUID)
+	if err != nil || owner == nil {
+		helper.GetLogger().Info("Unable to find the OpenStackLightspeed instance managing the OLSConfig",
+			"ownerUID", ownerUID)
+		return fmt.Errorf("%w (UID %s)", ErrOLSConfigOwnershipConflict, ownerUID)
+	}
+
+	return fmt.Errorf("%w %s in namespace %s", ErrOLSConfigOwnershipConflict,
+		owner.GetName(), owner.GetNamespace())
+}
+
+// GetOpenStackLightspeedByUID returns the OpenStackLightspeed instance with the given UID from any
+// namespace in the cluster. It returns (nil, nil) when there is no such instance.
+func GetOpenStackLightspeedByUID(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	uid string,
+) (*apiv1beta1.OpenStackLightspeed, error) {
+	// Use the raw client as the instance might live outside of the namespaces we watch.
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return nil, err
+	}
+
+	var instances apiv1beta1.OpenStackLightspeedList
+	if err := rawClient.List(ctx, &instances, client.InNamespace("")); err != nil {
+		return nil, err
+	}
+
+	for _, instance := range instances.Items {
+		if string(instance.GetUID()) == uid {
+			return &instance, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// IsOLSConfigOrphaned returns whether the OLSConfig managed by the instance with the ownerUID can be
+// adopted by instance because its owner no longer exists, e.g. it was deleted without cleaning up the
+// OLSConfig. OLSConfigs whose owner name labels point to another instance are never orphaned, and
+// neither are those another instance could claim, see GetOtherOLSConfigClaimant.
+func IsOLSConfigOrphaned(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	olsConfig *uns.Unstructured,
+	instance *apiv1beta1.OpenStackLightspeed,
+	ownerUID string,
+) (bool, error) {
+	if olsConfig.GetLabels()[OpenStackLightspeedOwnerNameLabel] != "" {
+		return false, nil
+	}
+
+	owner, err := GetOpenStackLightspeedByUID(ctx, helper, ownerUID)
+	if err != nil {
+		return false, err
+	} else if owner != nil {
+		return false, nil
+	}
+
+	claimant, err := GetOtherOLSConfigClaimant(ctx, helper, instance)
+	if err != nil {
+		return false, err
+	}
+
+	return claimant == nil, nil
+}
+
+// GetOtherOLSConfigClaimant returns an OpenStackLightspeed instance other than instance that has
+// written the OLSConfig before, and could therefore claim an OLSConfig that lost its owner label. It
+// returns (nil, nil) when instance is the only possible manager.
+func GetOtherOLSConfigClaimant(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (*apiv1beta1.OpenStackLightspeed, error) {
+	// Use the raw client as the instances might live outside of the namespaces we watch.
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return nil, err
+	}
+
+	var instances apiv1beta1.OpenStackLightspeedList
+	if err := rawClient.List(ctx, &instances, client.InNamespace("")); err != nil {
+		return nil, err
+	}
+
+	for _, other := range instances.Items {
+		// The current model is only recorded once the OLSConfig was written by the instance
+		if other.GetUID() != instance.GetUID() && other.GetDeletionTimestamp().IsZero() &&
+			other.Status.CurrentModel != "" {
+			return &other, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetOLSConfigChangedPaths returns the sorted, dot separated paths of the OLSConfig fields that
+// the JSON merge patch patchData sets or removes.
+func GetOLSConfigChangedPaths(patchData []byte) ([]string, error) {
+	var patch map[string]interface{}
+	if err := json.Unmarshal(patchData, &patch); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	var collect func(prefix string, value interface{})
+	collect = func(prefix string, value interface{}) {
+		fields, isObject := value.(map[string]interface{})
+		if !isObject || len(fields) == 0 {
+			paths = append(paths, prefix)
+			return
+		}
+
+		for name, fieldValue := range fields {
+			path := name
+			if prefix != "" {
+				path = prefix + "." + name
+			}
+			collect(path, fieldValue)
+		}
+	}
+	for name, value := range patch {
+		collect(name, value)
+	}
+
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// GetOLSConfigPrunedPaths returns the sorted paths of the spec fields of the written OLSConfig that
+// are missing from the stored OLSConfig. The API server silently prunes the fields the OLSConfig CRD
+// schema does not declare instead of rejecting the write.
+func GetOLSConfigPrunedPaths(written *uns.Unstructured, stored *uns.Unstructured) []string {
+	var paths []string
+	var collect func(path *field.Path, writtenValue interface{}, storedValue interface{})
+	collect = func(path *field.Path, writtenValue interface{}, storedValue interface{}) {
+		switch value := writtenValue.(type) {
+		case map[string]interface{}:
+			storedFields, _ := storedValue.(map[string]interface{})
+			for name, fieldValue := range value {
+				if storedFieldValue, found := storedFields[name]; found {
+					collect(path.Child(name), fieldValue, storedFieldValue)
+				} else if fieldValue != nil {
+					paths = append(paths, path.Child(name).String())
+				}
+			}
+		case []interface{}:
+			storedItems, _ := storedValue.([]interface{})
+			for i, item := range value {
+				if i < len(storedItems) {
+					collect(path.Index(i), item, storedItems[i])
+				}
+			}
+		}
+	}
+	collect(field.NewPath("spec"), written.Object["spec"], stored.Object["spec"])
+
+	slices.Sort(paths)
+	return paths
+}
+
+// APITLSSecretKeys - keys the secret serving the OLS API certificate must hold
+var APITLSSecretKeys = []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey}
+
+// GetAPITLSSecretMissingKeys returns the keys of APITLSSecretKeys the secret lacks or holds empty
+func GetAPITLSSecretMissingKeys(secret *corev1.Secret) []string {
+	var missingKeys []string
+	for _, key := range APITLSSecretKeys {
+		if len(secret.Data[key]) == 0 {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+
+	return missingKeys
+}
+
+// isOLSObjectPresent reads the object named by the name and namespace of obj into obj and returns
+// whether it exists
+func isOLSObjectPresent(ctx context.Context, helper *common_helper.Helper, obj client.Object) (bool, error) {
+	// Use raw client as the OLS namespace might not be among the watched namespaces
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return false, err
+	}
+
+	err = rawClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+	if err != nil && k8s_errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// PatchOLSConfig patches OLSConfig with information from OpenStackLightspeed instance. The target
+// OLSConfig is computed on a copy and olsConfig is only modified when the target differs from it,
+// so that an up to date OLSConfig is never rewritten. Returns whether olsConfig was modified.
+func PatchOLSConfig(
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+	olsConfig *uns.Unstructured,
+) (bool, error) {
+	target := olsConfig.DeepCopy()
+	if err := patchOLSConfig(helper, instance, target); err != nil {
+		return false, err
+	}
+
+	// Patch an empty object as well to learn which paths the instance sets, the overlay must not
+	// touch them.
+	managed := &uns.Unstructured{Object: map[string]interface{}{}}
+	if err := patchOLSConfig(helper, instance, managed); err != nil {
+		return false, err
+	}
+
+	if err := applyOLSConfigOverlay(instance, target, managed); err != nil {
+		return false, err
+	}
+
+	isEqual, err := isUnstructuredEqual(olsConfig, target)
+	if err != nil || isEqual {
+		return false, err
+	}
+
+	olsConfig.Object = target.Object
+	return true, nil
+}
+
+// isUnstructuredEqual returns whether a and b serialize to the same JSON. Unlike a deep comparison
+// this ignores whether numbers are held as int64 or float64, which differs between the objects read
+// from the API server and the ones we build.
+func isUnstructuredEqual(a, b *uns.Unstructured) (bool, error) {
+	aJSON, err := json.Marshal(a.Object)
+	if err != nil {
+		return false, err
+	}
+
+	bJSON, err := json.Marshal(b.Object)
+	if err != nil {
+		return false, err
+	}
+
+	return string(aJSON) == string(bJSON), nil
+}
+
+// patchOLSConfig writes the information from OpenStackLightspeed instance into olsConfig
+func patchOLSConfig(
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+	olsConfig *uns.Unstructured,
+) error {
+	// OLS rejects or silently ignores an unnamed model. Never write one.
+	if instance.Spec.ModelName == "" && len(instance.Spec.Providers) == 0 {
+		return fmt.Errorf("%w: no model name is set", ErrOLSConfigInvalidDefaults)
+	}
+
+	// Patch the Providers section. The provider described by the shorthand LLM fields comes first.
+	providersPatch := []interface{}{}
+	if instance.Spec.ModelName != "" {
+		providersPatch = append(providersPatch, buildOLSConfigShorthandProvider(instance))
+	}
+
+	for _, provider := range instance.Spec.Providers {
+		providersPatch = append(providersPatch, buildOLSConfigProvider(instance, provider))
 	}
 
 	if err := uns.SetNestedSlice(olsConfig.Object, providersPatch, "spec", "llm", "providers"); err != nil {
@@ -219,10 +1166,142 @@ func PatchOLSConfig(
 	}
 
 	// Patch the RAG section
-	// Build RAG array with priorities using BuildRAGConfigs
-	ragConfigs := BuildRAGConfigs(instance, instance.Status.ActiveOCPRAGVersion)
+	// Build RAG array with priorities using BuildRAGConfigs. Drop it when OLS falls back to
+	// answering without RAG or RAG is disabled. An empty RAG array would silently disable the
+	// retrieval, it is only written when RAG is disabled explicitly.
+	ragLessFallback := IsRAGlessFallbackActive(instance)
+	if ragLessFallback || instance.Spec.DisableRAG {
+		uns.RemoveNestedField(olsConfig.Object, "spec", "ols", "rag")
+	} else {
+		ragConfigs := BuildRAGConfigs(instance, instance.Status.ActiveOCPRAGVersion)
+		if len(ragConfigs) == 0 {
+			return fmt.Errorf("%w: set ragImage, or disableRAG to run OLS without RAG",
+				ErrOLSConfigNoRAGSources)
+		}
+		if err := uns.SetNestedSlice(olsConfig.Object, ragConfigs, "spec", "ols", "rag"); err != nil {
+			return err
+		}
+	}
 
-	if err := uns.SetNestedSlice(olsConfig.Object, ragConfigs, "spec", "ols", "rag"); err != nil {
+	// Patch the pull secret of the RAG image. Drop it when the RAG image is not pulled.
+	if instance.Spec.RAGImagePullSecret != "" && instance.Spec.RAGImage != "" && !ragLessFallback &&
+		!instance.Spec.DisableRAG {
+		pullSecrets := []interface{}{map[string]interface{}{"name": instance.Spec.RAGImagePullSecret}}
+		if err := uns.SetNestedSlice(olsConfig.Object, pullSecrets, "spec", "ols", "imagePullSecrets"); err != nil {
+			return err
+		}
+	} else {
+		uns.RemoveNestedField(olsConfig.Object, "spec", "ols", "imagePullSecrets")
+	}
+
+	// Patch the scheduling constraints of the OLS pods. Drop them when unset.
+	if len(instance.Spec.NodeSelector) > 0 {
+		nodeSelector := map[string]interface{}{}
+		for key, value := range instance.Spec.NodeSelector {
+			nodeSelector[key] = value
+		}
+
+		err := uns.SetNestedMap(olsConfig.Object, nodeSelector, "spec", "ols", "deployment", "api", "nodeSelector")
+		if err != nil {
+			return err
+		}
+	} else {
+		uns.RemoveNestedField(olsConfig.Object, "spec", "ols", "deployment", "api", "nodeSelector")
+	}
+
+	if len(instance.Spec.Tolerations) > 0 {
+		tolerations := []interface{}{}
+		for _, toleration := range instance.Spec.Tolerations {
+			unsToleration, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&toleration)
+			if err != nil {
+				return err
+			}
+			tolerations = append(tolerations, unsToleration)
+		}
+
+		err := uns.SetNestedSlice(olsConfig.Object, tolerations, "spec", "ols", "deployment", "api", "tolerations")
+		if err != nil {
+			return err
+		}
+	} else {
+		uns.RemoveNestedField(olsConfig.Object, "spec", "ols", "deployment", "api", "tolerations")
+	}
+
+	// Patch the log level. OLS expects it in upper case. The CRD defaults LogLevel, it is only unset
+	// when the instance was not defaulted, in which case the OLSConfig is left as is.
+	if instance.Spec.LogLevel != "" {
+		err := uns.SetNestedField(olsConfig.Object, strings.ToUpper(instance.Spec.LogLevel), "spec", "ols", "logLevel")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Patch the number of OLS API pods
+	replicas := int64(ptr.Deref(instance.Spec.Replicas, OLSDefaultReplicas))
+	err := uns.SetNestedField(olsConfig.Object, replicas, "spec", "ols", "deployment", "replicas")
+	if err != nil {
+		return err
+	}
+
+	// Patch the compute resources of the OLS components. Drop them when unset so that OLS applies
+	// its defaults.
+	componentResources := []struct {
+		resources *corev1.ResourceRequirements
+		component string
+	}{
+		{instance.Spec.APIResources, "api"},
+		{instance.Spec.ConsoleResources, "console"},
+	}
+
+	for _, c := range componentResources {
+		if c.resources == nil {
+			uns.RemoveNestedField(olsConfig.Object, "spec", "ols", "deployment", c.component, "resources")
+			continue
+		}
+
+		resources, err := runtime.DefaultUnstructuredConverter.ToUnstructured(
+			getResourcesWithDefaultRequests(c.resources))
+		if err != nil {
+			return err
+		}
+
+		err = uns.SetNestedMap(olsConfig.Object, resources, "spec", "ols", "deployment", c.component, "resources")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Patch the certificate the OLS API is served with. OLS falls back to the service serving
+	// certificate when it is dropped.
+	if apiTLS := instance.Spec.APITLS; apiTLS != nil {
+		err := uns.SetNestedField(olsConfig.Object, apiTLS.SecretName, "spec", "ols", "tlsConfig", "keyCertSecretRef", "name")
+		if err != nil {
+			return err
+		}
+	} else {
+		uns.RemoveNestedField(olsConfig.Object, "spec", "ols", "tlsConfig")
+	}
+
+	// Patch the filters redacting the user queries. Drop them when unset.
+	if len(instance.Spec.QueryFilters) > 0 {
+		filters := []interface{}{}
+		for _, filter := range instance.Spec.QueryFilters {
+			filters = append(filters, map[string]interface{}{
+				"name":        filter.Name,
+				"pattern":     filter.Pattern,
+				"replaceWith": filter.ReplaceWith,
+			})
+		}
+
+		if err := uns.SetNestedSlice(olsConfig.Object, filters, "spec", "ols", "queryFilters"); err != nil {
+			return err
+		}
+	} else {
+		uns.RemoveNestedField(olsConfig.Object, "spec", "ols", "queryFilters")
+	}
+
+	// Patch the conversation cache. The cache type is left to OLS when unset.
+	if err := patchOLSConfigConversationCache(olsConfig, instance.Spec.ConversationCache); err != nil {
 		return err
 	}
 
@@ -234,33 +1313,46 @@ func PatchOLSConfig(
 		}
 	}
 
-	modelName := instance.Spec.ModelName
-	err := uns.SetNestedField(olsConfig.Object, modelName, "spec", "ols", "defaultModel")
+	defaultProvider, defaultModel := getOLSConfigDefaults(providersPatch, instance.Spec.DefaultProvider)
+	err = uns.SetNestedField(olsConfig.Object, defaultModel, "spec", "ols", "defaultModel")
+	if err != nil {
+		return err
+	}
+
+	err = uns.SetNestedField(olsConfig.Object, defaultProvider, "spec", "ols", "defaultProvider")
 	if err != nil {
 		return err
 	}
 
-	err = uns.SetNestedField(olsConfig.Object, OpenStackLightspeedDefaultProvider, "spec", "ols", "defaultProvider")
+	repaired, err := RepairOLSConfigDefaults(olsConfig)
 	if err != nil {
 		return err
+	} else if repaired {
+		defaultProvider, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultProvider")
+		defaultModel, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultModel")
+		helper.GetLogger().Info("OLSConfig default provider and model repaired",
+			"defaultProvider", defaultProvider, "defaultModel", defaultModel)
 	}
 
-	// Disable the OCP RAG
-	// TODO(lucasagomes): Remove this once we have a "query router" that can
-	// handle multiple RAGs nicely
-	err = uns.SetNestedField(olsConfig.Object, true, "spec", "ols", "byokRAGOnly")
+	// Restrict the answers to the BYOK RAG sources. There are none left in the RAGless fallback or
+	// when RAG is disabled, and the OCP documentation is not a BYOK source.
+	err = uns.SetNestedField(olsConfig.Object,
+		!ragLessFallback && !instance.Spec.DisableRAG && instance.Status.ActiveOCPRAGVersion == "",
+		"spec", "ols", "byokRAGOnly")
 	if err != nil {
 		return err
 	}
 
-	// Disable or enable feedback collection
-	err = uns.SetNestedField(olsConfig.Object, instance.Spec.FeedbackDisabled, "spec", "ols", "userDataCollection", "feedbackDisabled")
+	// Disable or enable feedback collection, DisableDataCollection turns off all collection
+	feedbackDisabled := instance.Spec.FeedbackDisabled || instance.Spec.DisableDataCollection
+	err = uns.SetNestedField(olsConfig.Object, feedbackDisabled, "spec", "ols", "userDataCollection", "feedbackDisabled")
 	if err != nil {
 		return err
 	}
 
 	// Disable or enable transcripts collection
-	err = uns.SetNestedField(olsConfig.Object, instance.Spec.TranscriptsDisabled, "spec", "ols", "userDataCollection", "transcriptsDisabled")
+	transcriptsDisabled := instance.Spec.TranscriptsDisabled || instance.Spec.DisableDataCollection
+	err = uns.SetNestedField(olsConfig.Object, transcriptsDisabled, "spec", "ols", "userDataCollection", "transcriptsDisabled")
 	if err != nil {
 		return err
 	}
@@ -278,12 +1370,25 @@ func PatchOLSConfig(
 	for k, v := range labels {
 		updatedLabels[k] = v
 	}
+	updatedLabels[OpenStackLightspeedOwnerNameLabel] = instance.GetName()
+	updatedLabels[OpenStackLightspeedOwnerNamespaceLabel] = instance.GetNamespace()
+
+	// OLSConfigPing used to write a random label, drop it along with this patch so that cleaning
+	// up the older OLSConfigs costs no extra write.
+	delete(updatedLabels, olsConfigLegacyPingLabel)
 
 	err = uns.SetNestedField(olsConfig.Object, updatedLabels, "metadata", "labels")
 	if err != nil {
 		return err
 	}
 
+	// Leave the OLSConfig lifecycle to whoever manages it externally and drop the finalizer we
+	// might have added before.
+	if !ptr.Deref(instance.Spec.ManageOLSConfigFinalizer, true) {
+		controllerutil.RemoveFinalizer(olsConfig, helper.GetFinalizer())
+		return nil
+	}
+
 	// Add OpenStack finalizers
 	if !controllerutil.AddFinalizer(olsConfig, helper.GetFinalizer()) && instance.Status.Conditions == nil {
 		return fmt.Errorf("cannot add finalizer")
@@ -292,25 +1397,63 @@ func PatchOLSConfig(
 	return nil
 }
 
-// IsOLSConfigReady returns true if OLSConfig's overallStatus is Ready
-func IsOLSConfigReady(ctx context.Context, helper *common_helper.Helper) (bool, error) {
+// IsOLSConfigManagedByInstanceName returns whether the owner name and namespace labels of the
+// OLSConfig point to the given instance. See OpenStackLightspeedOwnerNameLabel.
+func IsOLSConfigManagedByInstanceName(olsConfig *uns.Unstructured, instance *apiv1beta1.OpenStackLightspeed) bool {
+	labels := olsConfig.GetLabels()
+	return labels[OpenStackLightspeedOwnerNameLabel] == instance.GetName() &&
+		labels[OpenStackLightspeedOwnerNamespaceLabel] == instance.GetNamespace()
+}
+
+// IsOLSConfigReady returns true if all the requiredConditions of the OLSConfig status are True.
+// When requiredConditions is empty, the OLSConfig's overallStatus must be Ready instead.
+func IsOLSConfigReady(ctx context.Context, helper *common_helper.Helper, requiredConditions []string) (bool, error) {
 	olsConfig, err := GetOLSConfig(ctx, helper)
 	if err != nil {
 		return false, err
 	}
 
+	if len(requiredConditions) > 0 {
+		for _, conditionType := range requiredConditions {
+			if !isStatusConditionTrue(&olsConfig, conditionType) {
+				return false, pingOLSConfigIfEnabled(ctx, helper)
+			}
+		}
+
+		return true, nil
+	}
+
 	overallStatus, found, err := uns.NestedString(olsConfig.Object, "status", "overallStatus")
 	if err != nil {
 		return false, err
 	}
 
 	if !found || overallStatus != "Ready" {
-		return false, OLSConfigPing(ctx, helper)
+		return false, pingOLSConfigIfEnabled(ctx, helper)
 	}
 
 	return true, nil
 }
 
+// GetOLSConfigGenerations returns the generation of the OLSConfig and the observedGeneration OLS
+// reports in its status. found is false when OLS does not report an observedGeneration.
+func GetOLSConfigGenerations(
+	ctx context.Context,
+	helper *common_helper.Helper,
+) (generation int64, observedGeneration int64, found bool, err error) {
+	olsConfig, err := GetOLSConfig(ctx, helper)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	observedGeneration, found, err = uns.NestedInt64(olsConfig.Object, "status", "observedGeneration")
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	return olsConfig.GetGeneration(), observedGeneration, found, nil
+}
+
 // IsOwnedBy returns true if 'object' is owned by 'owner' based on OwnerReference UID.
 func IsOwnedBy(object metav1.Object, owner metav1.Object) bool {
 	for _, ref := range object.GetOwnerReferences() {
@@ -325,6 +1468,12 @@ func IsOwnedBy(object metav1.Object, owner metav1.Object) bool {
 // This is useful for operations that need to query resources across all namespaces
 // cluster wide.
 func GetRawClient(helper *common_helper.Helper) (client.Client, error) {
+	return newRawClient(helper)
+}
+
+// newRawClient - builds the client returned by GetRawClient. It is a variable so that unit tests
+// can replace the cluster wide client with a fake one.
+var newRawClient = func(helper *common_helper.Helper) (client.Client, error) {
 	cfg, err := config.GetConfig()
 	if err != nil {
 		return nil, err
@@ -338,30 +1487,55 @@ func GetRawClient(helper *common_helper.Helper) (client.Client, error) {
 	return rawClient, nil
 }
 
-// OLSConfigPing adds a random label to the OLSConfig to trigger a reconciliation
+// olsConfigPingKey - key of the annotation OLSConfigPing writes. Older versions wrote it as a label.
+const olsConfigPingKey = "openstack-lightspeed/ping"
+
+// olsConfigLegacyPingLabel - label OLSConfigPing wrote before it switched to an annotation
+const olsConfigLegacyPingLabel = olsConfigPingKey
+
+// OLSConfigPingEnabledEnvVar - Environment variable that makes the readiness check ping the
+// OLSConfig when set to "true". The OLSConfig status changes are watched instead by default, the
+// ping is a fallback for clusters where the watch misbehaves.
+const OLSConfigPingEnabledEnvVar = "ENABLE_OLSCONFIG_PING"
+
+// OLSConfigPingInterval - Minimum time between two OLSConfig pings. The status update OLS makes in
+// reaction to a ping triggers another reconcile, which would otherwise ping again right away.
+const OLSConfigPingInterval = 30 * time.Second
+
+// pingOLSConfigIfEnabled pings the OLSConfig when OLSConfigPingEnabledEnvVar is "true"
+func pingOLSConfigIfEnabled(ctx context.Context, helper *common_helper.Helper) error {
+	if os.Getenv(OLSConfigPingEnabledEnvVar) != "true" {
+		return nil
+	}
+
+	return OLSConfigPing(ctx, helper)
+}
+
+// OLSConfigPing annotates the OLSConfig with the current time to trigger a reconciliation
 // by the OpenShift Lightspeed operator. This causes the operator to update the Status field.
+// The OLSConfig is left untouched when it was pinged less than OLSConfigPingInterval ago.
 // Note: This is a workaround for a current limitation—when the OLS operator is installed
 // in the openstack-lightspeed namespace, it does not automatically update the OLSConfig
 // status as expected.
 func OLSConfigPing(ctx context.Context, helper *common_helper.Helper) error {
-	const randomLabelKey = "openstack-lightspeed/ping"
-
 	olsConfig, err := GetOLSConfig(ctx, helper)
 	if err != nil {
 		return err
 	}
 
-	labels := olsConfig.GetLabels()
-	if labels == nil {
-		labels = make(map[string]string)
+	annotations := olsConfig.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
 	}
 
-	randInt, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
-	if err != nil {
-		return err
+	// Older versions wrote a random number, which is not a valid time
+	lastPing, err := time.Parse(time.RFC3339Nano, annotations[olsConfigPingKey])
+	if err == nil && time.Since(lastPing) < OLSConfigPingInterval {
+		return nil
 	}
-	labels[randomLabelKey] = strconv.FormatInt(randInt.Int64(), 10)
-	olsConfig.SetLabels(labels)
+
+	annotations[olsConfigPingKey] = time.Now().UTC().Format(time.RFC3339Nano)
+	olsConfig.SetAnnotations(annotations)
 
 	if err := helper.GetClient().Update(ctx, &olsConfig); err != nil {
 		return err
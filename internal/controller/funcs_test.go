@@ -0,0 +1,2151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+// patchTestOLSConfig runs PatchOLSConfig for instance against olsConfig (or an empty OLSConfig
+// when nil) and returns the patched object.
+func patchTestOLSConfig(
+	t *testing.T,
+	instance *apiv1beta1.OpenStackLightspeed,
+	olsConfig *uns.Unstructured,
+) *uns.Unstructured {
+	t.Helper()
+
+	if olsConfig == nil {
+		olsConfig = &uns.Unstructured{}
+		olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+		olsConfig.SetName(OLSConfigName)
+	}
+
+	cl := newTestClient(t)
+	helper := newTestHelper(t, cl, instance)
+	if _, err := PatchOLSConfig(helper, instance, olsConfig); err != nil {
+		t.Fatalf("PatchOLSConfig unexpected error: %v", err)
+	}
+
+	return olsConfig
+}
+
+func TestGetOLSConfigChangedPaths(t *testing.T) {
+	instance := newTestInstance()
+	instance.Spec.Replicas = ptr.To[int32](3)
+	olsConfig := patchTestOLSConfig(t, instance, nil)
+	_ = uns.SetNestedField(olsConfig.Object, "Ready", "status", "overallStatus")
+	original := olsConfig.DeepCopy()
+
+	instance.Spec.ModelName = "other-model"
+	instance.Spec.Replicas = nil
+	instance.Status.Conditions = condition.Conditions{}
+	olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+	patchData, err := client.MergeFrom(original).Data(olsConfig)
+	if err != nil {
+		t.Fatalf("failed to compute the OLSConfig patch: %v", err)
+	}
+
+	paths, err := GetOLSConfigChangedPaths(patchData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"spec.llm.providers",
+		"spec.ols.defaultModel",
+		"spec.ols.deployment.replicas",
+	}
+	if !slices.Equal(paths, expected) {
+		t.Errorf("GetOLSConfigChangedPaths() = %v, want %v", paths, expected)
+	}
+}
+
+func TestGetOLSConfigPrunedPaths(t *testing.T) {
+	written := &uns.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"ols": map[string]interface{}{
+				"defaultModel": "granite",
+				"rag": []interface{}{
+					map[string]interface{}{"image": "rag-content", "indexPath": "/rag/vector_db", "topK": int64(5)},
+				},
+				"queryRouter": map[string]interface{}{"strategy": "Merge"},
+				"logLevel":    nil,
+			},
+		},
+	}}
+	stored := &uns.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"ols": map[string]interface{}{
+				"defaultModel": "granite",
+				"rag": []interface{}{
+					map[string]interface{}{"image": "rag-content", "indexPath": "/rag/vector_db"},
+				},
+			},
+		},
+		"status": map[string]interface{}{"overallStatus": "Ready"},
+	}}
+
+	expected := []string{"spec.ols.queryRouter", "spec.ols.rag[0].topK"}
+	if paths := GetOLSConfigPrunedPaths(written, stored); !slices.Equal(paths, expected) {
+		t.Errorf("GetOLSConfigPrunedPaths() = %v, want %v", paths, expected)
+	}
+
+	if paths := GetOLSConfigPrunedPaths(stored, stored); len(paths) != 0 {
+		t.Errorf("expected no pruned paths for a stored OLSConfig, got %v", paths)
+	}
+}
+
+func TestCreateOrPatchOLSConfigConcurrentInstances(t *testing.T) {
+	const (
+		instanceCount = 5
+		patchCount    = 3
+	)
+
+	cl := newTestClient(t)
+
+	instances := make([]*apiv1beta1.OpenStackLightspeed, instanceCount)
+	helpers := make([]*common_helper.Helper, instanceCount)
+	for i := range instances {
+		instance := newTestInstance()
+		instance.Name = fmt.Sprintf("%s-%d", testInstanceName, i)
+		instance.UID = types.UID(fmt.Sprintf("instance-uid-%d", i))
+		instance.Spec.ModelName = fmt.Sprintf("model-%d", i)
+		// The finalizer check in PatchOLSConfig expects the conditions of an already
+		// reconciled instance.
+		instance.Status.Conditions = condition.Conditions{}
+
+		instances[i] = instance
+		helpers[i] = newTestHelper(t, cl, instance)
+	}
+
+	// Every instance tries to manage the singleton OLSConfig at the same time, the way it
+	// happens when reconciles of different instances run concurrently.
+	failures := make([]int, instanceCount)
+	var wg sync.WaitGroup
+	for i := range instances {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for range patchCount {
+				if err := CreateOrPatchOLSConfig(context.Background(), helpers[i], instances[i]); err != nil {
+					failures[i]++
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	olsConfig, err := getTestOLSConfig(t, cl)
+	if err != nil {
+		t.Fatalf("failed to get OLSConfig: %v", err)
+	}
+
+	var owner *apiv1beta1.OpenStackLightspeed
+	for i, instance := range instances {
+		isOwner := olsConfig.GetLabels()[OpenStackLightspeedOwnerIDLabel] == string(instance.UID)
+		if isOwner {
+			owner = instance
+		}
+
+		if isOwner && failures[i] != 0 {
+			t.Errorf("owner %s failed to patch the OLSConfig %d times", instance.Name, failures[i])
+		} else if !isOwner && failures[i] != patchCount {
+			t.Errorf("%s patched an OLSConfig it does not own", instance.Name)
+		}
+	}
+
+	if owner == nil {
+		t.Fatalf("OLSConfig is not owned by any instance: %v", olsConfig.GetLabels())
+	}
+
+	providers, _, _ := uns.NestedSlice(olsConfig.Object, "spec", "llm", "providers")
+	if len(providers) != 1 {
+		t.Fatalf("expected a single provider, got %v", providers)
+	}
+	models, _, _ := uns.NestedSlice(providers[0].(map[string]interface{}), "models")
+	if len(models) != 1 || models[0].(map[string]interface{})["name"] != owner.Spec.ModelName {
+		t.Errorf("OLSConfig models = %v, want the model of the owner %s", models, owner.Spec.ModelName)
+	}
+}
+
+func TestCreateOrPatchOLSConfigInterleavedWrites(t *testing.T) {
+	first := newTestInstance()
+	first.Name = testInstanceName + "-first"
+	first.UID = types.UID("first-uid")
+	first.Spec.ModelName = "first-model"
+	first.Status.Conditions = condition.Conditions{}
+
+	second := newTestInstance()
+	second.Name = testInstanceName + "-second"
+	second.UID = types.UID("second-uid")
+	second.Spec.ModelName = "second-model"
+	second.Status.Conditions = condition.Conditions{}
+
+	// An OLSConfig that is not owned by any instance yet, so both instances may claim it
+	olsConfig := newTestOLSConfig(first, false)
+	olsConfig.SetLabels(nil)
+	olsConfig.SetFinalizers(nil)
+
+	// The second instance writes the OLSConfig after the first one read it but before the first
+	// one's write lands, the way it happens when the reconciles run concurrently.
+	var secondHelper *common_helper.Helper
+	var secondErr error
+	interleaved := false
+	writes := 0
+	cl := newTestClientWithInterceptor(t, interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if !interleaved {
+				interleaved = true
+				secondErr = CreateOrPatchOLSConfig(ctx, secondHelper, second)
+			}
+
+			err := c.Patch(ctx, obj, patch, opts...)
+			if err == nil {
+				writes++
+			}
+			return err
+		},
+	}, first, second, olsConfig)
+	secondHelper = newTestHelper(t, cl, second)
+
+	err := CreateOrPatchOLSConfig(context.Background(), newTestHelper(t, cl, first), first)
+	if !errors.Is(err, ErrOLSConfigWriteConflict) {
+		t.Errorf("expected the first write to be rejected with ErrOLSConfigWriteConflict, got %v", err)
+	}
+	if secondErr != nil {
+		t.Fatalf("expected the second write to succeed, got %v", secondErr)
+	}
+	if writes != 1 {
+		t.Errorf("expected a single OLSConfig write, got %d", writes)
+	}
+
+	olsConfig, _ = getTestOLSConfig(t, cl)
+	if owner := olsConfig.GetLabels()[OpenStackLightspeedOwnerIDLabel]; owner != string(second.UID) {
+		t.Errorf("owner label = %q, want %q", owner, second.UID)
+	}
+	if model, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultModel"); model != second.Spec.ModelName {
+		t.Errorf("defaultModel = %q, want %q", model, second.Spec.ModelName)
+	}
+
+	// The retry of the first instance respects the new owner.
+	err = CreateOrPatchOLSConfig(context.Background(), newTestHelper(t, cl, first), first)
+	if err == nil || errors.Is(err, ErrOLSConfigWriteConflict) {
+		t.Errorf("expected the retry to report the ownership conflict, got %v", err)
+	}
+	if writes != 1 {
+		t.Errorf("expected the retry not to write the OLSConfig, got %d writes", writes)
+	}
+}
+
+func TestPatchOLSConfigUnmanagedFinalizer(t *testing.T) {
+	instance := newTestInstance()
+	instance.Spec.ManageOLSConfigFinalizer = ptr.To(false)
+
+	// A finalizer added while the finalizer was still managed has to be dropped.
+	olsConfig := newTestOLSConfig(instance, false)
+	olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+	if finalizers := olsConfig.GetFinalizers(); len(finalizers) != 0 {
+		t.Errorf("expected no finalizers, got %v", finalizers)
+	}
+}
+
+func TestRemoveOLSConfigUnmanagedFinalizer(t *testing.T) {
+	instance := newTestInstance()
+	instance.Spec.ManageOLSConfigFinalizer = ptr.To(false)
+
+	olsConfig := newTestOLSConfig(instance, true)
+	olsConfig.SetFinalizers([]string{"example.com/gitops"})
+	cl := newTestClient(t, instance, olsConfig)
+	helper := newTestHelper(t, cl, instance)
+
+	isRemoved, err := RemoveOLSConfig(context.Background(), helper, instance)
+	if err != nil {
+		t.Fatalf("RemoveOLSConfig unexpected error: %v", err)
+	}
+	if !isRemoved {
+		t.Errorf("expected RemoveOLSConfig to report completion")
+	}
+
+	olsConfig, err = getTestOLSConfig(t, cl)
+	if err != nil {
+		t.Fatalf("expected OLSConfig to be kept, got %v", err)
+	}
+	if !olsConfig.GetDeletionTimestamp().IsZero() {
+		t.Errorf("expected OLSConfig not to be deleted")
+	}
+	if finalizers := olsConfig.GetFinalizers(); len(finalizers) != 1 || finalizers[0] != "example.com/gitops" {
+		t.Errorf("expected finalizers to be untouched, got %v", finalizers)
+	}
+}
+
+func TestCreateOrPatchOLSConfigOwnedByInstanceInOtherNamespace(t *testing.T) {
+	owner := newTestInstance()
+	owner.Namespace = "team-a"
+	owner.UID = types.UID("owner-uid")
+
+	instance := newTestInstance()
+	instance.Namespace = "team-b"
+
+	// The owner name labels keep the OLSConfig of a deleted owner from being adopted
+	namedOLSConfig := newTestOLSConfig(owner, true)
+	namedOLSConfig.SetLabels(map[string]string{
+		OpenStackLightspeedOwnerIDLabel:        string(owner.UID),
+		OpenStackLightspeedOwnerNameLabel:      owner.Name,
+		OpenStackLightspeedOwnerNamespaceLabel: owner.Namespace,
+	})
+
+	tests := []struct {
+		name            string
+		objs            []client.Object
+		expectedMessage string
+	}{
+		{
+			name: "Owner found",
+			objs: []client.Object{owner, instance, newTestOLSConfig(owner, true)},
+			expectedMessage: "OLSConfig is managed by different OpenStackLightspeed instance " +
+				testInstanceName + " in namespace team-a",
+		},
+		{
+			name:            "Owner not found",
+			objs:            []client.Object{instance, namedOLSConfig},
+			expectedMessage: "OLSConfig is managed by different OpenStackLightspeed instance (UID owner-uid)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := newTestClient(t, tt.objs...)
+			helper := newTestHelper(t, cl, instance)
+
+			err := CreateOrPatchOLSConfig(context.Background(), helper, instance)
+			if !errors.Is(err, ErrOLSConfigOwnershipConflict) {
+				t.Fatalf("expected ErrOLSConfigOwnershipConflict, got %v", err)
+			}
+			if err.Error() != tt.expectedMessage {
+				t.Errorf("error = %q, want %q", err.Error(), tt.expectedMessage)
+			}
+
+			olsConfig, err := getTestOLSConfig(t, cl)
+			if err != nil {
+				t.Fatalf("failed to get OLSConfig: %v", err)
+			}
+			if olsConfig.GetLabels()[OpenStackLightspeedOwnerIDLabel] != string(owner.UID) {
+				t.Errorf("expected OLSConfig to stay owned by %s", owner.UID)
+			}
+		})
+	}
+}
+
+func TestCreateOrPatchOLSConfigAdoptsOrphanedOLSConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		ownerLabels   bool
+		otherClaimant bool
+		expectAdopted bool
+	}{
+		{
+			name:          "Instance deleted and recreated with the same name",
+			ownerLabels:   true,
+			expectAdopted: true,
+		},
+		{
+			name:          "Owner deleted, OLSConfig without owner name labels",
+			expectAdopted: true,
+		},
+		{
+			name:          "Owner deleted, another instance managed the OLSConfig",
+			otherClaimant: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The deleted instance left its OLSConfig behind, the recreated one got a new UID
+			deleted := newTestInstance()
+			deleted.UID = types.UID("deleted-uid")
+			olsConfig := newTestOLSConfig(deleted, true)
+			if tt.ownerLabels {
+				olsConfig.SetLabels(map[string]string{
+					OpenStackLightspeedOwnerIDLabel:        string(deleted.UID),
+					OpenStackLightspeedOwnerNameLabel:      deleted.Name,
+					OpenStackLightspeedOwnerNamespaceLabel: deleted.Namespace,
+				})
+			}
+
+			instance := newTestInstance()
+			instance.Status.Conditions = condition.Conditions{}
+
+			objs := []client.Object{instance, olsConfig}
+			if tt.otherClaimant {
+				other := newTestInstance()
+				other.Name = "other-instance"
+				other.UID = "other-uid"
+				other.Status.CurrentModel = other.Spec.ModelName
+				objs = append(objs, other)
+			}
+
+			cl := newTestClient(t, objs...)
+			helper := newTestHelper(t, cl, instance)
+
+			err := CreateOrPatchOLSConfig(context.Background(), helper, instance)
+			if tt.expectAdopted && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if !tt.expectAdopted && err == nil {
+				t.Fatalf("expected an ownership conflict")
+			}
+
+			olsConfig, err = getTestOLSConfig(t, cl)
+			if err != nil {
+				t.Fatalf("failed to get OLSConfig: %v", err)
+			}
+
+			expectedOwnerID := string(deleted.UID)
+			if tt.expectAdopted {
+				expectedOwnerID = string(instance.UID)
+			}
+			if ownerID := olsConfig.GetLabels()[OpenStackLightspeedOwnerIDLabel]; ownerID != expectedOwnerID {
+				t.Errorf("owner ID label = %q, want %q", ownerID, expectedOwnerID)
+			}
+		})
+	}
+}
+
+func TestCreateOrPatchOLSConfigReadoptsPreviousOwnerScheme(t *testing.T) {
+	tests := []struct {
+		name           string
+		ownerNamespace string
+		expectAdopted  bool
+	}{
+		{
+			name:           "Managed by the instance under the previous owner ID",
+			ownerNamespace: testInstanceNamespace,
+			expectAdopted:  true,
+		},
+		{
+			name:           "Managed by an instance with the same name in another namespace",
+			ownerNamespace: "team-a",
+			expectAdopted:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			instance.Status.Conditions = condition.Conditions{}
+
+			// An earlier operator version wrote a shortened UID as the owner ID
+			olsConfig := newTestOLSConfig(instance, true)
+			olsConfig.SetLabels(map[string]string{
+				OpenStackLightspeedOwnerIDLabel:        testInstanceUID[:8],
+				OpenStackLightspeedOwnerNameLabel:      testInstanceName,
+				OpenStackLightspeedOwnerNamespaceLabel: tt.ownerNamespace,
+				"example.com/user-label":               "kept",
+			})
+
+			cl := newTestClient(t, instance, olsConfig)
+			helper := newTestHelper(t, cl, instance)
+
+			err := CreateOrPatchOLSConfig(context.Background(), helper, instance)
+			if tt.expectAdopted && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if !tt.expectAdopted && err == nil {
+				t.Fatalf("expected an ownership conflict")
+			}
+
+			olsConfig, err = getTestOLSConfig(t, cl)
+			if err != nil {
+				t.Fatalf("failed to get OLSConfig: %v", err)
+			}
+
+			expectedOwnerID := testInstanceUID[:8]
+			if tt.expectAdopted {
+				expectedOwnerID = testInstanceUID
+			}
+			labels := olsConfig.GetLabels()
+			if labels[OpenStackLightspeedOwnerIDLabel] != expectedOwnerID {
+				t.Errorf("owner ID label = %q, want %q", labels[OpenStackLightspeedOwnerIDLabel], expectedOwnerID)
+			}
+			if labels["example.com/user-label"] != "kept" {
+				t.Errorf("expected the other OLSConfig labels to be kept, got %v", labels)
+			}
+		})
+	}
+}
+
+func TestCreateOrPatchOLSConfigAdoptsRecreatedOLSConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		otherClaimant bool
+		expectAdopted bool
+	}{
+		{
+			name:          "Instance is the only manager",
+			expectAdopted: true,
+		},
+		{
+			name:          "Another instance managed the OLSConfig",
+			otherClaimant: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			instance.Status.CurrentModel = instance.Spec.ModelName
+
+			// OLS re-created the OLSConfig without our labels and finalizer
+			olsConfig := newTestOLSConfig(instance, true)
+			olsConfig.SetLabels(nil)
+			olsConfig.SetFinalizers(nil)
+
+			objs := []client.Object{instance, olsConfig}
+			if tt.otherClaimant {
+				other := newTestInstance()
+				other.Name = "other-instance"
+				other.UID = "other-uid"
+				other.Status.CurrentModel = other.Spec.ModelName
+				objs = append(objs, other)
+			}
+
+			cl := newTestClient(t, objs...)
+			helper := newTestHelper(t, cl, instance)
+
+			err := CreateOrPatchOLSConfig(context.Background(), helper, instance)
+			if tt.expectAdopted && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if !tt.expectAdopted && err == nil {
+				t.Fatalf("expected the OLSConfig to be left to the other instance")
+			}
+
+			olsConfig, err = getTestOLSConfig(t, cl)
+			if err != nil {
+				t.Fatalf("failed to get OLSConfig: %v", err)
+			}
+
+			adopted := olsConfig.GetLabels()[OpenStackLightspeedOwnerIDLabel] == string(instance.GetUID())
+			if adopted != tt.expectAdopted {
+				t.Errorf("OLSConfig labels = %v, adoption expected: %v", olsConfig.GetLabels(), tt.expectAdopted)
+			}
+			if slices.Contains(olsConfig.GetFinalizers(), helper.GetFinalizer()) != tt.expectAdopted {
+				t.Errorf("OLSConfig finalizers = %v, adoption expected: %v", olsConfig.GetFinalizers(), tt.expectAdopted)
+			}
+		})
+	}
+}
+
+func TestCreateOrPatchOLSConfigStripsLegacyPingLabel(t *testing.T) {
+	instance := newTestInstance()
+	instance.Status.Conditions = condition.Conditions{}
+
+	olsConfig := newTestOLSConfig(instance, true)
+	labels := olsConfig.GetLabels()
+	labels[olsConfigLegacyPingLabel] = "8674665223082153551"
+	olsConfig.SetLabels(labels)
+
+	var writes int
+	cl := newTestClientWithInterceptor(t, interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			writes++
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			writes++
+			return c.Update(ctx, obj, opts...)
+		},
+	}, instance, olsConfig)
+	helper := newTestHelper(t, cl, instance)
+
+	for i := 0; i < 2; i++ {
+		instance.Status.Conditions = condition.Conditions{}
+		if err := CreateOrPatchOLSConfig(context.Background(), helper, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if writes != 1 {
+		t.Errorf("OLSConfig written %d times, want the legacy label stripped by a single write", writes)
+	}
+
+	olsConfig, err := getTestOLSConfig(t, cl)
+	if err != nil {
+		t.Fatalf("failed to get OLSConfig: %v", err)
+	}
+	if _, found := olsConfig.GetLabels()[olsConfigLegacyPingLabel]; found {
+		t.Errorf("expected the legacy ping label to be removed, got labels %v", olsConfig.GetLabels())
+	}
+}
+
+func TestOLSConfigPingAnnotates(t *testing.T) {
+	instance := newTestInstance()
+	cl := newTestClient(t, instance, newTestOLSConfig(instance, false))
+	helper := newTestHelper(t, cl, instance)
+
+	if err := OLSConfigPing(context.Background(), helper); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	olsConfig, err := getTestOLSConfig(t, cl)
+	if err != nil {
+		t.Fatalf("failed to get OLSConfig: %v", err)
+	}
+	if olsConfig.GetAnnotations()[olsConfigPingKey] == "" {
+		t.Errorf("expected the ping annotation to be set, got annotations %v", olsConfig.GetAnnotations())
+	}
+	if _, found := olsConfig.GetLabels()[olsConfigLegacyPingLabel]; found {
+		t.Errorf("expected no ping label, got labels %v", olsConfig.GetLabels())
+	}
+}
+
+func TestIsOLSConfigReadyPing(t *testing.T) {
+	tests := []struct {
+		name           string
+		pingEnabled    string
+		expectedPinged bool
+	}{
+		{
+			name:           "Status changes are watched",
+			expectedPinged: false,
+		},
+		{
+			name:           "Ping enabled",
+			pingEnabled:    "true",
+			expectedPinged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(OLSConfigPingEnabledEnvVar, tt.pingEnabled)
+
+			instance := newTestInstance()
+			cl := newTestClient(t, instance, newTestOLSConfig(instance, false))
+			helper := newTestHelper(t, cl, instance)
+
+			ready, err := IsOLSConfigReady(context.Background(), helper, nil)
+			if err != nil || ready {
+				t.Fatalf("IsOLSConfigReady = (%v, %v), want (false, nil)", ready, err)
+			}
+
+			olsConfig, err := getTestOLSConfig(t, cl)
+			if err != nil {
+				t.Fatalf("failed to get OLSConfig: %v", err)
+			}
+			if _, pinged := olsConfig.GetAnnotations()[olsConfigPingKey]; pinged != tt.expectedPinged {
+				t.Errorf("OLSConfig pinged = %v, want %v", pinged, tt.expectedPinged)
+			}
+		})
+	}
+}
+
+func TestOLSConfigPingInterval(t *testing.T) {
+	instance := newTestInstance()
+	cl := newTestClient(t, instance, newTestOLSConfig(instance, false))
+	helper := newTestHelper(t, cl, instance)
+
+	if err := OLSConfigPing(context.Background(), helper); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pinged, err := getTestOLSConfig(t, cl)
+	if err != nil {
+		t.Fatalf("failed to get OLSConfig: %v", err)
+	}
+
+	if err := OLSConfigPing(context.Background(), helper); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	olsConfig, err := getTestOLSConfig(t, cl)
+	if err != nil {
+		t.Fatalf("failed to get OLSConfig: %v", err)
+	}
+	if olsConfig.GetResourceVersion() != pinged.GetResourceVersion() {
+		t.Errorf("expected no OLSConfig write within OLSConfigPingInterval of the previous ping")
+	}
+}
+
+func TestPatchOLSConfigUnchanged(t *testing.T) {
+	instance := newTestInstance()
+	instance.Status.Conditions = condition.Conditions{}
+	helper := newTestHelper(t, newTestClient(t), instance)
+
+	olsConfig := &uns.Unstructured{}
+	olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+	olsConfig.SetName(OLSConfigName)
+
+	isPatched, err := PatchOLSConfig(helper, instance, olsConfig)
+	if err != nil {
+		t.Fatalf("PatchOLSConfig unexpected error: %v", err)
+	}
+	if !isPatched {
+		t.Errorf("expected the first PatchOLSConfig to modify the OLSConfig")
+	}
+
+	// Simulate the round trip through the API server, which decodes all whole numbers as int64
+	data, err := olsConfig.MarshalJSON()
+	if err != nil {
+		t.Fatalf("failed to encode OLSConfig: %v", err)
+	}
+	stored := &uns.Unstructured{}
+	if err := stored.UnmarshalJSON(data); err != nil {
+		t.Fatalf("failed to decode OLSConfig: %v", err)
+	}
+	original := stored.DeepCopy()
+
+	isPatched, err = PatchOLSConfig(helper, instance, stored)
+	if err != nil {
+		t.Fatalf("PatchOLSConfig unexpected error: %v", err)
+	}
+	if isPatched {
+		t.Errorf("expected the second PatchOLSConfig to leave the OLSConfig alone")
+	}
+
+	patchData, err := client.MergeFrom(original).Data(stored)
+	if err != nil {
+		t.Fatalf("failed to compute the patch: %v", err)
+	}
+	if string(patchData) != "{}" {
+		t.Errorf("expected no diff from the second PatchOLSConfig, got %s", patchData)
+	}
+}
+
+func TestOLSConfigAPIVersion(t *testing.T) {
+	t.Setenv("OLS_CONFIG_API_VERSION", "v1")
+
+	instance := newTestInstance()
+	instance.Status.Conditions = condition.Conditions{}
+	cl := newTestClient(t, instance)
+	helper := newTestHelper(t, cl, instance)
+	ctx := context.Background()
+
+	if err := CreateOrPatchOLSConfig(ctx, helper, instance); err != nil {
+		t.Fatalf("CreateOrPatchOLSConfig unexpected error: %v", err)
+	}
+	if err := CreateOrPatchOLSConfig(ctx, helper, instance); err != nil {
+		t.Fatalf("CreateOrPatchOLSConfig unexpected error on update: %v", err)
+	}
+	if err := OLSConfigPing(ctx, helper); err != nil {
+		t.Fatalf("OLSConfigPing unexpected error: %v", err)
+	}
+
+	olsConfig, err := GetOLSConfig(ctx, helper)
+	if err != nil {
+		t.Fatalf("GetOLSConfig unexpected error: %v", err)
+	}
+	if olsConfig.GetAPIVersion() != "ols.openshift.io/v1" {
+		t.Errorf("OLSConfig apiVersion = %s, want ols.openshift.io/v1", olsConfig.GetAPIVersion())
+	}
+
+	// Nothing must have been written through the default API version.
+	if _, err := getTestOLSConfig(t, cl); !k8s_errors.IsNotFound(err) {
+		t.Errorf("expected no %s OLSConfig, got %v", OLSConfigDefaultAPIVersion, err)
+	}
+
+	isRemoved, err := RemoveOLSConfig(ctx, helper, instance)
+	if err != nil || !isRemoved {
+		t.Errorf("RemoveOLSConfig = (%v, %v), want (true, nil)", isRemoved, err)
+	}
+}
+
+func TestPatchOLSConfigScheduling(t *testing.T) {
+	t.Run("node selector only", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.NodeSelector = map[string]string{"node-role.kubernetes.io/infra": ""}
+
+		olsConfig := patchTestOLSConfig(t, instance, nil)
+
+		nodeSelector, _, _ := uns.NestedStringMap(olsConfig.Object, "spec", "ols", "deployment", "api", "nodeSelector")
+		if !equality.Semantic.DeepEqual(nodeSelector, instance.Spec.NodeSelector) {
+			t.Errorf("nodeSelector = %v, want %v", nodeSelector, instance.Spec.NodeSelector)
+		}
+		if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "deployment", "api", "tolerations"); found {
+			t.Errorf("expected tolerations to be omitted when unset")
+		}
+	})
+
+	t.Run("tolerations set", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.Tolerations = []corev1.Toleration{{
+			Key:      "dedicated",
+			Operator: corev1.TolerationOpEqual,
+			Value:    "lightspeed",
+			Effect:   corev1.TaintEffectNoSchedule,
+		}}
+
+		olsConfig := patchTestOLSConfig(t, instance, nil)
+
+		expected := []interface{}{map[string]interface{}{
+			"key":      "dedicated",
+			"operator": "Equal",
+			"value":    "lightspeed",
+			"effect":   "NoSchedule",
+		}}
+		tolerations, _, _ := uns.NestedSlice(olsConfig.Object, "spec", "ols", "deployment", "api", "tolerations")
+		if !equality.Semantic.DeepEqual(tolerations, expected) {
+			t.Errorf("tolerations = %v, want %v", tolerations, expected)
+		}
+	})
+
+	t.Run("scheduling constraints unset", func(t *testing.T) {
+		instance := newTestInstance()
+
+		olsConfig := &uns.Unstructured{}
+		olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+		olsConfig.SetName(OLSConfigName)
+		_ = uns.SetNestedStringMap(olsConfig.Object, map[string]string{"stale": ""},
+			"spec", "ols", "deployment", "api", "nodeSelector")
+		_ = uns.SetNestedSlice(olsConfig.Object, []interface{}{map[string]interface{}{"key": "stale"}},
+			"spec", "ols", "deployment", "api", "tolerations")
+
+		olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+		for _, name := range []string{"nodeSelector", "tolerations"} {
+			if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "deployment", "api", name); found {
+				t.Errorf("expected %s to be omitted when unset", name)
+			}
+		}
+	})
+}
+
+func TestPatchOLSConfigReplicas(t *testing.T) {
+	t.Run("replicas set", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.Replicas = ptr.To[int32](3)
+
+		olsConfig := patchTestOLSConfig(t, instance, nil)
+
+		replicas, _, _ := uns.NestedInt64(olsConfig.Object, "spec", "ols", "deployment", "replicas")
+		if replicas != 3 {
+			t.Errorf("replicas = %d, want 3", replicas)
+		}
+	})
+
+	t.Run("replicas unset", func(t *testing.T) {
+		instance := newTestInstance()
+
+		olsConfig := &uns.Unstructured{}
+		olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+		olsConfig.SetName(OLSConfigName)
+		_ = uns.SetNestedField(olsConfig.Object, int64(3), "spec", "ols", "deployment", "replicas")
+
+		olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+		replicas, _, _ := uns.NestedInt64(olsConfig.Object, "spec", "ols", "deployment", "replicas")
+		if replicas != OLSDefaultReplicas {
+			t.Errorf("replicas = %d, want %d", replicas, OLSDefaultReplicas)
+		}
+	})
+}
+
+func TestPatchOLSConfigModelParameters(t *testing.T) {
+	instance := newTestInstance()
+	instance.Spec.ModelParameters = &apiv1beta1.ModelParameters{ContextWindowSize: ptr.To[int32](128000)}
+	instance.Spec.AdditionalModels = []apiv1beta1.ProviderModel{
+		{Name: "granite-code", Parameters: &apiv1beta1.ModelParameters{ContextWindowSize: ptr.To[int32](8192)}},
+		{Name: "llama"},
+	}
+
+	olsConfig := patchTestOLSConfig(t, instance, nil)
+
+	providers, _, _ := uns.NestedSlice(olsConfig.Object, "spec", "llm", "providers")
+	models := providers[0].(map[string]interface{})["models"].([]interface{})
+	expectedParameters := map[string]interface{}{
+		"maxTokensForResponse": float64(instance.Spec.MaxTokensForResponse),
+	}
+	expectedContextWindowSizes := map[string]int64{
+		instance.Spec.ModelName: 128000,
+		"granite-code":          8192,
+		"llama":                 0,
+	}
+	if len(models) != len(expectedContextWindowSizes) {
+		t.Fatalf("expected %d models, got %v", len(expectedContextWindowSizes), models)
+	}
+	for _, m := range models {
+		model := m.(map[string]interface{})
+		parameters, _, _ := uns.NestedMap(model, "parameters")
+		if !equality.Semantic.DeepEqual(parameters, expectedParameters) {
+			t.Errorf("model %v: parameters = %v, want %v", model["name"], parameters, expectedParameters)
+		}
+
+		expectedSize := expectedContextWindowSizes[model["name"].(string)]
+		size, found, _ := uns.NestedInt64(model, "contextWindowSize")
+		if expectedSize == 0 && found {
+			t.Errorf("model %v: expected no context window size, got %d", model["name"], size)
+		} else if size != expectedSize {
+			t.Errorf("model %v: contextWindowSize = %d, want %d", model["name"], size, expectedSize)
+		}
+	}
+}
+
+func TestCreateOrPatchOLSConfigLabelDrift(t *testing.T) {
+	instance := newTestInstance()
+	instance.Status.Conditions = condition.Conditions{}
+
+	var patches []map[string]interface{}
+	cl := newTestClientWithInterceptor(t, interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			data, err := patch.Data(obj)
+			if err != nil {
+				return err
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(data, &body); err != nil {
+				return err
+			}
+			patches = append(patches, body)
+
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	}, instance)
+	helper := newTestHelper(t, cl, instance)
+
+	if err := CreateOrPatchOLSConfig(context.Background(), helper, instance); err != nil {
+		t.Fatalf("CreateOrPatchOLSConfig unexpected error: %v", err)
+	}
+
+	// The OLS operator reports the OLSConfig status, then the user drops the owner label.
+	olsConfig, err := getTestOLSConfig(t, cl)
+	if err != nil {
+		t.Fatalf("failed to get OLSConfig: %v", err)
+	}
+	_ = uns.SetNestedSlice(olsConfig.Object, []interface{}{
+		map[string]interface{}{
+			"type":               "ApiReady",
+			"status":             "True",
+			"lastTransitionTime": "2025-01-01T00:00:00Z",
+		},
+	}, "status", "conditions")
+	labels := olsConfig.GetLabels()
+	delete(labels, OpenStackLightspeedOwnerIDLabel)
+	olsConfig.SetLabels(labels)
+	if err := cl.Update(context.Background(), olsConfig); err != nil {
+		t.Fatalf("failed to update OLSConfig: %v", err)
+	}
+
+	drifted, _ := getTestOLSConfig(t, cl)
+	patches = nil
+
+	if err := CreateOrPatchOLSConfig(context.Background(), helper, instance); err != nil {
+		t.Fatalf("CreateOrPatchOLSConfig unexpected error: %v", err)
+	}
+
+	restored, _ := getTestOLSConfig(t, cl)
+	if owner := restored.GetLabels()[OpenStackLightspeedOwnerIDLabel]; owner != string(instance.GetUID()) {
+		t.Errorf("owner label = %q, want %q", owner, instance.GetUID())
+	}
+
+	if len(patches) != 1 {
+		t.Fatalf("expected a single patch, got %v", patches)
+	}
+	for key := range patches[0] {
+		if key != "metadata" {
+			t.Errorf("expected a metadata only patch, got %v", patches[0])
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(restored.Object["spec"], drifted.Object["spec"]) {
+		t.Errorf("expected the spec to be left untouched, got %v", restored.Object["spec"])
+	}
+	if !equality.Semantic.DeepEqual(restored.Object["status"], drifted.Object["status"]) {
+		t.Errorf("expected the status to be left untouched, got %v", restored.Object["status"])
+	}
+
+	// Once the label is restored there is nothing left to patch.
+	patches = nil
+	if err := CreateOrPatchOLSConfig(context.Background(), helper, instance); err != nil {
+		t.Fatalf("CreateOrPatchOLSConfig unexpected error: %v", err)
+	}
+	if len(patches) != 0 {
+		t.Errorf("expected no patch for an up to date OLSConfig, got %v", patches)
+	}
+}
+
+func TestPatchOLSConfigAdditionalModels(t *testing.T) {
+	instance := newTestInstance()
+	instance.Spec.AdditionalModels = []apiv1beta1.ProviderModel{
+		{Name: "granite-code", URL: "https://gateway.example.com/granite-code/v1"},
+		{Name: "llama"},
+	}
+
+	olsConfig := patchTestOLSConfig(t, instance, nil)
+
+	providers, _, _ := uns.NestedSlice(olsConfig.Object, "spec", "llm", "providers")
+	provider := providers[0].(map[string]interface{})
+	if provider["url"] != instance.Spec.LLMEndpoint {
+		t.Errorf("provider url = %v, want %s", provider["url"], instance.Spec.LLMEndpoint)
+	}
+
+	models := provider["models"].([]interface{})
+	expectedURLs := map[string]string{
+		instance.Spec.ModelName: "",
+		"granite-code":          "https://gateway.example.com/granite-code/v1",
+		"llama":                 "",
+	}
+	if len(models) != len(expectedURLs) {
+		t.Fatalf("expected %d models, got %v", len(expectedURLs), models)
+	}
+	for _, m := range models {
+		model := m.(map[string]interface{})
+		expectedURL, ok := expectedURLs[model["name"].(string)]
+		if !ok {
+			t.Errorf("unexpected model %v", model)
+			continue
+		}
+
+		url, found, _ := uns.NestedString(model, "url")
+		if expectedURL == "" && found {
+			t.Errorf("model %v: expected no url so that the provider url is used, got %s", model["name"], url)
+		} else if url != expectedURL {
+			t.Errorf("model %v: url = %s, want %s", model["name"], url, expectedURL)
+		}
+	}
+}
+
+func TestPatchOLSConfigProviders(t *testing.T) {
+	primary := apiv1beta1.ProviderSpec{
+		Name:              "primary",
+		Type:              "openai",
+		URL:               "https://primary.example.com/v1",
+		CredentialsSecret: "primary-credentials",
+		Models:            []apiv1beta1.ProviderModel{{Name: "granite"}},
+	}
+	fallback := apiv1beta1.ProviderSpec{
+		Name:              "fallback",
+		Type:              "azure_openai",
+		URL:               "https://fallback.example.com/v1",
+		CredentialsSecret: "fallback-credentials",
+		Models:            []apiv1beta1.ProviderModel{{Name: "gpt-4o"}, {Name: "gpt-4o-mini"}},
+		DeploymentName:    "lightspeed",
+		APIVersion:        "2024-02-15-preview",
+	}
+
+	tests := []struct {
+		name                    string
+		shorthand               bool
+		defaultProvider         string
+		expectedProviders       []string
+		expectedDefaultProvider string
+		expectedDefaultModel    string
+	}{
+		{
+			name:                    "Two providers",
+			expectedProviders:       []string{"primary", "fallback"},
+			expectedDefaultProvider: "primary",
+			expectedDefaultModel:    "granite",
+		},
+		{
+			name:                    "Two providers with default provider override",
+			defaultProvider:         "fallback",
+			expectedProviders:       []string{"primary", "fallback"},
+			expectedDefaultProvider: "fallback",
+			expectedDefaultModel:    "gpt-4o",
+		},
+		{
+			name:                    "Two providers next to the shorthand provider",
+			shorthand:               true,
+			expectedProviders:       []string{OpenStackLightspeedDefaultProvider, "primary", "fallback"},
+			expectedDefaultProvider: OpenStackLightspeedDefaultProvider,
+			expectedDefaultModel:    newTestInstance().Spec.ModelName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			if !tt.shorthand {
+				instance.Spec.ModelName = ""
+				instance.Spec.LLMEndpoint = ""
+			}
+			instance.Spec.Providers = []apiv1beta1.ProviderSpec{primary, fallback}
+			instance.Spec.DefaultProvider = tt.defaultProvider
+
+			olsConfig := patchTestOLSConfig(t, instance, nil)
+
+			providers, _, _ := uns.NestedSlice(olsConfig.Object, "spec", "llm", "providers")
+			names := []string{}
+			for _, p := range providers {
+				names = append(names, p.(map[string]interface{})["name"].(string))
+			}
+			if !equality.Semantic.DeepEqual(names, tt.expectedProviders) {
+				t.Fatalf("providers = %v, want %v", names, tt.expectedProviders)
+			}
+
+			expectedFallback := map[string]interface{}{
+				"credentialsSecretRef": map[string]interface{}{"name": "fallback-credentials"},
+				"models": []interface{}{
+					map[string]interface{}{
+						"name":       "gpt-4o",
+						"parameters": map[string]interface{}{"maxTokensForResponse": float64(instance.Spec.MaxTokensForResponse)},
+					},
+					map[string]interface{}{
+						"name":       "gpt-4o-mini",
+						"parameters": map[string]interface{}{"maxTokensForResponse": float64(instance.Spec.MaxTokensForResponse)},
+					},
+				},
+				"name":           "fallback",
+				"type":           "azure_openai",
+				"url":            "https://fallback.example.com/v1",
+				"deploymentName": "lightspeed",
+				"apiVersion":     "2024-02-15-preview",
+			}
+			if provider := providers[len(providers)-1]; !equality.Semantic.DeepEqual(provider, expectedFallback) {
+				t.Errorf("fallback provider = %v, want %v", provider, expectedFallback)
+			}
+
+			defaultProvider, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultProvider")
+			if defaultProvider != tt.expectedDefaultProvider {
+				t.Errorf("defaultProvider = %s, want %s", defaultProvider, tt.expectedDefaultProvider)
+			}
+
+			defaultModel, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultModel")
+			if defaultModel != tt.expectedDefaultModel {
+				t.Errorf("defaultModel = %s, want %s", defaultModel, tt.expectedDefaultModel)
+			}
+		})
+	}
+}
+
+func TestPatchOLSConfigWithoutRAGSources(t *testing.T) {
+	t.Run("accidentally without RAG sources", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.RAGImage = ""
+
+		olsConfig := &uns.Unstructured{}
+		olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+		olsConfig.SetName(OLSConfigName)
+
+		helper := newTestHelper(t, newTestClient(t), instance)
+		_, err := PatchOLSConfig(helper, instance, olsConfig)
+		if !errors.Is(err, ErrOLSConfigNoRAGSources) {
+			t.Fatalf("expected ErrOLSConfigNoRAGSources, got %v", err)
+		}
+		if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "rag"); found {
+			t.Errorf("expected no RAG section to be written")
+		}
+	})
+
+	t.Run("RAG disabled", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.RAGImage = ""
+		instance.Spec.DisableRAG = true
+
+		olsConfig := &uns.Unstructured{}
+		olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+		olsConfig.SetName(OLSConfigName)
+		_ = uns.SetNestedSlice(olsConfig.Object, []interface{}{
+			map[string]interface{}{"image": testRAGImage, "indexPath": OpenStackLightspeedVectorDBPath},
+		}, "spec", "ols", "rag")
+
+		olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+		if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "rag"); found {
+			t.Errorf("expected the RAG section to be removed when RAG is disabled")
+		}
+		if byokRAGOnly, _, _ := uns.NestedBool(olsConfig.Object, "spec", "ols", "byokRAGOnly"); byokRAGOnly {
+			t.Errorf("expected byokRAGOnly to be false when RAG is disabled")
+		}
+	})
+}
+
+func TestPatchOLSConfigConversationCache(t *testing.T) {
+	t.Run("postgres backend", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.ConversationCache = &apiv1beta1.ConversationCache{
+			Type:                 apiv1beta1.ConversationCacheTypePostgres,
+			CredentialsSecretRef: "ols-postgres-credentials",
+		}
+
+		olsConfig := &uns.Unstructured{}
+		olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+		olsConfig.SetName(OLSConfigName)
+		_ = uns.SetNestedField(olsConfig.Object, OLSConfigInMemoryCacheType, "spec", "ols", "conversationCache", "type")
+
+		olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+		conversationCache, _, _ := uns.NestedMap(olsConfig.Object, "spec", "ols", "conversationCache")
+		expectedConversationCache := map[string]interface{}{
+			"type": "postgres",
+			"postgres": map[string]interface{}{
+				"credentialsSecret": "ols-postgres-credentials",
+			},
+		}
+		if !equality.Semantic.DeepEqual(conversationCache, expectedConversationCache) {
+			t.Errorf("conversation cache = %v, want %v", conversationCache, expectedConversationCache)
+		}
+	})
+
+	t.Run("memory backend", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.ConversationCache = &apiv1beta1.ConversationCache{Type: apiv1beta1.ConversationCacheTypeMemory}
+
+		olsConfig := &uns.Unstructured{}
+		olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+		olsConfig.SetName(OLSConfigName)
+		_ = uns.SetNestedMap(olsConfig.Object, map[string]interface{}{
+			"type":     "postgres",
+			"postgres": map[string]interface{}{"credentialsSecret": "ols-postgres-credentials"},
+		}, "spec", "ols", "conversationCache")
+
+		olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+		// The PostgreSQL settings of the previous backend are dropped
+		conversationCache, _, _ := uns.NestedMap(olsConfig.Object, "spec", "ols", "conversationCache")
+		expectedConversationCache := map[string]interface{}{"type": OLSConfigInMemoryCacheType}
+		if !equality.Semantic.DeepEqual(conversationCache, expectedConversationCache) {
+			t.Errorf("conversation cache = %v, want %v", conversationCache, expectedConversationCache)
+		}
+	})
+
+	t.Run("backend unset", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.ConversationCache = &apiv1beta1.ConversationCache{}
+
+		olsConfig := &uns.Unstructured{}
+		olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+		olsConfig.SetName(OLSConfigName)
+		_ = uns.SetNestedMap(olsConfig.Object, map[string]interface{}{
+			"type":     "postgres",
+			"postgres": map[string]interface{}{"credentialsSecret": "ols-postgres-credentials"},
+		}, "spec", "ols", "conversationCache")
+
+		olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+		// The backend picked by OLS is kept
+		conversationCache, _, _ := uns.NestedMap(olsConfig.Object, "spec", "ols", "conversationCache")
+		expectedConversationCache := map[string]interface{}{
+			"type":     "postgres",
+			"postgres": map[string]interface{}{"credentialsSecret": "ols-postgres-credentials"},
+		}
+		if !equality.Semantic.DeepEqual(conversationCache, expectedConversationCache) {
+			t.Errorf("conversation cache = %v, want %v", conversationCache, expectedConversationCache)
+		}
+	})
+
+	t.Run("no conversation cache section", func(t *testing.T) {
+		olsConfig := patchTestOLSConfig(t, newTestInstance(), nil)
+
+		if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "conversationCache"); found {
+			t.Errorf("expected no conversation cache section when the conversation cache is unset")
+		}
+	})
+}
+
+func TestPatchOLSConfigAPITLS(t *testing.T) {
+	tests := []struct {
+		name         string
+		apiTLS       *apiv1beta1.APITLS
+		expectedCert string
+	}{
+		{
+			name:         "Certificate secret",
+			apiTLS:       &apiv1beta1.APITLS{SecretName: "ols-api-cert"},
+			expectedCert: "ols-api-cert",
+		},
+		{
+			name: "Unset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			instance.Spec.APITLS = tt.apiTLS
+			instance.Spec.TLSCACertBundle = "llm-ca-bundle"
+
+			// The TLS configuration of a previous spec is dropped when unset
+			olsConfig := &uns.Unstructured{}
+			olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+			olsConfig.SetName(OLSConfigName)
+			_ = uns.SetNestedField(olsConfig.Object, "previous-cert",
+				"spec", "ols", "tlsConfig", "keyCertSecretRef", "name")
+
+			olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+			cert, found, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "tlsConfig", "keyCertSecretRef", "name")
+			if tt.expectedCert == "" && found {
+				t.Errorf("expected the API TLS to be omitted when unset, got %q", cert)
+			} else if cert != tt.expectedCert {
+				t.Errorf("spec.ols.tlsConfig.keyCertSecretRef.name = %q, want %q", cert, tt.expectedCert)
+			}
+			if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "deployment", "api", "tls"); found {
+				t.Errorf("expected no spec.ols.deployment.api.tls, which OLSConfig does not define")
+			}
+
+			// The CA bundle of the LLM endpoint is configured independently
+			caBundle, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "additionalCAConfigMapRef", "name")
+			if caBundle != "llm-ca-bundle" {
+				t.Errorf("additionalCAConfigMapRef = %q, want %q", caBundle, "llm-ca-bundle")
+			}
+		})
+	}
+}
+
+func TestGetAPITLSSecretMissingKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string][]byte
+		expected []string
+	}{
+		{
+			name: "Certificate and key set",
+			data: map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")},
+		},
+		{
+			name:     "Key missing",
+			data:     map[string][]byte{"tls.crt": []byte("cert")},
+			expected: []string{"tls.key"},
+		},
+		{
+			name:     "Certificate empty",
+			data:     map[string][]byte{"tls.crt": {}, "tls.key": []byte("key")},
+			expected: []string{"tls.crt"},
+		},
+		{
+			name:     "Empty secret",
+			expected: []string{"tls.crt", "tls.key"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{Data: tt.data}
+			if missing := GetAPITLSSecretMissingKeys(secret); !slices.Equal(missing, tt.expected) {
+				t.Errorf("GetAPITLSSecretMissingKeys() = %v, want %v", missing, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPatchOLSConfigRAGImagePullSecret(t *testing.T) {
+	tests := []struct {
+		name                string
+		pullSecret          string
+		disableRAG          bool
+		expectedPullSecrets []interface{}
+	}{
+		{
+			name:                "Pull secret set",
+			pullSecret:          "internal-registry",
+			expectedPullSecrets: []interface{}{map[string]interface{}{"name": "internal-registry"}},
+		},
+		{
+			name:       "RAG disabled",
+			pullSecret: "internal-registry",
+			disableRAG: true,
+		},
+		{
+			name: "Unset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			instance.Spec.RAGImagePullSecret = tt.pullSecret
+			if tt.disableRAG {
+				instance.Spec.DisableRAG = true
+				instance.Spec.RAGImage = ""
+			}
+
+			olsConfig := &uns.Unstructured{}
+			olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+			olsConfig.SetName(OLSConfigName)
+			_ = uns.SetNestedSlice(olsConfig.Object, []interface{}{map[string]interface{}{"name": "stale"}},
+				"spec", "ols", "imagePullSecrets")
+
+			olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+			pullSecrets, found, _ := uns.NestedSlice(olsConfig.Object, "spec", "ols", "imagePullSecrets")
+			if tt.expectedPullSecrets == nil {
+				if found {
+					t.Errorf("expected the image pull secrets to be omitted, got %v", pullSecrets)
+				}
+				return
+			}
+			if !equality.Semantic.DeepEqual(pullSecrets, tt.expectedPullSecrets) {
+				t.Errorf("imagePullSecrets = %v, want %v", pullSecrets, tt.expectedPullSecrets)
+			}
+		})
+	}
+}
+
+func TestPatchOLSConfigLogLevel(t *testing.T) {
+	tests := []struct {
+		logLevel string
+		expected string
+	}{
+		{logLevel: apiv1beta1.LogLevelDebug, expected: "DEBUG"},
+		{logLevel: apiv1beta1.LogLevelInfo, expected: "INFO"},
+		{logLevel: apiv1beta1.LogLevelWarning, expected: "WARNING"},
+		{logLevel: apiv1beta1.LogLevelError, expected: "ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.logLevel, func(t *testing.T) {
+			instance := newTestInstance()
+			instance.Spec.LogLevel = tt.logLevel
+			olsConfig := patchTestOLSConfig(t, instance, nil)
+
+			if logLevel, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "logLevel"); logLevel != tt.expected {
+				t.Errorf("logLevel = %q, want %q", logLevel, tt.expected)
+			}
+		})
+	}
+
+	t.Run("level changed", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.LogLevel = apiv1beta1.LogLevelInfo
+		olsConfig := patchTestOLSConfig(t, instance, nil)
+
+		instance.Spec.LogLevel = apiv1beta1.LogLevelDebug
+		instance.Status.Conditions = condition.Conditions{}
+		olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+		if logLevel, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "logLevel"); logLevel != "DEBUG" {
+			t.Errorf("logLevel = %q, want DEBUG after the change", logLevel)
+		}
+	})
+}
+
+func TestPatchOLSConfigDataCollection(t *testing.T) {
+	tests := []struct {
+		name                        string
+		feedbackDisabled            bool
+		transcriptsDisabled         bool
+		disableDataCollection       bool
+		expectedFeedbackDisabled    bool
+		expectedTranscriptsDisabled bool
+	}{
+		{
+			name: "Collection enabled",
+		},
+		{
+			name:                     "Feedback disabled",
+			feedbackDisabled:         true,
+			expectedFeedbackDisabled: true,
+		},
+		{
+			name:                        "Data collection disabled",
+			disableDataCollection:       true,
+			expectedFeedbackDisabled:    true,
+			expectedTranscriptsDisabled: true,
+		},
+		{
+			name:                        "Data collection disabled overrides the individual fields",
+			feedbackDisabled:            true,
+			disableDataCollection:       true,
+			expectedFeedbackDisabled:    true,
+			expectedTranscriptsDisabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			instance.Spec.FeedbackDisabled = tt.feedbackDisabled
+			instance.Spec.TranscriptsDisabled = tt.transcriptsDisabled
+			instance.Spec.DisableDataCollection = tt.disableDataCollection
+			olsConfig := patchTestOLSConfig(t, instance, nil)
+
+			feedbackDisabled, _, _ := uns.NestedBool(olsConfig.Object, "spec", "ols", "userDataCollection", "feedbackDisabled")
+			if feedbackDisabled != tt.expectedFeedbackDisabled {
+				t.Errorf("feedbackDisabled = %v, want %v", feedbackDisabled, tt.expectedFeedbackDisabled)
+			}
+			transcriptsDisabled, _, _ := uns.NestedBool(olsConfig.Object, "spec", "ols", "userDataCollection", "transcriptsDisabled")
+			if transcriptsDisabled != tt.expectedTranscriptsDisabled {
+				t.Errorf("transcriptsDisabled = %v, want %v", transcriptsDisabled, tt.expectedTranscriptsDisabled)
+			}
+		})
+	}
+}
+
+func TestPatchOLSConfigOverlay(t *testing.T) {
+	instance := newTestInstance()
+	instance.Status.Conditions = condition.Conditions{}
+	instance.Spec.OLSConfigOverlay = &runtime.RawExtension{Raw: []byte(`{
+		"spec": {
+			"ols": {
+				"defaultModel": "overlay-model",
+				"logLevel": "DEBUG",
+				"deployment": {"api": {"replicas": 3, "nodeSelector": {"node-role": "ols"}}}
+			}
+		}
+	}`)}
+
+	olsConfig := &uns.Unstructured{}
+	olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+	olsConfig.SetName(OLSConfigName)
+	_ = uns.SetNestedField(olsConfig.Object, "postgres", "spec", "ols", "conversationCache", "type")
+
+	olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+	if model, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultModel"); model != instance.Spec.ModelName {
+		t.Errorf("defaultModel = %q, want the managed %q", model, instance.Spec.ModelName)
+	}
+	if logLevel, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "logLevel"); logLevel != "DEBUG" {
+		t.Errorf("logLevel = %q, want the overlaid DEBUG", logLevel)
+	}
+	if replicas, _, _ := uns.NestedFloat64(olsConfig.Object, "spec", "ols", "deployment", "api", "replicas"); replicas != 3 {
+		t.Errorf("replicas = %v, want the overlaid 3", replicas)
+	}
+	if cacheType, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "conversationCache", "type"); cacheType != "postgres" {
+		t.Errorf("conversationCache type = %q, want the existing postgres", cacheType)
+	}
+	nodeSelector, _, _ := uns.NestedStringMap(olsConfig.Object, "spec", "ols", "deployment", "api", "nodeSelector")
+	if nodeSelector["node-role"] != "ols" {
+		t.Errorf("nodeSelector = %v, want the overlaid one while the instance sets none", nodeSelector)
+	}
+
+	// Dropping fields from the overlay drops them from the OLSConfig
+	instance.Spec.OLSConfigOverlay = &runtime.RawExtension{Raw: []byte(`{"spec": {"ols": {"logLevel": "DEBUG"}}}`)}
+	olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+	if logLevel, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "logLevel"); logLevel != "DEBUG" {
+		t.Errorf("logLevel = %q, want the overlaid DEBUG", logLevel)
+	}
+	if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "deployment", "api", "replicas"); found {
+		t.Errorf("expected the replicas dropped from the overlay to be removed")
+	}
+	if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "deployment", "api", "nodeSelector"); found {
+		t.Errorf("expected the nodeSelector dropped from the overlay to be removed")
+	}
+	if cacheType, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "conversationCache", "type"); cacheType != "postgres" {
+		t.Errorf("conversationCache type = %q, want the existing postgres", cacheType)
+	}
+
+	instance.Spec.OLSConfigOverlay = nil
+	olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+	if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "logLevel"); found {
+		t.Errorf("expected the logLevel dropped from the overlay to be removed")
+	}
+	if _, found := olsConfig.GetAnnotations()[OLSConfigOverlayPathsAnnotation]; found {
+		t.Errorf("expected the %s annotation to be removed", OLSConfigOverlayPathsAnnotation)
+	}
+}
+
+func TestPatchOLSConfigRejectsEmptyModelName(t *testing.T) {
+	instance := newTestInstance()
+	instance.Spec.ModelName = ""
+
+	olsConfig := &uns.Unstructured{}
+	olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+	olsConfig.SetName(OLSConfigName)
+
+	cl := newTestClient(t)
+	helper := newTestHelper(t, cl, instance)
+	if _, err := PatchOLSConfig(helper, instance, olsConfig); !errors.Is(err, ErrOLSConfigInvalidDefaults) {
+		t.Errorf("expected ErrOLSConfigInvalidDefaults, got %v", err)
+	}
+	if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec"); found {
+		t.Errorf("expected the OLSConfig to be left untouched, got %v", olsConfig.Object["spec"])
+	}
+}
+
+func TestIsOLSConfigReadyRequiredConditions(t *testing.T) {
+	tests := []struct {
+		name               string
+		requiredConditions []string
+		expected           bool
+	}{
+		{
+			name:     "Overall status",
+			expected: false,
+		},
+		{
+			name:               "Required conditions without ConsolePluginReady",
+			requiredConditions: []string{"ApiReady", "CacheReady", "Reconciled"},
+			expected:           true,
+		},
+		{
+			name:               "Required conditions with ConsolePluginReady",
+			requiredConditions: []string{"ApiReady", "ConsolePluginReady"},
+			expected:           false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+
+			// Headless installation, the console plugin never becomes ready
+			olsConfig := newTestOLSConfig(instance, false)
+			_ = uns.SetNestedField(olsConfig.Object, "NotReady", "status", "overallStatus")
+			_ = uns.SetNestedSlice(olsConfig.Object, []interface{}{
+				map[string]interface{}{"type": "ApiReady", "status": "True"},
+				map[string]interface{}{"type": "CacheReady", "status": "True"},
+				map[string]interface{}{"type": "ConsolePluginReady", "status": "False"},
+				map[string]interface{}{"type": "Reconciled", "status": "True"},
+			}, "status", "conditions")
+
+			cl := newTestClient(t, olsConfig)
+			helper := newTestHelper(t, cl, instance)
+
+			ready, err := IsOLSConfigReady(context.Background(), helper, tt.requiredConditions)
+			if err != nil {
+				t.Fatalf("IsOLSConfigReady unexpected error: %v", err)
+			}
+			if ready != tt.expected {
+				t.Errorf("IsOLSConfigReady() = %v, want %v", ready, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetOLSConfigValidationFailures(t *testing.T) {
+	olsConfigGK := schema.GroupKind{Group: OLSConfigGroup, Kind: "OLSConfig"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name: "Field level failures",
+			err: fmt.Errorf("failed to patch OLSConfig: %w", k8s_errors.NewInvalid(olsConfigGK, OLSConfigName, field.ErrorList{
+				field.Invalid(field.NewPath("spec", "ols", "deployment", "replicas"), int64(-1),
+					"should be greater than or equal to 0"),
+				field.NotSupported(field.NewPath("spec", "ols", "conversationCache", "type"), "redis",
+					[]string{"postgres"}),
+			})),
+			expected: `spec.ols.deployment.replicas: Invalid value: -1: should be greater than or equal to 0; ` +
+				`spec.ols.conversationCache.type: Unsupported value: "redis": supported values: "postgres"`,
+		},
+		{
+			name:     "No field details",
+			err:      errors.New("admission webhook denied the request"),
+			expected: "admission webhook denied the request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if failures := GetOLSConfigValidationFailures(tt.err); failures != tt.expected {
+				t.Errorf("GetOLSConfigValidationFailures() = %q, want %q", failures, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRepairOLSConfigDefaults(t *testing.T) {
+	providers := []interface{}{
+		map[string]interface{}{
+			"name":   "openai",
+			"models": []interface{}{map[string]interface{}{"name": "gpt-4o"}, map[string]interface{}{"name": "gpt-4o-mini"}},
+		},
+		map[string]interface{}{
+			"name":   "vllm",
+			"models": []interface{}{map[string]interface{}{"name": "granite"}},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		providers        []interface{}
+		defaultProvider  string
+		defaultModel     string
+		expectedProvider string
+		expectedModel    string
+		expectRepair     bool
+		expectError      bool
+	}{
+		{
+			name:             "Consistent defaults",
+			providers:        providers,
+			defaultProvider:  "vllm",
+			defaultModel:     "granite",
+			expectedProvider: "vllm",
+			expectedModel:    "granite",
+		},
+		{
+			name:             "Unknown default provider",
+			providers:        providers,
+			defaultProvider:  "watsonx",
+			defaultModel:     "gpt-4o-mini",
+			expectedProvider: "openai",
+			expectedModel:    "gpt-4o-mini",
+			expectRepair:     true,
+		},
+		{
+			name:             "Default model of another provider",
+			providers:        providers,
+			defaultProvider:  "vllm",
+			defaultModel:     "gpt-4o",
+			expectedProvider: "vllm",
+			expectedModel:    "granite",
+			expectRepair:     true,
+		},
+		{
+			name:        "No provider",
+			expectError: true,
+		},
+		{
+			name:            "Provider without models",
+			providers:       []interface{}{map[string]interface{}{"name": "openai"}},
+			defaultProvider: "openai",
+			defaultModel:    "gpt-4o",
+			expectError:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			olsConfig := &uns.Unstructured{Object: map[string]interface{}{}}
+			if tt.providers != nil {
+				_ = uns.SetNestedSlice(olsConfig.Object, runtime.DeepCopyJSONValue(tt.providers).([]interface{}),
+					"spec", "llm", "providers")
+			}
+			_ = uns.SetNestedField(olsConfig.Object, tt.defaultProvider, "spec", "ols", "defaultProvider")
+			_ = uns.SetNestedField(olsConfig.Object, tt.defaultModel, "spec", "ols", "defaultModel")
+
+			repaired, err := RepairOLSConfigDefaults(olsConfig)
+			if tt.expectError {
+				if !errors.Is(err, ErrOLSConfigInvalidDefaults) {
+					t.Errorf("expected ErrOLSConfigInvalidDefaults, got %v", err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if repaired != tt.expectRepair {
+				t.Errorf("repaired = %v, want %v", repaired, tt.expectRepair)
+			}
+			defaultProvider, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultProvider")
+			defaultModel, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultModel")
+			if defaultProvider != tt.expectedProvider || defaultModel != tt.expectedModel {
+				t.Errorf("defaults = %s/%s, want %s/%s", defaultProvider, defaultModel, tt.expectedProvider, tt.expectedModel)
+			}
+		})
+	}
+}
+
+func TestPatchOLSConfigQueryFilters(t *testing.T) {
+	t.Run("query filters set", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.QueryFilters = []apiv1beta1.QueryFilter{
+			{Name: "email", Pattern: `[\w.+-]+@[\w-]+\.[\w.]+`, ReplaceWith: "[EMAIL]"},
+			{Name: "ssn", Pattern: `\b\d{3}-\d{2}-\d{4}\b`},
+		}
+
+		olsConfig := patchTestOLSConfig(t, instance, nil)
+
+		filters, _, _ := uns.NestedSlice(olsConfig.Object, "spec", "ols", "queryFilters")
+		expectedFilters := []interface{}{
+			map[string]interface{}{
+				"name":        "email",
+				"pattern":     `[\w.+-]+@[\w-]+\.[\w.]+`,
+				"replaceWith": "[EMAIL]",
+			},
+			map[string]interface{}{
+				"name":        "ssn",
+				"pattern":     `\b\d{3}-\d{2}-\d{4}\b`,
+				"replaceWith": "",
+			},
+		}
+		if !equality.Semantic.DeepEqual(filters, expectedFilters) {
+			t.Errorf("queryFilters = %v, want %v", filters, expectedFilters)
+		}
+	})
+
+	t.Run("query filters unset", func(t *testing.T) {
+		instance := newTestInstance()
+
+		olsConfig := &uns.Unstructured{}
+		olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+		olsConfig.SetName(OLSConfigName)
+		_ = uns.SetNestedSlice(olsConfig.Object, []interface{}{map[string]interface{}{"name": "stale"}},
+			"spec", "ols", "queryFilters")
+
+		olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+		if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "queryFilters"); found {
+			t.Errorf("expected the query filters to be omitted when unset")
+		}
+	})
+}
+
+func TestPatchOLSConfigComponentResources(t *testing.T) {
+	apiResources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+	}
+	consoleResources := &corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("100Mi")},
+	}
+
+	tests := []struct {
+		name      string
+		component string
+		setup     func(instance *apiv1beta1.OpenStackLightspeed)
+		expected  map[string]interface{}
+	}{
+		{
+			name:      "API resources",
+			component: "api",
+			setup:     func(instance *apiv1beta1.OpenStackLightspeed) { instance.Spec.APIResources = apiResources },
+			expected: map[string]interface{}{
+				"requests": map[string]interface{}{"cpu": "500m", "memory": "2Gi"},
+				"limits":   map[string]interface{}{"memory": "2Gi"},
+			},
+		},
+		{
+			name:      "API CPU and memory",
+			component: "api",
+			setup: func(instance *apiv1beta1.OpenStackLightspeed) {
+				instance.Spec.APIResources = &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+				}
+			},
+			expected: map[string]interface{}{
+				"requests": map[string]interface{}{"cpu": "1", "memory": "4Gi"},
+				"limits":   map[string]interface{}{"cpu": "2", "memory": "8Gi"},
+			},
+		},
+		{
+			name:      "API limits only",
+			component: "api",
+			setup: func(instance *apiv1beta1.OpenStackLightspeed) {
+				instance.Spec.APIResources = &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+				}
+			},
+			expected: map[string]interface{}{
+				"requests": map[string]interface{}{"cpu": "2", "memory": "8Gi"},
+				"limits":   map[string]interface{}{"cpu": "2", "memory": "8Gi"},
+			},
+		},
+		{
+			name:      "Console resources",
+			component: "console",
+			setup:     func(instance *apiv1beta1.OpenStackLightspeed) { instance.Spec.ConsoleResources = consoleResources },
+			expected: map[string]interface{}{
+				"requests": map[string]interface{}{"memory": "100Mi"},
+				"limits":   map[string]interface{}{"memory": "100Mi"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			tt.setup(instance)
+
+			olsConfig := patchTestOLSConfig(t, instance, nil)
+
+			for _, component := range []string{"api", "console"} {
+				resources, found, _ := uns.NestedMap(olsConfig.Object, "spec", "ols", "deployment", component, "resources")
+				if component != tt.component {
+					if found {
+						t.Errorf("expected the %s resources to be omitted when unset, got %v", component, resources)
+					}
+					continue
+				}
+
+				if !equality.Semantic.DeepEqual(resources, tt.expected) {
+					t.Errorf("%s resources = %v, want %v", component, resources, tt.expected)
+				}
+			}
+		})
+	}
+
+	t.Run("resources unset", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.ConsoleResources = consoleResources
+		olsConfig := patchTestOLSConfig(t, instance, nil)
+
+		instance.Spec.ConsoleResources = nil
+		instance.Status.Conditions = condition.Conditions{}
+		olsConfig = patchTestOLSConfig(t, instance, olsConfig)
+
+		if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "deployment", "console", "resources"); found {
+			t.Errorf("expected the console resources to be omitted when unset")
+		}
+	})
+}
+
+func TestInstallOLSOperatorDefersApprovalUntilInstallPlanRefIsStable(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Spec.InstallPlanApprovalStrategy = apiv1beta1.InstallPlanApprovalWaitForStableRef
+
+	objs := []client.Object{instance}
+	for _, obj := range newTestOLSOperatorObjects(instance) {
+		if installPlan, ok := obj.(*operatorsv1alpha1.InstallPlan); ok {
+			installPlan.Spec.Approved = false
+		}
+		objs = append(objs, obj)
+	}
+	cl := newTestClient(t, objs...)
+	helper := newTestHelper(t, cl, instance)
+
+	installPlan := &operatorsv1alpha1.InstallPlan{}
+	installPlanKey := client.ObjectKey{Name: "install-ols", Namespace: instance.Namespace}
+
+	// The first attempt aligns the Subscription with the instance and waits for OLM to process it
+	if _, err := InstallInstanceOwnedOLSOperator(context.Background(), helper, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// OLM regenerated the InstallPlan since the previous reconcile
+	instance.Status.ObservedInstallPlanRef = "install-ols-outdated"
+
+	installed, err := InstallInstanceOwnedOLSOperator(context.Background(), helper, instance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if installed {
+		t.Errorf("expected the installation to wait while the InstallPlan reference changes")
+	}
+	if err := cl.Get(context.Background(), installPlanKey, installPlan); err != nil {
+		t.Fatalf("failed to get InstallPlan: %v", err)
+	}
+	if installPlan.Spec.Approved {
+		t.Fatalf("expected the InstallPlan approval to be deferred while the reference changes")
+	}
+	if instance.Status.ObservedInstallPlanRef != installPlanKey.Name {
+		t.Fatalf("ObservedInstallPlanRef = %q, want %q", instance.Status.ObservedInstallPlanRef, installPlanKey.Name)
+	}
+
+	// The next reconcile observes the same reference
+	if _, err := InstallInstanceOwnedOLSOperator(context.Background(), helper, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cl.Get(context.Background(), installPlanKey, installPlan); err != nil {
+		t.Fatalf("failed to get InstallPlan: %v", err)
+	}
+	if !installPlan.Spec.Approved {
+		t.Errorf("expected the InstallPlan to be approved once the reference is stable")
+	}
+}
+
+func TestInstallOLSOperatorSubscriptionChannel(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	cl := newTestClient(t, append(newTestOLSOperatorObjects(instance), instance)...)
+	helper := newTestHelper(t, cl, instance)
+
+	subscription := &operatorsv1alpha1.Subscription{}
+	subscriptionKey := client.ObjectKey{Name: GetOLSSubscriptionName(instance), Namespace: instance.Namespace}
+
+	for _, channel := range []string{"", "candidate"} {
+		instance.Spec.OLSSubscriptionChannel = channel
+		if _, err := InstallInstanceOwnedOLSOperator(context.Background(), helper, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := cl.Get(context.Background(), subscriptionKey, subscription); err != nil {
+			t.Fatalf("failed to get Subscription: %v", err)
+		}
+
+		expectedChannel := channel
+		if expectedChannel == "" {
+			expectedChannel = OLSOperatorChannel
+		}
+		if subscription.Spec.Channel != expectedChannel {
+			t.Errorf("Subscription channel = %q, want %q", subscription.Spec.Channel, expectedChannel)
+		}
+	}
+}
+
+func TestInstallOLSOperatorRetriesCSVOwnershipConflict(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+
+	// OLM updates the CSV between our read and our update once
+	var csvUpdates int
+	cl := newTestClientWithInterceptor(t, interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if csv, ok := obj.(*operatorsv1alpha1.ClusterServiceVersion); ok {
+				csvUpdates++
+				if csvUpdates == 1 {
+					return k8s_errors.NewConflict(
+						operatorsv1alpha1.Resource("clusterserviceversions"), csv.GetName(), nil)
+				}
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}, append(newTestOLSOperatorObjects(instance), instance)...)
+	helper := newTestHelper(t, cl, instance)
+
+	// The first attempt may only reconcile the Subscription
+	for range 2 {
+		if _, err := InstallInstanceOwnedOLSOperator(context.Background(), helper, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if csvUpdates > 0 {
+			break
+		}
+	}
+
+	if csvUpdates != 2 {
+		t.Fatalf("expected the CSV update to be retried once after the conflict, got %d updates", csvUpdates)
+	}
+
+	csv, err := GetOLSOperatorCSV(context.Background(), helper)
+	if err != nil {
+		t.Fatalf("failed to get CSV: %v", err)
+	}
+	ownerReferences := csv.GetOwnerReferences()
+	if len(ownerReferences) != 1 || ownerReferences[0].UID != instance.GetUID() {
+		t.Errorf("expected the CSV to be owned by the instance, got %+v", ownerReferences)
+	}
+}
+
+func TestInstallOLSOperatorUpgrade(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", "1.0.1")
+
+	instance := newTestInstance()
+	objs := []client.Object{instance}
+	for _, obj := range newTestOLSOperatorObjects(instance) {
+		if subscription, ok := obj.(*operatorsv1alpha1.Subscription); ok {
+			subscription.Status.InstallPlanRef.Name = "install-ols-upgrade"
+		}
+		objs = append(objs, obj)
+	}
+
+	// OLM offers the upgrade to the new recommended version, waiting for its manual approval
+	upgradeInstallPlan := &operatorsv1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "install-ols-upgrade",
+			Namespace: instance.Namespace,
+		},
+		Spec: operatorsv1alpha1.InstallPlanSpec{
+			ClusterServiceVersionNames: []string{GetOLSOperatorCSVName("1.0.1")},
+			Approval:                   operatorsv1alpha1.ApprovalManual,
+		},
+	}
+	cl := newTestClient(t, append(objs, upgradeInstallPlan)...)
+	helper := newTestHelper(t, cl, instance)
+
+	// The first attempt moves the Subscription to the new version
+	for range 2 {
+		installed, err := InstallInstanceOwnedOLSOperator(context.Background(), helper, instance)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if installed {
+			t.Errorf("expected the installation to wait for the upgrade")
+		}
+	}
+
+	if err := cl.Get(context.Background(), client.ObjectKeyFromObject(upgradeInstallPlan), upgradeInstallPlan); err != nil {
+		t.Fatalf("failed to get InstallPlan: %v", err)
+	}
+	if !upgradeInstallPlan.Spec.Approved {
+		t.Errorf("expected the upgrade InstallPlan to be approved")
+	}
+}
+
+func TestInstallOLSOperatorRefusesDowngrade(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", "0.9.0")
+
+	instance := newTestInstance()
+	cl := newTestClient(t, append(newTestOLSOperatorObjects(instance), instance)...)
+	helper := newTestHelper(t, cl, instance)
+
+	_, err := InstallInstanceOwnedOLSOperator(context.Background(), helper, instance)
+	if !errors.Is(err, ErrOLSOperatorDowngrade) {
+		t.Fatalf("expected ErrOLSOperatorDowngrade, got %v", err)
+	}
+}
+
+func TestIsOLSOperatorUpgrading(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", "1.0.1")
+
+	tests := []struct {
+		name            string
+		installedCSV    string
+		expectUpgrading bool
+		expectDowngrade bool
+	}{
+		{
+			name: "Nothing installed yet",
+		},
+		{
+			name:         "Recommended version installed",
+			installedCSV: GetOLSOperatorCSVName("1.0.1"),
+		},
+		{
+			name:            "Older version installed",
+			installedCSV:    GetOLSOperatorCSVName("1.0.0"),
+			expectUpgrading: true,
+		},
+		{
+			name:            "Newer version installed",
+			installedCSV:    GetOLSOperatorCSVName("1.1.0"),
+			expectDowngrade: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := &operatorsv1alpha1.Subscription{
+				Status: operatorsv1alpha1.SubscriptionStatus{InstalledCSV: tt.installedCSV},
+			}
+
+			isUpgrading, err := IsOLSOperatorUpgrading(subscription)
+			if tt.expectDowngrade != errors.Is(err, ErrOLSOperatorDowngrade) {
+				t.Fatalf("expected downgrade error %v, got %v", tt.expectDowngrade, err)
+			} else if !tt.expectDowngrade && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if isUpgrading != tt.expectUpgrading {
+				t.Errorf("IsOLSOperatorUpgrading = %v, want %v", isUpgrading, tt.expectUpgrading)
+			}
+		})
+	}
+}
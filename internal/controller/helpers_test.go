@@ -0,0 +1,483 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+const (
+	testInstanceName      = "openstack-lightspeed"
+	testInstanceNamespace = "openstack-lightspeed"
+	testInstanceUID       = "0a1b2c3d-0000-0000-0000-000000000000"
+	testOLSVersion        = "1.0.0"
+	testOLSCSVName        = OLSOperatorName + ".v" + testOLSVersion
+	testOLSNamespace      = "openshift-lightspeed"
+)
+
+var (
+	testOLSConfigGVK = schema.GroupVersionKind{
+		Group:   "ols.openshift.io",
+		Version: "v1alpha1",
+		Kind:    "OLSConfig",
+	}
+
+	testClusterVersionGVK = schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "ClusterVersion",
+	}
+
+	testProxyGVK = schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "Proxy",
+	}
+
+	testPackageManifestGVK = schema.GroupVersionKind{
+		Group:   "packages.operators.coreos.com",
+		Version: "v1",
+		Kind:    "PackageManifest",
+	}
+
+	testCRDGVK = schema.GroupVersionKind{
+		Group:   "apiextensions.k8s.io",
+		Version: "v1",
+		Kind:    "CustomResourceDefinition",
+	}
+
+	testImageDigestMirrorSetGVK = schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "ImageDigestMirrorSet",
+	}
+
+	testImageTagMirrorSetGVK = schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "ImageTagMirrorSet",
+	}
+
+	testPrometheusRuleGVK = schema.GroupVersionKind{
+		Group:   "monitoring.coreos.com",
+		Version: "v1",
+		Kind:    "PrometheusRule",
+	}
+)
+
+// newTestScheme returns a scheme that knows about every type the controller touches. Types
+// that are only consumed as unstructured objects are registered as such.
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	s := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		operatorsv1alpha1.AddToScheme,
+		apiv1beta1.AddToScheme,
+	} {
+		if err := addToScheme(s); err != nil {
+			t.Fatalf("failed to build test scheme: %v", err)
+		}
+	}
+
+	// Register the graduated OLSConfig API version as well, tests may switch to it through
+	// OLS_CONFIG_API_VERSION.
+	testOLSConfigV1GVK := schema.GroupVersionKind{Group: testOLSConfigGVK.Group, Version: "v1", Kind: testOLSConfigGVK.Kind}
+
+	for _, gvk := range []schema.GroupVersionKind{
+		testOLSConfigGVK, testOLSConfigV1GVK, testClusterVersionGVK, testProxyGVK, testPackageManifestGVK, testCRDGVK,
+		testImageDigestMirrorSetGVK, testImageTagMirrorSetGVK, testPrometheusRuleGVK,
+	} {
+		s.AddKnownTypeWithName(gvk, &uns.Unstructured{})
+		s.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &uns.UnstructuredList{})
+	}
+
+	return s
+}
+
+// newTestInstance returns a minimal valid OpenStackLightspeed instance.
+func newTestInstance() *apiv1beta1.OpenStackLightspeed {
+	return &apiv1beta1.OpenStackLightspeed{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiv1beta1.GroupVersion.String(),
+			Kind:       "OpenStackLightspeed",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testInstanceName,
+			Namespace: testInstanceNamespace,
+			UID:       types.UID(testInstanceUID),
+		},
+		Spec: apiv1beta1.OpenStackLightspeedSpec{
+			OpenStackLightspeedCore: apiv1beta1.OpenStackLightspeedCore{
+				LLMEndpoint:     "https://llm.example.com/v1",
+				LLMEndpointType: "openai",
+				ModelName:       "test-model",
+				LLMCredentials:  "llm-credentials",
+				// CRD defaults
+				CatalogSourceNamespace: "openshift-marketplace",
+				CatalogSourceName:      "redhat-operators",
+			},
+			RAGImage: testRAGImage,
+		},
+	}
+}
+
+// newTestClusterVersion returns a ClusterVersion reporting the given desired version.
+func newTestClusterVersion(version string) *uns.Unstructured {
+	clusterVersion := &uns.Unstructured{}
+	clusterVersion.SetGroupVersionKind(testClusterVersionGVK)
+	clusterVersion.SetName("version")
+	_ = uns.SetNestedField(clusterVersion.Object, version, "status", "desired", "version")
+	return clusterVersion
+}
+
+// newTestClusterProxy returns the cluster Proxy object of a cluster using the HTTPS egress proxy.
+func newTestClusterProxy(httpsProxy string) *uns.Unstructured {
+	proxy := &uns.Unstructured{}
+	proxy.SetGroupVersionKind(testProxyGVK)
+	proxy.SetName(ClusterProxyName)
+	_ = uns.SetNestedField(proxy.Object, httpsProxy, "status", "httpsProxy")
+	return proxy
+}
+
+// newTestOwnerReferences returns the owner references the controller sets on the objects owned
+// by instance. The fake client does not return TypeMeta for typed objects, so the references
+// built by the controller during the tests carry neither APIVersion nor Kind.
+func newTestOwnerReferences(instance *apiv1beta1.OpenStackLightspeed) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			Name:               instance.GetName(),
+			UID:                instance.GetUID(),
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
+
+// newTestOLSConfigCRD returns the OLSConfig CRD serving the default OLSConfig API version. When
+// established is true the CRD reports the Established condition.
+func newTestOLSConfigCRD(established bool) *uns.Unstructured {
+	crd := &uns.Unstructured{}
+	crd.SetGroupVersionKind(testCRDGVK)
+	crd.SetName(OLSConfigCRDName)
+	_ = uns.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"name": OLSConfigDefaultAPIVersion, "served": true, "storage": true},
+	}, "spec", "versions")
+
+	status := "False"
+	if established {
+		status = "True"
+	}
+	_ = uns.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"type": "Established", "status": status},
+	}, "status", "conditions")
+
+	return crd
+}
+
+// newTestLLMCredentialsSecret returns the LLM credentials secret of instance in the OLS namespace
+func newTestLLMCredentialsSecret(instance *apiv1beta1.OpenStackLightspeed) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Spec.LLMCredentials,
+			Namespace: OLSOperatorNamespace,
+		},
+		Data: map[string][]byte{LLMCredentialsAPITokenKey: []byte("test-token")},
+	}
+}
+
+// newTestOLSOperatorObjects returns the Subscription, InstallPlan, CSV and the established
+// OLSConfig CRD of an OLS operator that was successfully installed by instance, along with the LLM
+// credentials secret of instance. Tests using these objects are expected to set
+// OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION to testOLSVersion.
+func newTestOLSOperatorObjects(instance *apiv1beta1.OpenStackLightspeed) []client.Object {
+	subscription := &operatorsv1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            GetOLSSubscriptionName(instance),
+			Namespace:       instance.Namespace,
+			OwnerReferences: newTestOwnerReferences(instance),
+		},
+		Spec: &operatorsv1alpha1.SubscriptionSpec{
+			Channel:                "stable",
+			InstallPlanApproval:    operatorsv1alpha1.ApprovalManual,
+			CatalogSource:          instance.Spec.CatalogSourceName,
+			CatalogSourceNamespace: instance.Spec.CatalogSourceNamespace,
+			Package:                OLSOperatorName,
+			StartingCSV:            testOLSCSVName,
+		},
+		Status: operatorsv1alpha1.SubscriptionStatus{
+			InstallPlanRef: &corev1.ObjectReference{
+				Name:      "install-ols",
+				Namespace: instance.Namespace,
+			},
+			InstalledCSV: testOLSCSVName,
+		},
+	}
+
+	installPlan := &operatorsv1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "install-ols",
+			Namespace: instance.Namespace,
+		},
+		Spec: operatorsv1alpha1.InstallPlanSpec{
+			ClusterServiceVersionNames: []string{testOLSCSVName},
+			Approval:                   operatorsv1alpha1.ApprovalManual,
+			Approved:                   true,
+		},
+	}
+
+	csv := &operatorsv1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            testOLSCSVName,
+			Namespace:       testOLSNamespace,
+			OwnerReferences: newTestOwnerReferences(instance),
+		},
+		Status: operatorsv1alpha1.ClusterServiceVersionStatus{
+			Phase: operatorsv1alpha1.CSVPhaseSucceeded,
+		},
+	}
+
+	packageManifest := newTestPackageManifest(instance, OLSOperatorChannel, testOLSCSVName)
+
+	return []client.Object{subscription, installPlan, csv, packageManifest, newTestOLSConfigCRD(true),
+		newTestLLMCredentialsSecret(instance)}
+}
+
+// setTestOLSOperatorVersion makes objs, as returned by newTestOLSOperatorObjects, describe the
+// given OLS operator version installed as the recommended version.
+func setTestOLSOperatorVersion(t *testing.T, objs []client.Object, olsVersion string) {
+	t.Helper()
+
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", olsVersion)
+	csvName := GetOLSOperatorCSVName(olsVersion)
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *operatorsv1alpha1.Subscription:
+			o.Spec.StartingCSV = csvName
+			o.Status.InstalledCSV = csvName
+		case *operatorsv1alpha1.InstallPlan:
+			o.Spec.ClusterServiceVersionNames = []string{csvName}
+		case *operatorsv1alpha1.ClusterServiceVersion:
+			o.Name = csvName
+			if err := o.Spec.Version.UnmarshalJSON([]byte(`"` + olsVersion + `"`)); err != nil {
+				t.Fatalf("failed to set the CSV version: %v", err)
+			}
+		}
+	}
+}
+
+// newTestPackageManifest returns the PackageManifest of the OLS package in the catalog configured
+// in instance, offering the given CSVs in channel.
+func newTestPackageManifest(
+	instance *apiv1beta1.OpenStackLightspeed,
+	channel string,
+	csvNames ...string,
+) *uns.Unstructured {
+	packageManifest := &uns.Unstructured{}
+	packageManifest.SetGroupVersionKind(testPackageManifestGVK)
+	packageManifest.SetName(OLSOperatorName)
+	packageManifest.SetNamespace(instance.Spec.CatalogSourceNamespace)
+	packageManifest.SetLabels(map[string]string{"catalog": instance.Spec.CatalogSourceName})
+
+	entries := []interface{}{}
+	for _, name := range csvNames {
+		entries = append(entries, map[string]interface{}{"name": name})
+	}
+	_ = uns.SetNestedField(packageManifest.Object, instance.Spec.CatalogSourceName, "status", "catalogSource")
+	_ = uns.SetNestedSlice(packageManifest.Object, []interface{}{
+		map[string]interface{}{"name": channel, "entries": entries},
+	}, "status", "channels")
+
+	return packageManifest
+}
+
+// newTestCatalogSource returns the CatalogSource configured in instance in the given connection
+// state. The connection state is left unset when state is empty.
+func newTestCatalogSource(instance *apiv1beta1.OpenStackLightspeed, state string) *operatorsv1alpha1.CatalogSource {
+	catalogSource := &operatorsv1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Spec.CatalogSourceName,
+			Namespace: instance.Spec.CatalogSourceNamespace,
+		},
+	}
+	if state != "" {
+		catalogSource.Status.GRPCConnectionState = &operatorsv1alpha1.GRPCConnectionState{LastObservedState: state}
+	}
+
+	return catalogSource
+}
+
+// newTestOLSConfig returns an OLSConfig managed by instance. When ready is true the OLSConfig
+// reports a Ready overall status.
+func newTestOLSConfig(instance *apiv1beta1.OpenStackLightspeed, ready bool) *uns.Unstructured {
+	olsConfig := &uns.Unstructured{}
+	olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+	olsConfig.SetName(OLSConfigName)
+	olsConfig.SetLabels(map[string]string{OpenStackLightspeedOwnerIDLabel: string(instance.GetUID())})
+	olsConfig.SetFinalizers([]string{"openstack.org/openstacklightspeed"})
+	if ready {
+		_ = uns.SetNestedField(olsConfig.Object, "Ready", "status", "overallStatus")
+	}
+
+	return olsConfig
+}
+
+// getTestOLSConfig returns the OLSConfig stored in cl.
+func getTestOLSConfig(t *testing.T, cl client.Client) (*uns.Unstructured, error) {
+	t.Helper()
+
+	olsConfig := &uns.Unstructured{}
+	olsConfig.SetGroupVersionKind(testOLSConfigGVK)
+	err := cl.Get(context.Background(), client.ObjectKey{Name: OLSConfigName}, olsConfig)
+	return olsConfig, err
+}
+
+// newTestClient returns a fake client pre-populated with objs. The same client is also used
+// as the cluster wide client returned by GetRawClient for the duration of the test.
+func newTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	return newTestClientWithInterceptor(t, interceptor.Funcs{}, objs...)
+}
+
+// newTestClientWithInterceptor is like newTestClient but routes the client calls through funcs,
+// which allows tests to inject API errors.
+func newTestClientWithInterceptor(t *testing.T, funcs interceptor.Funcs, objs ...client.Object) client.Client {
+	t.Helper()
+
+	cl := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(objs...).
+		WithStatusSubresource(&apiv1beta1.OpenStackLightspeed{}).
+		WithInterceptorFuncs(funcs).
+		Build()
+
+	origNewRawClient := newRawClient
+	newRawClient = func(_ *common_helper.Helper) (client.Client, error) {
+		return cl, nil
+	}
+	t.Cleanup(func() { newRawClient = origNewRawClient })
+
+	// Unit tests must not reach out to container registries.
+	stubImageInspection(t, &ImageInspection{Err: errors.New("registry access is disabled in unit tests")})
+
+	return cl
+}
+
+// stubImageInspection makes the registry lookup of the RAG image return inspection for the
+// duration of the test. The cached lookups are dropped so that every test starts afresh.
+func stubImageInspection(t *testing.T, inspection *ImageInspection) {
+	t.Helper()
+
+	resetImageInspections := func() {
+		imageInspectionsMutex.Lock()
+		defer imageInspectionsMutex.Unlock()
+		imageInspections = map[string]*ImageInspection{}
+	}
+
+	origInspectImage := inspectImage
+	inspectImage = func(_ context.Context, _ []string, _ map[string]string) *ImageInspection {
+		return &ImageInspection{Architectures: inspection.Architectures, Labels: inspection.Labels, Err: inspection.Err}
+	}
+	resetImageInspections()
+	t.Cleanup(func() {
+		inspectImage = origInspectImage
+		resetImageInspections()
+	})
+}
+
+// newTestNode returns a cluster node running on the given architecture.
+func newTestNode(name string, arch string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{Architecture: arch},
+		},
+	}
+}
+
+// newTestRAGPod returns an OLS pod whose init container copies the vector DB from the RAG image.
+// The init container is waiting for the given reason, or already running when reason is empty.
+func newTestRAGPod(name string, ragImage string, reason string, message string) *corev1.Pod {
+	state := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	if reason != "" {
+		state = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message}}
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: OLSOperatorNamespace},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "rag-0", Image: ragImage}},
+			Containers:     []corev1.Container{{Name: "lightspeed-service-api", Image: "lightspeed-service:latest"}},
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{Name: "rag-0", Image: ragImage, State: state}},
+		},
+	}
+}
+
+// newTestHelper returns a helper for instance backed by cl.
+func newTestHelper(t *testing.T, cl client.Client, instance *apiv1beta1.OpenStackLightspeed) *common_helper.Helper {
+	t.Helper()
+
+	helper, err := common_helper.NewHelper(instance, cl, nil, cl.Scheme(), ctrl.Log)
+	if err != nil {
+		t.Fatalf("failed to create helper: %v", err)
+	}
+
+	return helper
+}
+
+// reconcileTestInstance runs a single reconcile of the test instance and returns the
+// persisted instance afterwards.
+func reconcileTestInstance(
+	t *testing.T,
+	r *OpenStackLightspeedReconciler,
+) (ctrl.Result, *apiv1beta1.OpenStackLightspeed, error) {
+	t.Helper()
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: testInstanceName, Namespace: testInstanceNamespace}
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+	instance := &apiv1beta1.OpenStackLightspeed{}
+	if getErr := r.Get(ctx, key, instance); getErr != nil {
+		t.Fatalf("failed to get OpenStackLightspeed instance: %v", getErr)
+	}
+
+	return result, instance, err
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// imageManifestMediaTypes - media types accepted when fetching an image manifest
+const imageManifestMediaTypes = "application/vnd.oci.image.index.v1+json," +
+	"application/vnd.docker.distribution.manifest.list.v2+json," +
+	"application/vnd.oci.image.manifest.v1+json," +
+	"application/vnd.docker.distribution.manifest.v2+json"
+
+// imageManifest holds the fields we need from an image index or an image manifest
+type imageManifest struct {
+	Manifests []struct {
+		Digest   string `json:"digest"`
+		Platform struct {
+			Architecture string `json:"architecture"`
+		} `json:"platform"`
+	} `json:"manifests"`
+	Config struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+}
+
+// ParseImageReference splits an image reference into the registry host, the repository and the
+// tag or digest. Images without a registry are pulled from Docker Hub.
+// Example: "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2" ->
+// ("quay.io", "openstack-lightspeed/rag-content", "os-docs-2025.2")
+func ParseImageReference(image string) (string, string, string) {
+	name, reference := image, "latest"
+	if before, digest, found := strings.Cut(image, "@"); found {
+		name, reference = before, digest
+	}
+
+	lastSlash := strings.LastIndex(name, "/")
+	if idx := strings.LastIndex(name, ":"); idx > lastSlash {
+		if reference == "latest" {
+			reference = name[idx+1:]
+		}
+		name = name[:idx]
+	}
+
+	registry, repository := "registry-1.docker.io", name
+	if first, rest, found := strings.Cut(name, "/"); found &&
+		(strings.ContainsAny(first, ".:") || first == "localhost") {
+		registry, repository = first, rest
+	}
+
+	if registry == "registry-1.docker.io" && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	return registry, repository, reference
+}
+
+// GetImageArchitectures queries the registry for the architectures the image is built for. auth
+// holds the base64 encoded basic auth credentials of the registry, anonymous access is used when
+// it is empty.
+func GetImageArchitectures(ctx context.Context, httpClient *http.Client, image string, auth string) ([]string, error) {
+	registry, repository, reference := ParseImageReference(image)
+	baseURL := fmt.Sprintf("https://%s/v2/%s", registry, repository)
+
+	var manifest imageManifest
+	authorization, err := registryGet(ctx, httpClient, baseURL+"/manifests/"+reference, "", auth, &manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var archs []string
+	for _, m := range manifest.Manifests {
+		// Attestation manifests are listed with an unknown platform
+		arch := m.Platform.Architecture
+		if arch != "" && arch != "unknown" && !slices.Contains(archs, arch) {
+			archs = append(archs, arch)
+		}
+	}
+
+	if len(manifest.Manifests) > 0 {
+		return archs, nil
+	}
+
+	// A single architecture image, the architecture is stored in the image config
+	if manifest.Config.Digest == "" {
+		return nil, fmt.Errorf("image %s has neither a manifest list nor a config", image)
+	}
+
+	var config struct {
+		Architecture string `json:"architecture"`
+	}
+	if _, err := registryGet(ctx, httpClient, baseURL+"/blobs/"+manifest.Config.Digest, authorization, auth, &config); err != nil {
+		return nil, err
+	}
+
+	if config.Architecture == "" {
+		return nil, fmt.Errorf("image %s does not report its architecture", image)
+	}
+
+	return []string{config.Architecture}, nil
+}
+
+// registryGet fetches the registry URL and decodes the JSON response into out. When the registry
+// asks for authentication, a bearer token is requested, or the basic auth credentials sent, and the
+// request repeated. The Authorization header in use is returned so that it can be reused for
+// further requests.
+func registryGet(
+	ctx context.Context,
+	httpClient *http.Client,
+	registryURL string,
+	authorization string,
+	auth string,
+	out interface{},
+) (string, error) {
+	resp, err := registryRequest(ctx, httpClient, registryURL, authorization)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized && authorization == "" {
+		authorization, err = getRegistryAuthorization(ctx, httpClient, resp.Header.Get("WWW-Authenticate"), auth)
+		if err != nil {
+			return "", err
+		}
+		return registryGet(ctx, httpClient, registryURL, authorization, auth, out)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s returned %s", registryURL, resp.Status)
+	}
+
+	return authorization, json.NewDecoder(io.LimitReader(resp.Body, 4<<20)).Decode(out)
+}
+
+// registryRequest sends a GET request to the registry with the given Authorization header
+func registryRequest(ctx context.Context, httpClient *http.Client, registryURL string, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, registryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", imageManifestMediaTypes)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	return httpClient.Do(req)
+}
+
+// getRegistryAuthorization returns the Authorization header answering the WWW-Authenticate
+// challenge of the registry. A basic challenge is answered with the credentials in auth, a bearer
+// token is requested otherwise, authenticated with the credentials in auth when set.
+// Example: Bearer realm="https://quay.io/v2/auth",service="quay.io",scope="repository:foo/bar:pull"
+func getRegistryAuthorization(ctx context.Context, httpClient *http.Client, challenge string, auth string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if strings.EqualFold(scheme, "Basic") {
+		if auth == "" {
+			return "", errors.New("registry requires credentials, set ragImagePullSecret")
+		}
+		return "Basic " + auth, nil
+	} else if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported registry authentication: %q", challenge)
+	}
+
+	values := map[string]string{}
+	for _, param := range strings.Split(params, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		values[key] = strings.Trim(value, `"`)
+	}
+
+	if values["realm"] == "" {
+		return "", errors.New("registry authentication challenge without realm")
+	}
+
+	tokenURL, err := url.Parse(values["realm"])
+	if err != nil {
+		return "", err
+	}
+
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if values[key] != "" {
+			query.Set(key, values[key])
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	var tokenAuthorization string
+	if auth != "" {
+		tokenAuthorization = "Basic " + auth
+	}
+
+	resp, err := registryRequest(ctx, httpClient, tokenURL.String(), tokenAuthorization)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token request returned %s", resp.Status)
+	}
+
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&tokenResponse); err != nil {
+		return "", err
+	}
+
+	if tokenResponse.Token != "" {
+		return "Bearer " + tokenResponse.Token, nil
+	}
+
+	return "Bearer " + tokenResponse.AccessToken, nil
+}
+
+// GetNodeArchitectures returns the architectures of the cluster nodes
+func GetNodeArchitectures(ctx context.Context, helper *common_helper.Helper) ([]string, error) {
+	// Use raw client to access cluster-scoped resources
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes corev1.NodeList
+	if err := rawClient.List(ctx, &nodes); err != nil {
+		return nil, err
+	}
+
+	var archs []string
+	for _, node := range nodes.Items {
+		arch := node.Status.NodeInfo.Architecture
+		if arch != "" && !slices.Contains(archs, arch) {
+			archs = append(archs, arch)
+		}
+	}
+
+	slices.Sort(archs)
+	return archs, nil
+}
+
+// GetUnsupportedArchitectures returns the node architectures the image is not built for
+func GetUnsupportedArchitectures(imageArchs []string, nodeArchs []string) []string {
+	var unsupported []string
+	for _, arch := range nodeArchs {
+		if !slices.Contains(imageArchs, arch) {
+			unsupported = append(unsupported, arch)
+		}
+	}
+
+	return unsupported
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		name               string
+		image              string
+		expectedRegistry   string
+		expectedRepository string
+		expectedReference  string
+	}{
+		{
+			name:               "Tagged image",
+			image:              "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2",
+			expectedRegistry:   "quay.io",
+			expectedRepository: "openstack-lightspeed/rag-content",
+			expectedReference:  "os-docs-2025.2",
+		},
+		{
+			name:               "Registry with port and no tag",
+			image:              "registry.example.com:5000/rag-content",
+			expectedRegistry:   "registry.example.com:5000",
+			expectedRepository: "rag-content",
+			expectedReference:  "latest",
+		},
+		{
+			name:               "Tag and digest",
+			image:              "quay.io/rag-content:os-docs-2025.2@sha256:0123",
+			expectedRegistry:   "quay.io",
+			expectedRepository: "rag-content",
+			expectedReference:  "sha256:0123",
+		},
+		{
+			name:               "Docker Hub image",
+			image:              "rag-content:v1",
+			expectedRegistry:   "registry-1.docker.io",
+			expectedRepository: "library/rag-content",
+			expectedReference:  "v1",
+		},
+		{
+			name:               "Docker Hub organization",
+			image:              "openstack/rag-content:v1",
+			expectedRegistry:   "registry-1.docker.io",
+			expectedRepository: "openstack/rag-content",
+			expectedReference:  "v1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, repository, reference := ParseImageReference(tt.image)
+			if registry != tt.expectedRegistry || repository != tt.expectedRepository || reference != tt.expectedReference {
+				t.Errorf("ParseImageReference(%s) = (%s, %s, %s), want (%s, %s, %s)", tt.image,
+					registry, repository, reference,
+					tt.expectedRegistry, tt.expectedRepository, tt.expectedReference)
+			}
+		})
+	}
+}
+
+func TestGetImageArchitectures(t *testing.T) {
+	const token = "test-token"
+	// base64 of "user:secret"
+	const auth = "dXNlcjpzZWNyZXQ="
+
+	var registryHost string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("scope") {
+		case "repository:rag/private:pull":
+		case "repository:rag/restricted:pull":
+			if r.Header.Get("Authorization") != "Basic "+auth {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		default:
+			http.Error(w, "unexpected scope", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"token": %q}`, token)
+	})
+	mux.HandleFunc("/v2/rag/restricted/manifests/v1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+				`Bearer realm="https://%s/token",service="test",scope="repository:rag/restricted:pull"`, registryHost))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"manifests": [{"platform": {"architecture": "amd64", "os": "linux"}}]}`)
+	})
+	mux.HandleFunc("/v2/rag/basic/manifests/v1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Basic "+auth {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"manifests": [{"platform": {"architecture": "s390x", "os": "linux"}}]}`)
+	})
+	mux.HandleFunc("/v2/rag/multiarch/manifests/v1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"manifests": [
+			{"platform": {"architecture": "amd64", "os": "linux"}},
+			{"platform": {"architecture": "arm64", "os": "linux"}},
+			{"platform": {"architecture": "unknown", "os": "unknown"}}
+		]}`)
+	})
+	mux.HandleFunc("/v2/rag/private/manifests/v1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+				`Bearer realm="https://%s/token",service="test",scope="repository:rag/private:pull"`, registryHost))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"config": {"digest": "sha256:abcd"}}`)
+	})
+	mux.HandleFunc("/v2/rag/private/blobs/sha256:abcd", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"architecture": "amd64", "os": "linux"}`)
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	registryHost = strings.TrimPrefix(server.URL, "https://")
+
+	tests := []struct {
+		name          string
+		image         string
+		auth          string
+		expectedArchs []string
+		shouldError   bool
+	}{
+		{
+			name:          "Multi-architecture image",
+			image:         registryHost + "/rag/multiarch:v1",
+			expectedArchs: []string{"amd64", "arm64"},
+		},
+		{
+			name:          "Single architecture image behind token authentication",
+			image:         registryHost + "/rag/private:v1",
+			expectedArchs: []string{"amd64"},
+		},
+		{
+			name:          "Token authentication with credentials",
+			image:         registryHost + "/rag/restricted:v1",
+			auth:          auth,
+			expectedArchs: []string{"amd64"},
+		},
+		{
+			name:        "Token authentication without credentials",
+			image:       registryHost + "/rag/restricted:v1",
+			shouldError: true,
+		},
+		{
+			name:          "Basic authentication with credentials",
+			image:         registryHost + "/rag/basic:v1",
+			auth:          auth,
+			expectedArchs: []string{"s390x"},
+		},
+		{
+			name:        "Basic authentication without credentials",
+			image:       registryHost + "/rag/basic:v1",
+			shouldError: true,
+		},
+		{
+			name:        "Missing image",
+			image:       registryHost + "/rag/missing:v1",
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archs, err := GetImageArchitectures(context.Background(), server.Client(), tt.image, tt.auth)
+			if tt.shouldError {
+				if err == nil {
+					t.Errorf("GetImageArchitectures(%s) expected error, got nil", tt.image)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetImageArchitectures(%s) unexpected error: %v", tt.image, err)
+			}
+			if !slices.Equal(archs, tt.expectedArchs) {
+				t.Errorf("GetImageArchitectures(%s) = %v, want %v", tt.image, archs, tt.expectedArchs)
+			}
+		})
+	}
+}
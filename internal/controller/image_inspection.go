@@ -0,0 +1,310 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// ImageInspectionTimeout - upper bound for the registry lookup of the RAG image. The lookup is
+	// best-effort and must not hold the reconcile for long.
+	ImageInspectionTimeout = 10 * time.Second
+
+	// ImageInspectionTTL - how long a successful registry lookup is reused
+	ImageInspectionTTL = time.Hour
+
+	// ImageInspectionFailureTTL - how long a failed registry lookup is reused before the registry
+	// is queried again. Shorter than ImageInspectionTTL so that a fixed pull secret or registry
+	// outage is picked up reasonably fast.
+	ImageInspectionFailureTTL = 10 * time.Minute
+)
+
+// ImageInspection - outcome of the registry lookup of an image
+type ImageInspection struct {
+	// Architectures the image is built for
+	Architectures []string
+
+	// Labels of the image config
+	Labels map[string]string
+
+	// Err is set when the image could not be looked up in any of its sources
+	Err error
+
+	expiresAt time.Time
+}
+
+// imageInspections caches the registry lookups, failed ones included, so that the registries are
+// not queried on every reconcile. Entries are keyed by imageInspectionKey.
+var (
+	imageInspectionsMutex sync.Mutex
+	imageInspections      = map[string]*ImageInspection{}
+)
+
+// inspectImage looks the image up in each of the sources in turn and returns the first successful
+// lookup, or the error of the last source. auths maps a registry host to its basic auth
+// credentials. It is a variable so that unit tests can replace the registry lookup.
+var inspectImage = func(ctx context.Context, sources []string, auths map[string]string) *ImageInspection {
+	httpClient := &http.Client{Timeout: ImageInspectionTimeout}
+
+	var err error
+	for _, source := range sources {
+		registry, _, _ := ParseImageReference(source)
+
+		var archs []string
+		archs, err = GetImageArchitectures(ctx, httpClient, source, auths[registry])
+		if err != nil {
+			continue
+		}
+
+		var labels map[string]string
+		labels, err = GetImageLabels(ctx, httpClient, source, auths[registry])
+		if err == nil {
+			return &ImageInspection{Architectures: archs, Labels: labels}
+		}
+	}
+
+	return &ImageInspection{Err: err}
+}
+
+// GetImageInspection returns the registry lookup of the image. The cached lookup is returned until
+// it expires, the registries are queried only when the image changes or the entry expired. The
+// image mirrors configured in the cluster are tried before the image itself and the credentials
+// of the pull secret in the OLS namespace are used when pullSecret is set. A failure to read the
+// cluster configuration is reported, and cached, as a failed lookup.
+func GetImageInspection(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	image string,
+	pullSecret string,
+) *ImageInspection {
+	key := imageInspectionKey(image)
+	now := time.Now()
+
+	imageInspectionsMutex.Lock()
+	inspection, found := imageInspections[key]
+	imageInspectionsMutex.Unlock()
+	if found && now.Before(inspection.expiresAt) {
+		return inspection
+	}
+
+	sources, err := GetImageSources(ctx, helper, image)
+	if err == nil {
+		var auths map[string]string
+		auths, err = GetRegistryAuths(ctx, helper, pullSecret)
+		if err == nil {
+			inspection = inspectImage(ctx, sources, auths)
+		}
+	}
+	if err != nil {
+		inspection = &ImageInspection{Err: err}
+	}
+
+	ttl := ImageInspectionTTL
+	if inspection.Err != nil {
+		ttl = ImageInspectionFailureTTL
+	}
+	inspection.expiresAt = now.Add(ttl)
+
+	imageInspectionsMutex.Lock()
+	defer imageInspectionsMutex.Unlock()
+	for k, v := range imageInspections {
+		if !now.Before(v.expiresAt) {
+			delete(imageInspections, k)
+		}
+	}
+	imageInspections[key] = inspection
+
+	return inspection
+}
+
+// imageInspectionKey returns the cache key of the image. Images pinned by digest are keyed by the
+// digest, the same content pulled through a different name is looked up only once.
+func imageInspectionKey(image string) string {
+	if _, digest, found := strings.Cut(image, "@"); found {
+		return digest
+	}
+
+	return image
+}
+
+// GetImageDigestMirrorSetGVK returns the GroupVersionKind of the ImageDigestMirrorSet
+func GetImageDigestMirrorSetGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "ImageDigestMirrorSet",
+	}
+}
+
+// GetImageTagMirrorSetGVK returns the GroupVersionKind of the ImageTagMirrorSet
+func GetImageTagMirrorSetGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "ImageTagMirrorSet",
+	}
+}
+
+// GetImageContentSourcePolicyGVK returns the GroupVersionKind of the deprecated
+// ImageContentSourcePolicy
+func GetImageContentSourcePolicyGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "operator.openshift.io",
+		Version: "v1alpha1",
+		Kind:    "ImageContentSourcePolicy",
+	}
+}
+
+// GetImageSources returns the references the image can be pulled from: the mirrors configured
+// through ImageDigestMirrorSets and ImageContentSourcePolicies for images pinned by digest, or
+// through ImageTagMirrorSets for tagged images, followed by the image itself. Mirror APIs missing
+// from the cluster are ignored.
+// Example: with quay.io/openstack-lightspeed mirrored to registry.local/ols,
+// "quay.io/openstack-lightspeed/rag-content@sha256:0123" ->
+// ["registry.local/ols/rag-content@sha256:0123", "quay.io/openstack-lightspeed/rag-content@sha256:0123"]
+func GetImageSources(ctx context.Context, helper *common_helper.Helper, image string) ([]string, error) {
+	name, reference := splitImageName(image)
+
+	type mirrorList struct {
+		gvk  schema.GroupVersionKind
+		path []string
+	}
+
+	mirrorLists := []mirrorList{
+		{GetImageTagMirrorSetGVK(), []string{"spec", "imageTagMirrors"}},
+	}
+	if strings.HasPrefix(reference, "@") {
+		mirrorLists = []mirrorList{
+			{GetImageDigestMirrorSetGVK(), []string{"spec", "imageDigestMirrors"}},
+			{GetImageContentSourcePolicyGVK(), []string{"spec", "repositoryDigestMirrors"}},
+		}
+	}
+
+	// Use raw client to access cluster-scoped resources
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []string
+	for _, mirrors := range mirrorLists {
+		list := &uns.UnstructuredList{}
+		list.SetGroupVersionKind(mirrors.gvk.GroupVersion().WithKind(mirrors.gvk.Kind + "List"))
+		err := rawClient.List(ctx, list)
+		if meta.IsNoMatchError(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		for _, item := range list.Items {
+			mirrorSets, _, _ := uns.NestedSlice(item.Object, mirrors.path...)
+			for _, mirrorSet := range mirrorSets {
+				mirrorSetMap, ok := mirrorSet.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				source, _, _ := uns.NestedString(mirrorSetMap, "source")
+				if source == "" || (name != source && !strings.HasPrefix(name, source+"/")) {
+					continue
+				}
+
+				mirrorHosts, _, _ := uns.NestedStringSlice(mirrorSetMap, "mirrors")
+				for _, mirror := range mirrorHosts {
+					sources = append(sources, mirror+strings.TrimPrefix(name, source)+reference)
+				}
+			}
+		}
+	}
+
+	return append(sources, image), nil
+}
+
+// splitImageName splits the image reference into the image name and the tag or digest, including
+// its separator.
+// Example: "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2" ->
+// ("quay.io/openstack-lightspeed/rag-content", ":os-docs-2025.2")
+func splitImageName(image string) (string, string) {
+	if idx := strings.Index(image, "@"); idx >= 0 {
+		return image[:idx], image[idx:]
+	}
+
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		return image[:idx], image[idx:]
+	}
+
+	return image, ""
+}
+
+// GetRegistryAuths returns the basic auth credentials, base64 encoded, of the registries listed in
+// the dockerconfigjson pull secret in the OLS namespace, keyed by registry host. A pull secret that
+// does not exist yet provides no credentials.
+func GetRegistryAuths(ctx context.Context, helper *common_helper.Helper, pullSecret string) (map[string]string, error) {
+	auths := map[string]string{}
+	if pullSecret == "" {
+		return auths, nil
+	}
+
+	secret, err := GetOLSSecret(ctx, helper, pullSecret)
+	if err != nil || secret == nil {
+		return auths, err
+	}
+
+	var dockerConfig struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &dockerConfig); err != nil {
+		return nil, err
+	}
+
+	for server, auth := range dockerConfig.Auths {
+		// Entries might be full URLs or scoped to a repository, e.g. https://index.docker.io/v1/
+		host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+		host, _, _ = strings.Cut(host, "/")
+		if host == "docker.io" || host == "index.docker.io" {
+			host = "registry-1.docker.io"
+		}
+
+		credentials := auth.Auth
+		if credentials == "" && auth.Username != "" {
+			credentials = base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))
+		}
+		if credentials != "" {
+			auths[host] = credentials
+		}
+	}
+
+	return auths, nil
+}
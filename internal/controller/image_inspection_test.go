@@ -0,0 +1,225 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetImageInspection(t *testing.T) {
+	const image = "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2"
+
+	instance := newTestInstance()
+	cl := newTestClient(t, instance)
+	helper := newTestHelper(t, cl, instance)
+
+	lookups := 0
+	var lookupErr error
+	stubImageInspection(t, &ImageInspection{})
+	inspectImage = func(_ context.Context, _ []string, _ map[string]string) *ImageInspection {
+		lookups++
+		if lookupErr != nil {
+			return &ImageInspection{Err: lookupErr}
+		}
+		return &ImageInspection{Architectures: []string{"amd64"}}
+	}
+
+	expireEntry := func(image string) {
+		imageInspectionsMutex.Lock()
+		defer imageInspectionsMutex.Unlock()
+		imageInspections[imageInspectionKey(image)].expiresAt = time.Now()
+	}
+
+	// Failed lookups are cached as well
+	lookupErr = errors.New("registry unreachable")
+	for range 2 {
+		if inspection := GetImageInspection(context.Background(), helper, image, ""); inspection.Err == nil {
+			t.Fatalf("expected lookup error, got %+v", inspection)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("expected the failed lookup to be cached, got %d lookups", lookups)
+	}
+
+	// The registry is queried again once the entry expires
+	lookupErr = nil
+	expireEntry(image)
+	for range 2 {
+		inspection := GetImageInspection(context.Background(), helper, image, "")
+		if inspection.Err != nil || !slices.Equal(inspection.Architectures, []string{"amd64"}) {
+			t.Fatalf("unexpected inspection %+v", inspection)
+		}
+	}
+	if lookups != 2 {
+		t.Errorf("expected the lookup to be retried once expired, got %d lookups", lookups)
+	}
+
+	// A new image is looked up
+	GetImageInspection(context.Background(), helper, image+"-updated", "")
+	if lookups != 3 {
+		t.Errorf("expected a lookup of the new image, got %d lookups", lookups)
+	}
+}
+
+func TestGetImageSources(t *testing.T) {
+	newMirrorSet := func(gvk schema.GroupVersionKind, field string, source string, mirrors ...interface{}) client.Object {
+		mirrorSet := &uns.Unstructured{}
+		mirrorSet.SetGroupVersionKind(gvk)
+		mirrorSet.SetName(source)
+		_ = uns.SetNestedSlice(mirrorSet.Object, []interface{}{
+			map[string]interface{}{"source": source, "mirrors": mirrors},
+		}, "spec", field)
+		return mirrorSet
+	}
+
+	objs := []client.Object{
+		newMirrorSet(testImageDigestMirrorSetGVK, "imageDigestMirrors",
+			"quay.io/openstack-lightspeed", "registry.local/ols", "backup.local/ols"),
+		newMirrorSet(testImageTagMirrorSetGVK, "imageTagMirrors",
+			"quay.io/openstack-lightspeed/rag-content", "registry.local/rag-content"),
+	}
+
+	tests := []struct {
+		name            string
+		image           string
+		expectedSources []string
+	}{
+		{
+			name:  "Digest mirrored by an ImageDigestMirrorSet",
+			image: "quay.io/openstack-lightspeed/rag-content@sha256:0123",
+			expectedSources: []string{
+				"registry.local/ols/rag-content@sha256:0123",
+				"backup.local/ols/rag-content@sha256:0123",
+				"quay.io/openstack-lightspeed/rag-content@sha256:0123",
+			},
+		},
+		{
+			name:  "Tag mirrored by an ImageTagMirrorSet",
+			image: "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2",
+			expectedSources: []string{
+				"registry.local/rag-content:os-docs-2025.2",
+				"quay.io/openstack-lightspeed/rag-content:os-docs-2025.2",
+			},
+		},
+		{
+			name:            "Image without mirror",
+			image:           "quay.io/openstack-lightspeed-other/rag-content@sha256:0123",
+			expectedSources: []string{"quay.io/openstack-lightspeed-other/rag-content@sha256:0123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			// ImageContentSourcePolicy is not registered in the test scheme, like in a cluster that
+			// no longer serves the API.
+			cl := newTestClient(t, append(objs, instance)...)
+			helper := newTestHelper(t, cl, instance)
+
+			sources, err := GetImageSources(context.Background(), helper, tt.image)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(sources, tt.expectedSources) {
+				t.Errorf("GetImageSources(%s) = %v, want %v", tt.image, sources, tt.expectedSources)
+			}
+		})
+	}
+}
+
+func TestGetRegistryAuths(t *testing.T) {
+	newPullSecret := func(dockerConfig string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal-registry", Namespace: OLSOperatorNamespace},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(dockerConfig)},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		pullSecret    string
+		secret        *corev1.Secret
+		expectedAuths map[string]string
+		shouldError   bool
+	}{
+		{
+			name:          "No pull secret",
+			expectedAuths: map[string]string{},
+		},
+		{
+			name:          "Pull secret not created yet",
+			pullSecret:    "internal-registry",
+			expectedAuths: map[string]string{},
+		},
+		{
+			name:       "Auth and username entries",
+			pullSecret: "internal-registry",
+			secret: newPullSecret(`{"auths": {
+				"registry.local:5000": {"auth": "dXNlcjpzZWNyZXQ="},
+				"https://index.docker.io/v1/": {"username": "user", "password": "secret"}
+			}}`),
+			expectedAuths: map[string]string{
+				"registry.local:5000":  "dXNlcjpzZWNyZXQ=",
+				"registry-1.docker.io": "dXNlcjpzZWNyZXQ=",
+			},
+		},
+		{
+			name:        "Malformed pull secret",
+			pullSecret:  "internal-registry",
+			secret:      newPullSecret(`{"auths": [`),
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			objs := []client.Object{instance}
+			if tt.secret != nil {
+				objs = append(objs, tt.secret)
+			}
+			cl := newTestClient(t, objs...)
+			helper := newTestHelper(t, cl, instance)
+
+			auths, err := GetRegistryAuths(context.Background(), helper, tt.pullSecret)
+			if tt.shouldError {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(auths, tt.expectedAuths) {
+				t.Errorf("GetRegistryAuths() = %v, want %v", auths, tt.expectedAuths)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+const (
+	// LLMCredentialsAPITokenKey - key of the LLM credentials secret holding the API token
+	LLMCredentialsAPITokenKey = "apitoken"
+
+	// LLMProviderTypeAzureOpenAI and LLMProviderTypeFake - LLM provider types whose credentials
+	// differ from the API token
+	LLMProviderTypeAzureOpenAI = "azure_openai"
+	LLMProviderTypeFake        = "fake_provider"
+)
+
+// LLMCredentialsAzureADKeys - keys of the LLM credentials secret that authenticate to Azure OpenAI
+// through a Microsoft Entra ID service principal instead of an API token
+var LLMCredentialsAzureADKeys = []string{"client_id", "tenant_id", "client_secret"}
+
+// LLMCredentials describes the credentials secret of an LLM provider
+type LLMCredentials struct {
+	// Provider is the name of the LLM provider in the OLSConfig
+	Provider string
+	// Type is the type of the LLM provider
+	Type string
+	// SecretName is the name of the credentials secret in the OLS namespace
+	SecretName string
+}
+
+// GetLLMCredentials returns the credentials secrets of the LLM providers configured in the spec
+func GetLLMCredentials(instance *apiv1beta1.OpenStackLightspeed) []LLMCredentials {
+	credentials := []LLMCredentials{}
+	if instance.Spec.ModelName != "" {
+		credentials = append(credentials, LLMCredentials{
+			Provider:   OpenStackLightspeedDefaultProvider,
+			Type:       instance.Spec.LLMEndpointType,
+			SecretName: instance.Spec.LLMCredentials,
+		})
+	}
+
+	for _, provider := range instance.Spec.Providers {
+		credentials = append(credentials, LLMCredentials{
+			Provider:   provider.Name,
+			Type:       provider.Type,
+			SecretName: provider.CredentialsSecret,
+		})
+	}
+
+	return credentials
+}
+
+// GetLLMCredentialsMissingKeys returns the keys the LLM provider type expects in the credentials
+// secret that are missing from it. Azure OpenAI accepts either an API token or the keys of a
+// service principal, the API token is reported as missing when neither is complete. The fake
+// provider does not need any credentials.
+func GetLLMCredentialsMissingKeys(secret *corev1.Secret, providerType string) []string {
+	hasKey := func(key string) bool {
+		return len(secret.Data[key]) > 0
+	}
+
+	switch {
+	case providerType == LLMProviderTypeFake:
+		return nil
+	case hasKey(LLMCredentialsAPITokenKey):
+		return nil
+	case providerType == LLMProviderTypeAzureOpenAI && !slices.ContainsFunc(LLMCredentialsAzureADKeys,
+		func(key string) bool { return !hasKey(key) }):
+		return nil
+	}
+
+	return []string{LLMCredentialsAPITokenKey}
+}
+
+// GetOLSSecret returns the secret with the given name from the OLS namespace, or nil when it does
+// not exist
+func GetOLSSecret(ctx context.Context, helper *common_helper.Helper, name string) (*corev1.Secret, error) {
+	// Use raw client as the OLS namespace might not be among the watched namespaces
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	err = rawClient.Get(ctx, client.ObjectKey{Name: name, Namespace: OLSOperatorNamespace}, secret)
+	if err != nil && k8s_errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+func TestGetLLMCredentialsMissingKeys(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerType string
+		data         map[string][]byte
+		expected     []string
+	}{
+		{
+			name:         "API token set",
+			providerType: "openai",
+			data:         map[string][]byte{"apitoken": []byte("token")},
+		},
+		{
+			name:         "API token missing",
+			providerType: "watsonx",
+			data:         map[string][]byte{"token": []byte("token")},
+			expected:     []string{"apitoken"},
+		},
+		{
+			name:         "API token empty",
+			providerType: "rhoai_vllm",
+			data:         map[string][]byte{"apitoken": {}},
+			expected:     []string{"apitoken"},
+		},
+		{
+			name:         "Azure OpenAI with API token",
+			providerType: "azure_openai",
+			data:         map[string][]byte{"apitoken": []byte("token")},
+		},
+		{
+			name:         "Azure OpenAI with service principal",
+			providerType: "azure_openai",
+			data: map[string][]byte{
+				"client_id":     []byte("id"),
+				"tenant_id":     []byte("tenant"),
+				"client_secret": []byte("secret"),
+			},
+		},
+		{
+			name:         "Azure OpenAI with incomplete service principal",
+			providerType: "azure_openai",
+			data: map[string][]byte{
+				"client_id": []byte("id"),
+				"tenant_id": []byte("tenant"),
+			},
+			expected: []string{"apitoken"},
+		},
+		{
+			name:         "Fake provider without credentials",
+			providerType: "fake_provider",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{Data: tt.data}
+			if missing := GetLLMCredentialsMissingKeys(secret, tt.providerType); !slices.Equal(missing, tt.expected) {
+				t.Errorf("GetLLMCredentialsMissingKeys() = %v, want %v", missing, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetLLMCredentials(t *testing.T) {
+	instance := newTestInstance()
+	instance.Spec.Providers = []apiv1beta1.ProviderSpec{
+		{Name: "fallback", Type: "azure_openai", CredentialsSecret: "fallback-credentials"},
+	}
+
+	expected := []LLMCredentials{
+		{Provider: OpenStackLightspeedDefaultProvider, Type: "openai", SecretName: "llm-credentials"},
+		{Provider: "fallback", Type: "azure_openai", SecretName: "fallback-credentials"},
+	}
+	if credentials := GetLLMCredentials(instance); !slices.Equal(credentials, expected) {
+		t.Errorf("GetLLMCredentials() = %v, want %v", credentials, expected)
+	}
+
+	// The shorthand provider is not rendered without a model
+	instance.Spec.ModelName = ""
+	if credentials := GetLLMCredentials(instance); !slices.Equal(credentials, expected[1:]) {
+		t.Errorf("GetLLMCredentials() = %v, want %v", credentials, expected[1:])
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+// ListManagedResources returns references to the resources the operator created for instance: the
+// OLS operator Subscription and CSV owned by the instance, the OLSConfig labeled with the instance
+// UID, and the ConfigMaps of the OLS namespace that are owned by or labeled with the instance UID. Support can
+// use it to confirm what the operator created and that the deletion cleaned everything up.
+func ListManagedResources(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) ([]corev1.ObjectReference, error) {
+	var resources []corev1.ObjectReference
+
+	subscription := &operatorsv1alpha1.Subscription{}
+	err := helper.GetClient().Get(ctx, client.ObjectKey{
+		Name:      GetOLSSubscriptionName(instance),
+		Namespace: instance.Namespace,
+	}, subscription)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return nil, err
+	} else if err == nil && IsOwnedBy(subscription, instance) {
+		resources = append(resources, newManagedResourceReference("Subscription", subscription))
+	}
+
+	csv, err := GetOLSOperatorCSV(ctx, helper)
+	if err != nil {
+		return nil, err
+	} else if csv != nil && IsOwnedBy(csv, instance) {
+		resources = append(resources, newManagedResourceReference("ClusterServiceVersion", csv))
+	}
+
+	olsConfig, err := GetOLSConfig(ctx, helper)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return nil, err
+	} else if err == nil && isManagedByInstance(&olsConfig, instance) {
+		resources = append(resources, newManagedResourceReference("OLSConfig", &olsConfig))
+	}
+
+	// The operator only creates ConfigMaps in the OLS namespace, where the ConfigMaps are cached
+	var configMaps corev1.ConfigMapList
+	err = helper.GetClient().List(ctx, &configMaps, client.InNamespace(OLSOperatorNamespace))
+	if err != nil {
+		return nil, err
+	}
+	for _, configMap := range configMaps.Items {
+		if isManagedByInstance(&configMap, instance) {
+			resources = append(resources, newManagedResourceReference("ConfigMap", &configMap))
+		}
+	}
+
+	return resources, nil
+}
+
+// isManagedByInstance returns whether obj is owned by instance or labeled with its UID
+func isManagedByInstance(obj client.Object, instance *apiv1beta1.OpenStackLightspeed) bool {
+	return IsOwnedBy(obj, instance) ||
+		obj.GetLabels()[OpenStackLightspeedOwnerIDLabel] == string(instance.GetUID())
+}
+
+// newManagedResourceReference returns a reference to the managed resource obj of the given kind
+func newManagedResourceReference(kind string, obj client.Object) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		UID:       obj.GetUID(),
+	}
+}
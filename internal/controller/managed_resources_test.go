@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestListManagedResources(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+
+	ownedConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "owned-config",
+			Namespace:       testOLSNamespace,
+			OwnerReferences: newTestOwnerReferences(instance),
+		},
+	}
+	labeledConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "labeled-config",
+			Namespace: testOLSNamespace,
+			Labels:    map[string]string{OpenStackLightspeedOwnerIDLabel: string(instance.GetUID())},
+		},
+	}
+	foreignConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foreign-config",
+			Namespace: testOLSNamespace,
+			Labels:    map[string]string{OpenStackLightspeedOwnerIDLabel: "other-uid"},
+		},
+	}
+
+	objs := []client.Object{instance, newTestOLSConfig(instance, true), ownedConfigMap, labeledConfigMap, foreignConfigMap}
+	cl := newTestClient(t, append(objs, newTestOLSOperatorObjects(instance)...)...)
+	helper := newTestHelper(t, cl, instance)
+
+	resources, err := ListManagedResources(context.Background(), helper, instance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, resource := range resources {
+		names = append(names, resource.Kind+"/"+resource.Namespace+"/"+resource.Name)
+	}
+
+	expected := []string{
+		"Subscription/" + testInstanceNamespace + "/" + GetOLSSubscriptionName(instance),
+		"ClusterServiceVersion/" + testOLSNamespace + "/" + testOLSCSVName,
+		"OLSConfig//" + OLSConfigName,
+		"ConfigMap/" + testOLSNamespace + "/labeled-config",
+		"ConfigMap/" + testOLSNamespace + "/owned-config",
+	}
+	if !slices.Equal(names, expected) {
+		t.Errorf("ListManagedResources() = %v, want %v", names, expected)
+	}
+}
+
+func TestReconcileManagedResourceCount(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+	cl := newTestClient(t, append(newTestOLSOperatorObjects(instance), instance)...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	_, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	// Subscription, CSV and the OLSConfig created by the reconcile
+	if instance.Status.ManagedResourceCount != 3 {
+		t.Errorf("ManagedResourceCount = %d, want 3", instance.Status.ManagedResourceCount)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// ReconcileStaleThreshold - Time without a successful reconcile after which the instance is
+	// reported as stalled through the ReconcileProgressCondition
+	ReconcileStaleThreshold = 30 * time.Minute
+
+	// lastSuccessfulReconcileRefreshInterval - Minimum time between two updates of the last
+	// successful reconcile in the status. Every status update triggers a new reconcile.
+	lastSuccessfulReconcileRefreshInterval = time.Minute
+)
+
+// lastSuccessfulReconciles exposes the age of the last successful reconcile of every instance
+var lastSuccessfulReconciles = newReconcileAgeCollector()
+
+func init() {
+	metrics.Registry.MustRegister(lastSuccessfulReconciles)
+}
+
+// reconcileAgeCollector is a Prometheus collector reporting the time since the last successful
+// reconcile of the instances. The age is computed when the metrics are scraped so that it keeps
+// growing while the controller is wedged.
+type reconcileAgeCollector struct {
+	desc *prometheus.Desc
+
+	mu    sync.Mutex
+	times map[types.NamespacedName]time.Time
+}
+
+func newReconcileAgeCollector() *reconcileAgeCollector {
+	return &reconcileAgeCollector{
+		desc: prometheus.NewDesc(
+			"openstack_lightspeed_last_successful_reconcile_age_seconds",
+			"Seconds since the last successful reconcile of the OpenStackLightspeed instance",
+			[]string{"namespace", "name"},
+			nil,
+		),
+		times: map[types.NamespacedName]time.Time{},
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *reconcileAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector
+func (c *reconcileAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for instance, lastSuccess := range c.times {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue,
+			time.Since(lastSuccess).Seconds(), instance.Namespace, instance.Name)
+	}
+}
+
+// Record stores the time of the last successful reconcile of the instance
+func (c *reconcileAgeCollector) Record(instance types.NamespacedName, lastSuccess time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.times[instance] = lastSuccess
+}
+
+// Get returns the time of the last successful reconcile of the instance, if any
+func (c *reconcileAgeCollector) Get(instance types.NamespacedName) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lastSuccess, found := c.times[instance]
+	return lastSuccess, found
+}
+
+// Forget stops reporting the instance, e.g. once it is deleted
+func (c *reconcileAgeCollector) Forget(instance types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.times, instance)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"k8s.io/apimachinery/pkg/api/meta"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// InferenceServiceDefaultModelAnnotation - annotation that marks the InferenceService preferred by
+	// the model discovery when several InferenceServices serve a model
+	InferenceServiceDefaultModelAnnotation = "openstack.org/lightspeed-default-model"
+
+	// ModelDiscoveryRetryInterval - Time after which the model discovery is retried when no model was
+	// found. InferenceServices are not watched.
+	ModelDiscoveryRetryInterval = 30 * time.Second
+)
+
+// DiscoveredModel is a model served by an InferenceService found by the model discovery
+type DiscoveredModel struct {
+	// Name of the model
+	Name string
+
+	// Endpoint of the OpenAI compatible API serving the model
+	Endpoint string
+
+	// InferenceService serving the model
+	InferenceService types.NamespacedName
+}
+
+// DiscoverDefaultModel looks for a ready InferenceService (RHOAI / KServe) serving a model. When
+// modelName is set only the InferenceService of that name is considered. An InferenceService
+// annotated with InferenceServiceDefaultModelAnnotation=true is preferred, otherwise the first one
+// by namespace and name is selected. Returns nil when no model was found, including on clusters
+// without KServe.
+func DiscoverDefaultModel(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	modelName string,
+) (*DiscoveredModel, error) {
+	// Use raw client as the InferenceServices live outside of the watched namespaces
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return nil, err
+	}
+
+	inferenceServices := &uns.UnstructuredList{}
+	inferenceServices.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "serving.kserve.io",
+		Version: "v1beta1",
+		Kind:    "InferenceServiceList",
+	})
+
+	err = rawClient.List(ctx, inferenceServices)
+	if err != nil && meta.IsNoMatchError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var candidates []uns.Unstructured
+	for _, inferenceService := range inferenceServices.Items {
+		if modelName != "" && inferenceService.GetName() != modelName {
+			continue
+		}
+
+		url, _, _ := uns.NestedString(inferenceService.Object, "status", "url")
+		if url != "" && isInferenceServiceReady(&inferenceService) {
+			candidates = append(candidates, inferenceService)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	slices.SortFunc(candidates, func(a, b uns.Unstructured) int {
+		if c := strings.Compare(a.GetNamespace(), b.GetNamespace()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	selected := candidates[0]
+	for _, candidate := range candidates {
+		if candidate.GetAnnotations()[InferenceServiceDefaultModelAnnotation] == "true" {
+			selected = candidate
+			break
+		}
+	}
+
+	// KServe serves the model under the InferenceService name on an OpenAI compatible API
+	url, _, _ := uns.NestedString(selected.Object, "status", "url")
+	return &DiscoveredModel{
+		Name:     selected.GetName(),
+		Endpoint: strings.TrimSuffix(url, "/") + "/v1",
+		InferenceService: types.NamespacedName{
+			Namespace: selected.GetNamespace(),
+			Name:      selected.GetName(),
+		},
+	}, nil
+}
+
+// isInferenceServiceReady returns whether the InferenceService reports the Ready condition
+func isInferenceServiceReady(inferenceService *uns.Unstructured) bool {
+	return isStatusConditionTrue(inferenceService, "Ready")
+}
+
+// isStatusConditionTrue returns whether the object reports the status condition of the given type
+// as True
+func isStatusConditionTrue(obj *uns.Unstructured, conditionType string) bool {
+	conditions, _, _ := uns.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		objCondition, ok := c.(map[string]interface{})
+		if ok && objCondition["type"] == conditionType && objCondition["status"] == "True" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+// newTestInferenceService returns an InferenceService serving a model at url. A ready
+// InferenceService reports the Ready condition.
+func newTestInferenceService(namespace, name, url string, ready, isDefault bool) *uns.Unstructured {
+	inferenceService := &uns.Unstructured{}
+	inferenceService.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "serving.kserve.io",
+		Version: "v1beta1",
+		Kind:    "InferenceService",
+	})
+	inferenceService.SetNamespace(namespace)
+	inferenceService.SetName(name)
+	if isDefault {
+		inferenceService.SetAnnotations(map[string]string{InferenceServiceDefaultModelAnnotation: "true"})
+	}
+
+	status := "False"
+	if ready {
+		status = "True"
+	}
+	_ = uns.SetNestedField(inferenceService.Object, url, "status", "url")
+	_ = uns.SetNestedSlice(inferenceService.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": status},
+	}, "status", "conditions")
+
+	return inferenceService
+}
+
+func TestDiscoverDefaultModel(t *testing.T) {
+	granite := newTestInferenceService("models", "granite", "https://granite.models.example.com", true, false)
+	llama := newTestInferenceService("ai", "llama", "https://llama.ai.example.com/", true, false)
+	mistral := newTestInferenceService("models", "mistral", "https://mistral.models.example.com", true, true)
+	starting := newTestInferenceService("ai", "starting", "https://starting.ai.example.com", false, true)
+
+	tests := []struct {
+		name             string
+		objs             []client.Object
+		modelName        string
+		expectedModel    string
+		expectedEndpoint string
+	}{
+		{
+			name: "No InferenceService",
+		},
+		{
+			name: "InferenceService not ready",
+			objs: []client.Object{starting},
+		},
+		{
+			name:             "First InferenceService by namespace and name",
+			objs:             []client.Object{granite, llama, starting},
+			expectedModel:    "llama",
+			expectedEndpoint: "https://llama.ai.example.com/v1",
+		},
+		{
+			name:             "Annotated InferenceService preferred",
+			objs:             []client.Object{granite, llama, mistral, starting},
+			expectedModel:    "mistral",
+			expectedEndpoint: "https://mistral.models.example.com/v1",
+		},
+		{
+			name:             "InferenceService of the model name",
+			objs:             []client.Object{granite, llama, mistral},
+			modelName:        "granite",
+			expectedModel:    "granite",
+			expectedEndpoint: "https://granite.models.example.com/v1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			cl := newTestClient(t, append(tt.objs, instance)...)
+			helper := newTestHelper(t, cl, instance)
+
+			model, err := DiscoverDefaultModel(context.Background(), helper, tt.modelName)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.expectedModel == "" {
+				if model != nil {
+					t.Errorf("expected no model, got %+v", model)
+				}
+				return
+			}
+
+			if model == nil || model.Name != tt.expectedModel || model.Endpoint != tt.expectedEndpoint {
+				t.Errorf("DiscoverDefaultModel() = %+v, want model %s at %s", model, tt.expectedModel, tt.expectedEndpoint)
+			}
+		})
+	}
+}
+
+func TestReconcileAutoDiscoverModel(t *testing.T) {
+	tests := []struct {
+		name              string
+		autoDiscoverModel bool
+		objs              []client.Object
+		expectedStatus    corev1.ConditionStatus
+	}{
+		{
+			name:              "Model discovered",
+			autoDiscoverModel: true,
+			objs: []client.Object{
+				newTestInferenceService("models", "granite", "https://granite.models.example.com", true, false),
+			},
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name:              "No model found",
+			autoDiscoverModel: true,
+			expectedStatus:    corev1.ConditionFalse,
+		},
+		{
+			name: "Model discovery disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.AutoDiscoverModel = tt.autoDiscoverModel
+			instance.Spec.ModelName = ""
+			instance.Spec.LLMEndpoint = ""
+
+			objs := append(newTestOLSOperatorObjects(instance), instance)
+			cl := newTestClient(t, append(objs, tt.objs...)...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.ModelDiscoveryCondition)
+			if tt.expectedStatus == "" {
+				if cond != nil {
+					t.Errorf("expected no ModelDiscoveryCondition, got %+v", cond)
+				}
+				ready := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+				if ready == nil || ready.Status != corev1.ConditionFalse {
+					t.Errorf("expected the spec without a model to be rejected, got %+v", ready)
+				}
+			} else if cond == nil || cond.Status != tt.expectedStatus {
+				t.Errorf("expected ModelDiscoveryCondition %s, got %+v", tt.expectedStatus, cond)
+			}
+
+			olsConfig, err := getTestOLSConfig(t, cl)
+			if tt.expectedStatus != corev1.ConditionTrue {
+				if !k8s_errors.IsNotFound(err) {
+					t.Errorf("expected no OLSConfig without a model, got %v", err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("failed to get OLSConfig: %v", err)
+			}
+
+			if model, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultModel"); model != "granite" {
+				t.Errorf("defaultModel = %q, want the discovered granite", model)
+			}
+			providers, _, _ := uns.NestedSlice(olsConfig.Object, "spec", "llm", "providers")
+			if len(providers) == 0 || providers[0].(map[string]interface{})["url"] != "https://granite.models.example.com/v1" {
+				t.Errorf("expected the provider URL to be the discovered endpoint, got %v", providers)
+			}
+		})
+	}
+}
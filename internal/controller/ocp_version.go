@@ -19,14 +19,11 @@ package controller
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"slices"
 	"strings"
 
 	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
-	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openstack-lightspeed/operator/internal/ocpversion"
 )
 
 const (
@@ -35,16 +32,8 @@ const (
 
 	// OpenStackLightspeedOCPIndexPrefix - prefix for OCP index names
 	OpenStackLightspeedOCPIndexPrefix = "ocp-product-docs"
-
-	// Supported OCP versions in the RAG database
-	OCPVersion416    = "4.16"
-	OCPVersion418    = "4.18"
-	OCPVersionLatest = "latest"
 )
 
-// SupportedOCPVersions lists the OCP versions available in the RAG database
-var SupportedOCPVersions = []string{OCPVersion416, OCPVersion418, OCPVersionLatest}
-
 // DetectOCPVersion detects the OpenShift cluster version
 func DetectOCPVersion(ctx context.Context, helper *common_helper.Helper) (string, error) {
 	// Use raw client to access cluster-scoped resources
@@ -53,53 +42,19 @@ func DetectOCPVersion(ctx context.Context, helper *common_helper.Helper) (string
 		return "", fmt.Errorf("failed to get raw client: %w", err)
 	}
 
-	// Get ClusterVersion object
-	clusterVersion := &uns.Unstructured{}
-	clusterVersion.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "config.openshift.io",
-		Version: "v1",
-		Kind:    "ClusterVersion",
-	})
-
-	err = rawClient.Get(ctx, client.ObjectKey{Name: "version"}, clusterVersion)
-	if err != nil {
-		return "", fmt.Errorf("failed to get ClusterVersion: %w", err)
-	}
-
-	// Extract version from status.desired.version
-	// NOTE: We intentionally use desired.version rather than history[0].version because:
-	// - During OCP upgrades, desired.version reflects the target version
-	// - Users troubleshooting upgrade issues need docs for the NEW version
-	// - This provides proactive access to relevant documentation
-	version, found, err := uns.NestedString(clusterVersion.Object, "status", "desired", "version")
-	if err != nil {
-		return "", fmt.Errorf("failed to extract version from ClusterVersion: %w", err)
-	}
-	if !found {
-		return "", fmt.Errorf("version field not found in ClusterVersion status.desired.version")
-	}
-
-	// Parse version to get major.minor (e.g., "4.15.0" -> "4.15")
-	majorMinor, err := ParseMajorMinorVersion(version)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse version %s: %w", version, err)
-	}
-
-	return majorMinor, nil
+	return ocpversion.GetClusterVersion(ctx, rawClient)
 }
 
-// ParseMajorMinorVersion extracts major.minor version from full version string
-// Example: "4.15.0-0.nightly-2024-01-15-123456" -> "4.15"
-func ParseMajorMinorVersion(fullVersion string) (string, error) {
-	// Match major.minor pattern at the start
-	re := regexp.MustCompile(`^(\d+\.\d+)`)
-	matches := re.FindStringSubmatch(fullVersion)
-
-	if len(matches) < 2 {
-		return "", fmt.Errorf("invalid version format: %s", fullVersion)
+// DetectOCPFullVersion detects the full OpenShift cluster version, e.g. "4.16.3" or
+// "4.18.0-0.nightly-2024-01-15-123456"
+func DetectOCPFullVersion(ctx context.Context, helper *common_helper.Helper) (string, error) {
+	// Use raw client to access cluster-scoped resources
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return "", fmt.Errorf("failed to get raw client: %w", err)
 	}
 
-	return matches[1], nil
+	return ocpversion.GetClusterFullVersion(ctx, rawClient)
 }
 
 // GetOCPIndexName converts version to index name format
@@ -119,36 +74,3 @@ func GetOCPIndexName(version string) string {
 func GetOCPVectorDBPath(version string) string {
 	return fmt.Sprintf("%s_%s", OpenStackLightspeedOCPVectorDBPath, version)
 }
-
-// IsSupportedOCPVersion checks if the version is explicitly supported in RAG DB
-func IsSupportedOCPVersion(version string) bool {
-	return slices.Contains(SupportedOCPVersions, version)
-}
-
-// ResolveOCPVersion determines the OCP version to use for RAG configuration
-// Returns (version, isFallback, error)
-// - version: The version to use (might be "latest" as fallback)
-// - isFallback: true if falling back to "latest" for unsupported version
-// - error: any error during version resolution
-func ResolveOCPVersion(detectedVersion, overrideVersion string, enableOCPRAG bool) (string, bool, error) {
-	if !enableOCPRAG {
-		return "", false, nil
-	}
-
-	// Use override if provided
-	if overrideVersion != "" {
-		return overrideVersion, false, nil
-	}
-
-	if detectedVersion == "" {
-		return "", false, fmt.Errorf("no OCP version detected")
-	}
-
-	// Check if detected version is supported
-	if IsSupportedOCPVersion(detectedVersion) {
-		return detectedVersion, false, nil
-	}
-
-	// Fallback to latest for unsupported versions
-	return OCPVersionLatest, true, nil
-}
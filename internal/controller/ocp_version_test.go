@@ -93,146 +93,6 @@ func TestGetOCPVectorDBPath(t *testing.T) {
 	}
 }
 
-func TestParseMajorMinorVersion(t *testing.T) {
-	tests := []struct {
-		name        string
-		fullVersion string
-		expected    string
-		shouldError bool
-	}{
-		{
-			name:        "Standard version",
-			fullVersion: "4.16.0",
-			expected:    "4.16",
-			shouldError: false,
-		},
-		{
-			name:        "Version with build",
-			fullVersion: "4.18.0-0.nightly-2024-01-15-123456",
-			expected:    "4.18",
-			shouldError: false,
-		},
-		{
-			name:        "Invalid version",
-			fullVersion: "invalid",
-			expected:    "",
-			shouldError: true,
-		},
-		{
-			name:        "Empty version",
-			fullVersion: "",
-			expected:    "",
-			shouldError: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := ParseMajorMinorVersion(tt.fullVersion)
-			if tt.shouldError {
-				if err == nil {
-					t.Errorf("ParseMajorMinorVersion(%s) expected error, got nil", tt.fullVersion)
-				}
-			} else {
-				if err != nil {
-					t.Errorf("ParseMajorMinorVersion(%s) unexpected error: %v", tt.fullVersion, err)
-				}
-				if result != tt.expected {
-					t.Errorf("ParseMajorMinorVersion(%s) = %s, want %s", tt.fullVersion, result, tt.expected)
-				}
-			}
-		})
-	}
-}
-
-func TestResolveOCPVersion(t *testing.T) {
-	tests := []struct {
-		name             string
-		detected         string
-		override         string
-		enableOCPRAG     bool
-		expectedVer      string
-		expectedFallback bool
-		shouldError      bool
-	}{
-		{
-			name:             "OCP RAG disabled",
-			detected:         "4.16",
-			override:         "",
-			enableOCPRAG:     false,
-			expectedVer:      "",
-			expectedFallback: false,
-			shouldError:      false,
-		},
-		{
-			name:             "Supported version detected",
-			detected:         "4.16",
-			override:         "",
-			enableOCPRAG:     true,
-			expectedVer:      "4.16",
-			expectedFallback: false,
-			shouldError:      false,
-		},
-		{
-			name:             "Unsupported version - fallback",
-			detected:         "4.17",
-			override:         "",
-			enableOCPRAG:     true,
-			expectedVer:      "latest",
-			expectedFallback: true,
-			shouldError:      false,
-		},
-		{
-			name:             "Version override",
-			detected:         "4.18",
-			override:         "4.16",
-			enableOCPRAG:     true,
-			expectedVer:      "4.16",
-			expectedFallback: false,
-			shouldError:      false,
-		},
-		{
-			name:             "Custom override (any version allowed)",
-			detected:         "4.16",
-			override:         "4.99",
-			enableOCPRAG:     true,
-			expectedVer:      "4.99",
-			expectedFallback: false,
-			shouldError:      false,
-		},
-		{
-			name:             "No version detected",
-			detected:         "",
-			override:         "",
-			enableOCPRAG:     true,
-			expectedVer:      "",
-			expectedFallback: false,
-			shouldError:      true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			version, isFallback, err := ResolveOCPVersion(tt.detected, tt.override, tt.enableOCPRAG)
-			if tt.shouldError {
-				if err == nil {
-					t.Errorf("ResolveOCPVersion expected error, got nil")
-				}
-			} else {
-				if err != nil {
-					t.Errorf("ResolveOCPVersion unexpected error: %v", err)
-				}
-				if version != tt.expectedVer {
-					t.Errorf("ResolveOCPVersion version = %s, want %s", version, tt.expectedVer)
-				}
-				if isFallback != tt.expectedFallback {
-					t.Errorf("ResolveOCPVersion isFallback = %v, want %v", isFallback, tt.expectedFallback)
-				}
-			}
-		})
-	}
-}
-
 func TestBuildRAGConfigs(t *testing.T) {
 	t.Run("OCP RAG disabled (empty version)", func(t *testing.T) {
 		instance := &apiv1beta1.OpenStackLightspeed{
@@ -367,46 +227,3 @@ func TestBuildRAGConfigs(t *testing.T) {
 		}
 	})
 }
-
-func TestIsSupportedOCPVersion(t *testing.T) {
-	tests := []struct {
-		name     string
-		version  string
-		expected bool
-	}{
-		{
-			name:     "Supported version 4.16",
-			version:  "4.16",
-			expected: true,
-		},
-		{
-			name:     "Supported version 4.18",
-			version:  "4.18",
-			expected: true,
-		},
-		{
-			name:     "Supported version latest",
-			version:  "latest",
-			expected: true,
-		},
-		{
-			name:     "Unsupported version 4.17",
-			version:  "4.17",
-			expected: false,
-		},
-		{
-			name:     "Unsupported version 4.19",
-			version:  "4.19",
-			expected: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := IsSupportedOCPVersion(tt.version)
-			if result != tt.expected {
-				t.Errorf("IsSupportedOCPVersion(%s) = %v, want %v", tt.version, result, tt.expected)
-			}
-		})
-	}
-}
@@ -24,14 +24,18 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -39,8 +43,36 @@ import (
 const (
 	// OLSOperatorName - Name of the OpenShift Lightspeed operator.
 	OLSOperatorName = "lightspeed-operator"
+
+	// OLSOperatorNamespace - Namespace of the OpenShift Lightspeed operator. The RBAC rules only
+	// allow us to modify the OLS operator CSV in this namespace.
+	OLSOperatorNamespace = "openshift-lightspeed"
+
+	// OLSOperatorChannel - Subscription channel the OLS operator is installed from when the instance
+	// does not set one
+	OLSOperatorChannel = "stable"
+
+	// OLSOperatorCSVReplacementTimeout - Time after which an instance-owned OLS operator CSV that
+	// is still in the Replacing or Pending phase is considered stuck.
+	OLSOperatorCSVReplacementTimeout = 15 * time.Minute
+
+	// OLSExternallyManagedAnnotation - Annotation other operators set to "true" on the OLS operator
+	// CSV they manage, so that we leave its lifecycle to them.
+	OLSExternallyManagedAnnotation = "openstack.org/ols-externally-managed"
 )
 
+// ErrOLSOperatorCSVForbidden is returned when the OLS operator CSV cannot be updated because it
+// lives in a namespace where we do not have write access.
+var ErrOLSOperatorCSVForbidden = errors.New("OpenShift Lightspeed operator CSV update is forbidden")
+
+// ErrOLSOperatorDowngrade is returned when the installed OLS operator is newer than the recommended
+// version. OLM does not downgrade operators.
+var ErrOLSOperatorDowngrade = errors.New("OpenShift Lightspeed operator downgrade is not supported")
+
+// ErrOLSInstallPlanFailed is returned when OLM failed to execute the InstallPlan of the OLS operator.
+// OLM does not retry a failed InstallPlan.
+var ErrOLSInstallPlanFailed = errors.New("OpenShift Lightspeed operator InstallPlan failed")
+
 // EnsureOLSOperatorInstalled ensures that a compatible OLS Operator is present in the cluster.
 // If the operator already exists, this checks that it matches the required version (otherwise it fails).
 // If it is missing, this attempts to install the correct version.
@@ -72,8 +104,10 @@ func EnsureOLSOperatorInstalled(
 // InstallInstanceOwnedOLSOperator - ensures that the OpenShift Lightspeed Operator (OLS Operator)
 // is installed and owned by the specified OpenStackLightspeed instance. This function:
 //  1. Determines the recommended OLS Operator version.
-//  2. Creates or updates a Subscription, setting the instance as its owner.
-//  3. Approves the related InstallPlan manually.
+//  2. Creates or updates a Subscription, setting the instance as its owner. The Subscription is
+//     only created once the configured CatalogSource is READY.
+//  3. Approves the related InstallPlan manually, including the upgrade InstallPlan when the
+//     recommended version is newer than the installed one. Downgrades are refused.
 //  4. Sets ownership of the generated ClusterServiceVersion (CSV) to the instance.
 //  5. Returns true if the OLS Operator is installed and owned by the instance, or an error otherwise.
 func InstallInstanceOwnedOLSOperator(
@@ -88,6 +122,17 @@ func InstallInstanceOwnedOLSOperator(
 		},
 	}
 
+	// A Subscription to a missing or unreachable catalog does not resolve and OLM only reports it
+	// in the Subscription conditions, check the catalog before creating it
+	err := helper.GetClient().Get(ctx, client.ObjectKeyFromObject(subscription), subscription)
+	if err != nil && k8s_errors.IsNotFound(err) {
+		if err := CheckOLSCatalogSourceReady(ctx, helper, instance); err != nil {
+			return false, err
+		}
+	} else if err != nil {
+		return false, err
+	}
+
 	instanceOwnerReference := []metav1.OwnerReference{
 		{
 			APIVersion:         instance.APIVersion,
@@ -100,7 +145,7 @@ func InstallInstanceOwnedOLSOperator(
 	}
 	opResult, err := controllerutil.CreateOrUpdate(ctx, helper.GetClient(), subscription, func() error {
 		subscription.Spec = &operatorsv1alpha1.SubscriptionSpec{
-			Channel:                "stable",
+			Channel:                GetOLSSubscriptionChannel(instance),
 			InstallPlanApproval:    operatorsv1alpha1.ApprovalManual,
 			CatalogSource:          instance.Spec.CatalogSourceName,
 			CatalogSourceNamespace: instance.Spec.CatalogSourceNamespace,
@@ -120,6 +165,11 @@ func InstallInstanceOwnedOLSOperator(
 		return false, err
 	}
 
+	isUpgrading, err := IsOLSOperatorUpgrading(subscription)
+	if err != nil {
+		return false, err
+	}
+
 	// If the Subscription was just created, or if it doesn't yet contain an InstallPlanRef,
 	// return (false, nil) -> wait. Attempting to approve the InstallPlan before it is properly
 	// linked can cause OLM to create unnecessary additional InstallPlans.
@@ -127,6 +177,14 @@ func InstallInstanceOwnedOLSOperator(
 		return false, nil
 	}
 
+	// OLM may still be replacing the InstallPlan the Subscription points to. Approving a plan of an
+	// outdated generation leaves the current one pending, so wait for the reference to settle.
+	if instance.Spec.InstallPlanApprovalStrategy == apiv1beta1.InstallPlanApprovalWaitForStableRef {
+		if !IsOLSInstallPlanRefStable(helper, instance, subscription) {
+			return false, nil
+		}
+	}
+
 	// Because we've set the subscription to require manual approval, we need to explicitly
 	// approve the InstallPlan at this point. Manual approval is used to prevent OLM from
 	// automatically upgrading the operator to a newer version than we've tested. This way,
@@ -138,20 +196,33 @@ func InstallInstanceOwnedOLSOperator(
 		return false, nil
 	}
 
-	// Ensure the CSV is owned by this instance. This helps determine during
-	// deletion if the OLS Operator was installed by us or pre-existed before
-	// the instance.
-	OLSOperatorCSV, err := GetOLSOperatorCSV(ctx, helper)
-	if err != nil {
-		return false, err
-	} else if OLSOperatorCSV == nil {
+	// The installed CSV keeps running until OLM replaced it with the recommended version
+	if isUpgrading {
+		helper.GetLogger().Info("Waiting for the OpenShift Lightspeed operator upgrade")
 		return false, nil
 	}
 
-	OLSOperatorCSV.SetOwnerReferences(instanceOwnerReference)
-	err = helper.GetClient().Update(ctx, OLSOperatorCSV)
-	if err != nil && k8s_errors.IsConflict(err) {
+	// Ensure the CSV is owned by this instance. This helps determine during
+	// deletion if the OLS Operator was installed by us or pre-existed before
+	// the instance. OLM updates the CSV status frequently, so re-read the CSV
+	// and retry on conflicts instead of waiting for the next reconcile.
+	var OLSOperatorCSV *operatorsv1alpha1.ClusterServiceVersion
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		OLSOperatorCSV, err = GetOLSOperatorCSV(ctx, helper)
+		if err != nil || OLSOperatorCSV == nil {
+			return err
+		}
+
+		OLSOperatorCSV.SetOwnerReferences(instanceOwnerReference)
+		return helper.GetClient().Update(ctx, OLSOperatorCSV)
+	})
+	if err == nil && OLSOperatorCSV == nil {
 		return false, nil
+	} else if err != nil && k8s_errors.IsConflict(err) {
+		return false, nil
+	} else if err != nil && k8s_errors.IsForbidden(err) {
+		return false, fmt.Errorf("%w: CSV %s in namespace %s",
+			ErrOLSOperatorCSVForbidden, OLSOperatorCSV.GetName(), OLSOperatorCSV.GetNamespace())
 	} else if err != nil {
 		return false, err
 	}
@@ -159,6 +230,40 @@ func InstallInstanceOwnedOLSOperator(
 	return InstanceOwnedOLSOperatorComplete(ctx, helper, instance)
 }
 
+// IsOLSOperatorUpgrading returns whether the OLS operator installed through the given Subscription
+// is older than the recommended version, i.e. the InstallPlan of the recommended version is an
+// upgrade. The installed version is read from the installedCSV of the Subscription status, the CSV
+// OLM installed for it. An installed OLS operator newer than the recommended version returns
+// ErrOLSOperatorDowngrade. Versions that cannot be compared, e.g. when the latest version is
+// recommended or nothing is installed yet, are not an upgrade.
+func IsOLSOperatorUpgrading(subscription *operatorsv1alpha1.Subscription) (bool, error) {
+	recommendedVersion, err := GetRecommendedOLSVersion()
+	if err != nil || recommendedVersion == "" {
+		return false, err
+	}
+
+	if subscription.Status.InstalledCSV == "" {
+		return false, nil
+	}
+
+	installedVersion := strings.TrimPrefix(subscription.Status.InstalledCSV, OLSOperatorName+".v")
+	installedSemVer, err := version.ParseSemantic(installedVersion)
+	if err != nil {
+		return false, nil
+	}
+	recommendedSemVer, err := version.ParseSemantic(recommendedVersion)
+	if err != nil {
+		return false, nil
+	}
+
+	if recommendedSemVer.LessThan(installedSemVer) {
+		return false, fmt.Errorf("%w: installed version %s is newer than the recommended version %s",
+			ErrOLSOperatorDowngrade, installedVersion, recommendedVersion)
+	}
+
+	return installedSemVer.LessThan(recommendedSemVer), nil
+}
+
 // InstanceOwnedOLSOperatorComplete checks if the OLS Operator's CSV is owned
 // by the given OpenStackLightspeed instance and is in the Succeeded phase.
 func InstanceOwnedOLSOperatorComplete(
@@ -178,6 +283,66 @@ func InstanceOwnedOLSOperatorComplete(
 	return IsOwnedBy(OLSOperatorCSV, instance) && OLSOperatorCSV.Status.Phase == operatorsv1alpha1.CSVPhaseSucceeded, nil
 }
 
+// GetStuckOLSOperatorCSV returns the OLS operator CSV owned by the given instance that has been in
+// the Replacing or Pending phase for longer than OLSOperatorCSVReplacementTimeout. A misconfigured
+// CSV can loop in these phases during an upgrade and InstanceOwnedOLSOperatorComplete would wait
+// for it forever. CSVs not owned by the instance are never reported. Returns (nil, nil) when no
+// CSV is stuck.
+func GetStuckOLSOperatorCSV(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (*operatorsv1alpha1.ClusterServiceVersion, error) {
+	// Use raw client to list the CSVs from all namespaces, see GetOLSOperatorCSV
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return nil, err
+	}
+
+	var CSVs operatorsv1alpha1.ClusterServiceVersionList
+	if err := rawClient.List(ctx, &CSVs, client.InNamespace("")); err != nil {
+		return nil, err
+	}
+
+	for _, CSV := range CSVs.Items {
+		if !strings.HasPrefix(CSV.GetName(), OLSOperatorName) || !IsOwnedBy(&CSV, instance) {
+			continue
+		}
+
+		phase := CSV.Status.Phase
+		if phase != operatorsv1alpha1.CSVPhaseReplacing && phase != operatorsv1alpha1.CSVPhasePending {
+			continue
+		}
+
+		lastTransition := CSV.Status.LastTransitionTime
+		if lastTransition != nil && time.Since(lastTransition.Time) > OLSOperatorCSVReplacementTimeout {
+			return &CSV, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// DeleteStuckOLSOperatorCSV deletes the stuck OLS operator CSV so that OLM retries the upgrade
+// from the Subscription. Only CSVs owned by the given instance are deleted.
+func DeleteStuckOLSOperatorCSV(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+	CSV *operatorsv1alpha1.ClusterServiceVersion,
+) error {
+	if !IsOwnedBy(CSV, instance) {
+		return fmt.Errorf("refusing to delete CSV %s not owned by %s", CSV.GetName(), instance.GetName())
+	}
+
+	err := helper.GetClient().Delete(ctx, CSV)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
 // GetRecommendedOLSVersion returns the recommended version of the OpenShift
 // Lightspeed (OLS) operator to deploy. This version is obtained from the environment
 // variable "OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION". If the variable is unset or empty,
@@ -228,6 +393,38 @@ func GetOLSOperatorCSV(
 	return nil, nil
 }
 
+// IsOLSOperatorExternallyManaged returns whether the OLS operator lifecycle is left to someone else,
+// either because the instance asks for the External lifecycle mode or because the installed OLS
+// operator CSV is annotated as externally managed. The Subscription, InstallPlan and CSV are never
+// touched for an externally managed OLS operator.
+func IsOLSOperatorExternallyManaged(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (bool, error) {
+	if instance.Spec.OLSLifecycleMode == apiv1beta1.OLSLifecycleModeExternal {
+		return true, nil
+	}
+
+	OLSOperatorCSV, err := GetOLSOperatorCSV(ctx, helper)
+	if err != nil || OLSOperatorCSV == nil {
+		return false, err
+	}
+
+	return OLSOperatorCSV.GetAnnotations()[OLSExternallyManagedAnnotation] == "true", nil
+}
+
+// IsExternalOLSOperatorReady returns whether the externally managed OLS operator was installed
+// successfully.
+func IsExternalOLSOperatorReady(ctx context.Context, helper *common_helper.Helper) (bool, error) {
+	OLSOperatorCSV, err := GetOLSOperatorCSV(ctx, helper)
+	if err != nil || OLSOperatorCSV == nil {
+		return false, err
+	}
+
+	return OLSOperatorCSV.Status.Phase == operatorsv1alpha1.CSVPhaseSucceeded, nil
+}
+
 // IsUserInstalledOLSOperatorMode checks if an OpenShift Lightspeed Operator
 // (OLS Operator) is installed in the cluster (by the user), but was NOT installed/owned by
 // this specific OpenStackLightspeed instance. Returns true only if there is an OLS OperatorIsOwnedBy
@@ -352,10 +549,72 @@ func GetOLSOperatorInstallPlan(
 	return nil, nil
 }
 
+// IsOLSInstallPlanRefStable returns whether subscription references the same InstallPlan as in the
+// previous reconcile, as recorded in the ObservedInstallPlanRef of the instance status. A changed
+// reference means OLM is regenerating the InstallPlan and its approval should be deferred to a
+// later reconcile. The current reference is recorded for the next reconcile.
+func IsOLSInstallPlanRefStable(
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+	subscription *operatorsv1alpha1.Subscription,
+) bool {
+	previousRef := instance.Status.ObservedInstallPlanRef
+	instance.Status.ObservedInstallPlanRef = subscription.Status.InstallPlanRef.Name
+
+	if previousRef != instance.Status.ObservedInstallPlanRef {
+		helper.GetLogger().Info("Deferring the InstallPlan approval, the Subscription InstallPlan reference changed",
+			"subscription", subscription.Name, "installPlan", instance.Status.ObservedInstallPlanRef)
+		return false
+	}
+
+	return true
+}
+
+// olsSubscriptionFailures lists the Subscription conditions that report why OLM cannot install the
+// OLS operator, the most relevant first. InstallPlanPending is expected as the InstallPlans are
+// approved manually.
+var olsSubscriptionFailures = []operatorsv1alpha1.SubscriptionConditionType{
+	operatorsv1alpha1.SubscriptionCatalogSourcesUnhealthy,
+	operatorsv1alpha1.SubscriptionResolutionFailed,
+	operatorsv1alpha1.SubscriptionBundleUnpackFailed,
+	operatorsv1alpha1.SubscriptionInstallPlanFailed,
+	operatorsv1alpha1.SubscriptionInstallPlanMissing,
+}
+
+// GetOLSSubscriptionFailure returns the most relevant failure the OLS operator Subscription of the
+// instance reports in its status conditions. Returns nil when the Subscription does not exist or
+// reports no failure.
+func GetOLSSubscriptionFailure(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (*operatorsv1alpha1.SubscriptionCondition, error) {
+	subscription := &operatorsv1alpha1.Subscription{}
+	err := helper.GetClient().Get(ctx, client.ObjectKey{
+		Name:      GetOLSSubscriptionName(instance),
+		Namespace: instance.Namespace,
+	}, subscription)
+	if err != nil && k8s_errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, conditionType := range olsSubscriptionFailures {
+		subscriptionCondition := subscription.Status.GetCondition(conditionType)
+		if subscriptionCondition.Status == corev1.ConditionTrue {
+			return &subscriptionCondition, nil
+		}
+	}
+
+	return nil, nil
+}
+
 // ApproveOLSOperatorInstallPlan approves the InstallPlan that is responsible for installing
 // the OpenShift Lightspeed Operator (OLS Operator) in the given OpenStackLightspeed instance's
 // namespace. It sets the Approved field to true and updates the InstallPlan resource in the cluster.
-// Returns true if the approval succeeds, false and an error otherwise.
+// Returns true if the approval succeeds, false and an error otherwise. A failed InstallPlan returns
+// ErrOLSInstallPlanFailed.
 func ApproveOLSOperatorInstallPlan(
 	ctx context.Context,
 	helper *common_helper.Helper,
@@ -368,6 +627,11 @@ func ApproveOLSOperatorInstallPlan(
 		return false, nil
 	}
 
+	// Approving the failed InstallPlan again does not make OLM retry it
+	if failure := GetOLSInstallPlanFailure(installPlan); failure != "" {
+		return false, fmt.Errorf("%w: %s: %s", ErrOLSInstallPlanFailed, installPlan.GetName(), failure)
+	}
+
 	installPlan.Spec.Approved = true
 	err = helper.GetClient().Update(ctx, installPlan)
 	if err != nil {
@@ -377,6 +641,28 @@ func ApproveOLSOperatorInstallPlan(
 	return true, nil
 }
 
+// GetOLSInstallPlanFailure returns why OLM failed to execute the InstallPlan, or an empty string when
+// the InstallPlan is not in the Failed phase. The status message is preferred over the message of
+// the Installed condition.
+func GetOLSInstallPlanFailure(installPlan *operatorsv1alpha1.InstallPlan) string {
+	if installPlan.Status.Phase != operatorsv1alpha1.InstallPlanPhaseFailed {
+		return ""
+	}
+
+	if installPlan.Status.Message != "" {
+		return installPlan.Status.Message
+	}
+
+	installed := installPlan.Status.GetCondition(operatorsv1alpha1.InstallPlanInstalled)
+	if installed.Message != "" {
+		return installed.Message
+	} else if installed.Reason != "" {
+		return string(installed.Reason)
+	}
+
+	return "no reason reported"
+}
+
 // DeleteOLSOperatorInstallPlan deletes the InstallPlan associated with installing the
 // OpenShift Lightspeed Operator (OLS Operator) in the specified OpenStackLightspeed instance's
 // namespace. If the InstallPlan does not exist, the function returns true. It returns true
@@ -410,6 +696,15 @@ func GetOLSSubscriptionName(instance *apiv1beta1.OpenStackLightspeed) string {
 	return fmt.Sprintf("%s-%s", OLSOperatorName, string(instance.GetUID())[:5])
 }
 
+// GetOLSSubscriptionChannel returns the Subscription channel the OLS operator is installed from
+func GetOLSSubscriptionChannel(instance *apiv1beta1.OpenStackLightspeed) string {
+	if instance.Spec.OLSSubscriptionChannel == "" {
+		return OLSOperatorChannel
+	}
+
+	return instance.Spec.OLSSubscriptionChannel
+}
+
 // SetStartingCSV sets the StartingCSV field of the given Subscription based on
 // the recommended OLS operator version. If the recommended version is "",
 // StartingCSV is not set to allow OLM to select the latest compatible version.
@@ -420,8 +715,14 @@ func SetStartingCSV(subscription *operatorsv1alpha1.Subscription) error {
 	}
 
 	if recommendedVersion != "" {
-		subscription.Spec.StartingCSV = fmt.Sprintf("%s.v%s", OLSOperatorName, recommendedVersion)
+		subscription.Spec.StartingCSV = GetOLSOperatorCSVName(recommendedVersion)
 	}
 
 	return nil
 }
+
+// GetOLSOperatorCSVName returns the name of the CSV of the given OLS operator version
+// Example: "1.0.6" -> "lightspeed-operator.v1.0.6"
+func GetOLSOperatorCSVName(version string) string {
+	return fmt.Sprintf("%s.v%s", OLSOperatorName, version)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+// openStackContextHeader - heading of the system prompt section describing the OpenStack deployment
+const openStackContextHeader = "# OPENSTACK DEPLOYMENT\n" +
+	"The OpenStack deployment the user is asking about is described below. Prefer answers that " +
+	"apply to the services it runs.\n"
+
+// GetOpenStackContext returns the description of the OpenStack deployment referenced by the
+// OpenStackContextRef of the instance. Returns ("", false, nil) when the ConfigMap, or the key within
+// it, does not exist.
+func GetOpenStackContext(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (string, bool, error) {
+	ref := instance.Spec.OpenStackContextRef
+
+	// Use raw client as the cache only holds the ConfigMaps of the OLS namespace. The ConfigMap is
+	// read through the Role of the operator in its own namespace, where the instances live.
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return "", false, err
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err = rawClient.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: instance.Namespace}, configMap)
+	if err != nil && k8s_errors.IsNotFound(err) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	openStackContext, found := configMap.Data[ref.GetKey()]
+	return openStackContext, found, nil
+}
+
+// BuildSystemPrompt returns the OpenStackLightspeed system prompt extended with the description of
+// the OpenStack deployment, if any
+func BuildSystemPrompt(openStackContext string) string {
+	openStackContext = strings.TrimSpace(openStackContext)
+	if openStackContext == "" {
+		return GetSystemPrompt()
+	}
+
+	return strings.TrimRight(GetSystemPrompt(), "\n") + "\n\n" + openStackContextHeader + openStackContext + "\n"
+}
+
+// PatchOLSConfigOpenStackContext renders the description of the OpenStack deployment into the
+// system prompt of the OLSConfig. The plain system prompt is used when openStackContext is empty.
+func PatchOLSConfigOpenStackContext(olsConfig *uns.Unstructured, openStackContext string) error {
+	return uns.SetNestedField(olsConfig.Object, BuildSystemPrompt(openStackContext), "spec", "ols", "querySystemPrompt")
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+const testOpenStackContext = "Services: nova, neutron, cinder, glance, keystone, octavia"
+
+func TestGetOpenStackContext(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "openstack-context", Namespace: testInstanceNamespace},
+		Data:       map[string]string{"context": testOpenStackContext, "services": testOpenStackContext},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "openstack-context", Namespace: testInstanceNamespace},
+		Data:       map[string][]byte{"services": []byte(testOpenStackContext)},
+	}
+
+	tests := []struct {
+		name          string
+		ref           apiv1beta1.OpenStackContextRef
+		objs          []client.Object
+		expectedFound bool
+	}{
+		{
+			name:          "ConfigMap with the default key",
+			ref:           apiv1beta1.OpenStackContextRef{Name: "openstack-context"},
+			objs:          []client.Object{configMap},
+			expectedFound: true,
+		},
+		{
+			name:          "ConfigMap with a custom key",
+			ref:           apiv1beta1.OpenStackContextRef{Name: "openstack-context", Key: "services"},
+			objs:          []client.Object{configMap},
+			expectedFound: true,
+		},
+		{
+			name: "Missing key",
+			ref:  apiv1beta1.OpenStackContextRef{Name: "openstack-context", Key: "release"},
+			objs: []client.Object{configMap},
+		},
+		{
+			// Secrets are never inlined into the system prompt
+			name: "Secret of the same name",
+			ref:  apiv1beta1.OpenStackContextRef{Name: "openstack-context", Key: "services"},
+			objs: []client.Object{secret},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			instance.Spec.OpenStackContextRef = &tt.ref
+
+			cl := newTestClient(t, append(tt.objs, instance)...)
+			helper := newTestHelper(t, cl, instance)
+
+			openStackContext, found, err := GetOpenStackContext(context.Background(), helper, instance)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if found != tt.expectedFound {
+				t.Fatalf("found = %v, want %v", found, tt.expectedFound)
+			}
+			if found && openStackContext != testOpenStackContext {
+				t.Errorf("OpenStack context = %q, want %q", openStackContext, testOpenStackContext)
+			}
+		})
+	}
+}
+
+func TestPatchOLSConfigOpenStackContext(t *testing.T) {
+	t.Run("context set", func(t *testing.T) {
+		olsConfig := &uns.Unstructured{Object: map[string]interface{}{}}
+		if err := PatchOLSConfigOpenStackContext(olsConfig, testOpenStackContext+"\n"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		prompt, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "querySystemPrompt")
+		if !strings.HasPrefix(prompt, strings.TrimRight(GetSystemPrompt(), "\n")) {
+			t.Errorf("expected the system prompt to start with the OpenStackLightspeed system prompt")
+		}
+		if !strings.HasSuffix(prompt, openStackContextHeader+testOpenStackContext+"\n") {
+			t.Errorf("expected the system prompt to end with the OpenStack context, got %q", prompt)
+		}
+	})
+
+	t.Run("context unset", func(t *testing.T) {
+		olsConfig := &uns.Unstructured{Object: map[string]interface{}{}}
+		if err := PatchOLSConfigOpenStackContext(olsConfig, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		prompt, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "querySystemPrompt")
+		if prompt != GetSystemPrompt() {
+			t.Errorf("expected the plain system prompt without OpenStack context")
+		}
+	})
+}
+
+func TestCreateOrPatchOLSConfigOpenStackContext(t *testing.T) {
+	instance := newTestInstance()
+	instance.Spec.OpenStackContextRef = &apiv1beta1.OpenStackContextRef{Name: "openstack-context"}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "openstack-context", Namespace: testInstanceNamespace},
+		Data:       map[string]string{"context": testOpenStackContext},
+	}
+
+	cl := newTestClient(t, instance, configMap)
+	helper := newTestHelper(t, cl, instance)
+
+	if err := CreateOrPatchOLSConfig(context.Background(), helper, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	olsConfig, err := getTestOLSConfig(t, cl)
+	if err != nil {
+		t.Fatalf("failed to get OLSConfig: %v", err)
+	}
+
+	prompt, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "querySystemPrompt")
+	if !strings.Contains(prompt, testOpenStackContext) {
+		t.Errorf("expected the OpenStack context in the system prompt, got %q", prompt)
+	}
+}
@@ -18,27 +18,54 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+	"github.com/openstack-lightspeed/operator/internal/ocpversion"
+)
+
+const (
+	// DefaultOLSConfigPollInterval - Time after which an OLSConfig that is not ready is checked again
+	DefaultOLSConfigPollInterval = 5 * time.Second
+
+	// DefaultInstallPollInterval - Time after which an OLS operator installation or removal in
+	// progress is checked again
+	DefaultInstallPollInterval = 10 * time.Second
+
+	// ForceDeleteAnnotation - annotation that, when set to "true", lets the deletion of an
+	// OpenStackLightspeed instance complete without waiting for the removal of the OLSConfig and of
+	// the OLS operator. Both may be left behind on the cluster.
+	ForceDeleteAnnotation = "openstack.org/lightspeed-force-delete"
 )
 
 // OpenStackLightspeedReconciler reconciles a OpenStackLightspeed object
@@ -46,6 +73,48 @@ type OpenStackLightspeedReconciler struct {
 	client.Client
 	Scheme  *runtime.Scheme
 	Kclient kubernetes.Interface
+
+	// Recorder emits the events of the OpenStackLightspeed instances. Events are not emitted when
+	// unset.
+	Recorder record.EventRecorder
+
+	// MaxConcurrentReconciles is the maximum number of OpenStackLightspeed instances reconciled
+	// in parallel. The controller-runtime default (1) is used when unset.
+	MaxConcurrentReconciles int
+
+	// OLSConfigPollInterval is how often the OLSConfig is polled while waiting for OLS to become
+	// ready. DefaultOLSConfigPollInterval is used when unset.
+	OLSConfigPollInterval time.Duration
+
+	// InstallPollInterval is how often the installation and the removal of the OLS operator are
+	// polled. DefaultInstallPollInterval is used when unset.
+	InstallPollInterval time.Duration
+
+	// controller and cache are used to watch the OLSConfig once its CRD is established, see
+	// watchOLSConfig. Both are set by SetupWithManager.
+	controller controller.Controller
+	cache      cache.Cache
+
+	// olsConfigWatchLock guards olsConfigWatched, which is set once the OLSConfig watch started
+	olsConfigWatchLock sync.Mutex
+	olsConfigWatched   bool
+}
+
+// getOLSConfigPollInterval returns the interval at which the OLSConfig is polled
+func (r *OpenStackLightspeedReconciler) getOLSConfigPollInterval() time.Duration {
+	if r.OLSConfigPollInterval <= 0 {
+		return DefaultOLSConfigPollInterval
+	}
+	return r.OLSConfigPollInterval
+}
+
+// getInstallPollInterval returns the interval at which the installation and the removal of the OLS
+// operator are polled
+func (r *OpenStackLightspeedReconciler) getInstallPollInterval() time.Duration {
+	if r.InstallPollInterval <= 0 {
+		return DefaultInstallPollInterval
+	}
+	return r.InstallPollInterval
 }
 
 // GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
@@ -64,6 +133,19 @@ func (r *OpenStackLightspeedReconciler) GetLogger(ctx context.Context) logr.Logg
 // +kubebuilder:rbac:groups=operators.coreos.com,resources=subscriptions,namespace=openshift-lightspeed,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=operators.coreos.com,resources=installplans,namespace=openshift-lightspeed,verbs=get;list;watch;update;delete
 // +kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=packages.operators.coreos.com,resources=packagemanifests,verbs=get;list
+// +kubebuilder:rbac:groups=operators.coreos.com,resources=catalogsources,verbs=get
+// +kubebuilder:rbac:groups=config.openshift.io,resources=proxies,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,namespace=openshift-lightspeed,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,namespace=openshift-lightspeed,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,namespace=openshift-lightspeed,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices,verbs=get;list
+// +kubebuilder:rbac:groups=config.openshift.io,resources=imagedigestmirrorsets;imagetagmirrorsets,verbs=get;list
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=imagecontentsourcepolicies,verbs=get;list
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,namespace=openshift-lightspeed,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -83,6 +165,7 @@ func (r *OpenStackLightspeedReconciler) Reconcile(ctx context.Context, req ctrl.
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
 			Log.Info("OpenStackLightspeed CR not found")
+			lastSuccessfulReconciles.Forget(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
@@ -103,6 +186,9 @@ func (r *OpenStackLightspeedReconciler) Reconcile(ctx context.Context, req ctrl.
 	// when a condition's state doesn't change.
 	savedConditions := instance.Status.Conditions.DeepCopy()
 
+	// Set once the reconcile reaches its end without waiting for anything
+	reconcileSucceeded := false
+
 	// Always patch the instance status when exiting this function so we can persist any changes.
 	defer func() {
 		// Don't update the status, if reconciler Panics
@@ -111,25 +197,44 @@ func (r *OpenStackLightspeedReconciler) Reconcile(ctx context.Context, req ctrl.
 			panic(r)
 		}
 
+		if instance.DeletionTimestamp.IsZero() {
+			recordReconcileProgress(instance, reconcileSucceeded)
+		}
+
 		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
 		// update the Ready condition based on the sub conditions
 		if instance.Status.Conditions.AllSubConditionIsTrue() {
 			instance.Status.Conditions.MarkTrue(
 				condition.ReadyCondition, condition.ReadyMessage)
 		} else {
-			// something is not ready so reset the Ready condition
-			instance.Status.Conditions.MarkUnknown(
-				condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage)
+			// something is not ready so drop the Ready condition, a reset Ready condition would
+			// compete with the sub-conditions that transitioned within the same second
+			instance.Status.Conditions.Remove(condition.ReadyCondition)
 			// and recalculate it based on the state of the rest of the conditions
 			instance.Status.Conditions.Set(
 				instance.Status.Conditions.Mirror(condition.ReadyCondition))
 		}
 
+		// The mirrored Ready condition carries the message of the most relevant sub-condition
+		if instance.DeletionTimestamp.IsZero() {
+			instance.Status.Message = instance.Status.Conditions.Get(condition.ReadyCondition).Message
+		}
+
+		status := instance.Status.DeepCopy()
 		err := helper.PatchInstance(ctx, instance)
 		if err != nil {
 			return
 		}
 
+		// A metadata patch (e.g. registering the finalizer) refreshes the instance from the API
+		// server response and drops the status changes before they are patched. Patch them again.
+		if !equality.Semantic.DeepEqual(instance.Status, *status) {
+			instance.Status = *status
+			err = r.Status().Patch(ctx, instance, client.MergeFrom(helper.GetBeforeObject()))
+			if err != nil {
+				Log.Error(err, "Status update failed")
+			}
+		}
 	}()
 
 	cl := condition.CreateList(
@@ -144,17 +249,27 @@ func (r *OpenStackLightspeedReconciler) Reconcile(ctx context.Context, req ctrl.
 	instance.Status.ObservedGeneration = instance.Generation
 
 	// OCP Version Detection and Resolution - must be done early so status field is always set
-	r.resolveOCPVersion(ctx, helper, instance)
+	detectCtx, span := startReconcileSpan(ctx, SpanOCPDetect, instance)
+	ocpVersion := r.resolveOCPVersion(detectCtx, helper, instance)
+	span.SetAttributes(attribute.String("ocp.version", ocpVersion))
+	endReconcileSpan(span, nil)
 
 	if !instance.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, helper, instance)
 	}
 
+	// The deferred patch registers the finalizer. Report it so that the first reconcile is visible.
 	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) {
+		instance.Status.Conditions.Set(condition.UnknownCondition(
+			apiv1beta1.OpenStackLightspeedReadyCondition,
+			condition.InitReason,
+			apiv1beta1.OpenStackLightspeedRegisteringFinalizerMessage,
+		))
 		return ctrl.Result{}, nil
 	}
 
-	if instance.Spec.RAGImage == "" {
+	// The RAG image is not used when RAG is disabled
+	if instance.Spec.RAGImage == "" && !instance.Spec.DisableRAG {
 		instance.Spec.RAGImage = apiv1beta1.OpenStackLightspeedDefaultValues.RAGImageURL
 	}
 
@@ -162,10 +277,114 @@ func (r *OpenStackLightspeedReconciler) Reconcile(ctx context.Context, req ctrl.
 		instance.Spec.MaxTokensForResponse = apiv1beta1.OpenStackLightspeedDefaultValues.MaxTokensForResponse
 	}
 
+	// The discovered model is only kept in memory so that it follows the InferenceServices
+	if instance.Spec.AutoDiscoverModel && (instance.Spec.ModelName == "" || instance.Spec.LLMEndpoint == "") {
+		isDiscovered, err := r.discoverModel(ctx, helper, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if !isDiscovered {
+			return ctrl.Result{RequeueAfter: ModelDiscoveryRetryInterval}, nil
+		}
+	}
+
+	// Validate the parts of the spec that the CRD schema cannot validate before touching anything
+	// in the cluster. There is no point in requeueing, a spec update triggers a new reconcile.
+	specPath := field.NewPath("spec")
+	if errs := append(instance.Spec.ValidateModel(specPath), instance.Spec.ValidateSpec(specPath)...); len(errs) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenStackLightspeedReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			apiv1beta1.OpenStackLightspeedInvalidSpecMessage,
+			errs.ToAggregate().Error(),
+		))
+		return ctrl.Result{}, nil
+	}
+
+	// The default RAG image is only accepted when the environment pins it as well
+	if instance.Spec.RequireDigestPinnedImages && instance.Spec.RAGImage != "" && !IsDigestPinned(instance.Spec.RAGImage) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenStackLightspeedReadyCondition,
+			apiv1beta1.ImageNotPinnedReason,
+			condition.SeverityError,
+			apiv1beta1.OpenStackLightspeedImageNotPinnedMessage,
+			instance.Spec.RAGImage,
+		))
+		return ctrl.Result{}, nil
+	}
+
+	// Neither install nor configure OLS before the dependency is ready
+	if instance.Spec.WaitFor != nil {
+		isDependencyReady, err := r.checkDependency(ctx, helper, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if !isDependencyReady {
+			return ctrl.Result{RequeueAfter: DependencyRetryInterval}, nil
+		}
+	}
+
 	// Ensure a compatible version of the OpenShift Lightspeed Operator is running in the cluster.
 	// This checks if the correct OLS Operator version is present and installs it if necessary.
-	isOLSOperatorInstalled, err := EnsureOLSOperatorInstalled(ctx, helper, instance)
-	if err != nil {
+	// When the OLS operator is managed by someone else only wait for it to be installed.
+	installCtx, span := startReconcileSpan(ctx, SpanOperatorInstall, instance)
+	isOLSOperatorExternal, err := IsOLSOperatorExternallyManaged(installCtx, helper, instance)
+	isOLSOperatorInstalled := false
+	if err == nil && isOLSOperatorExternal {
+		isOLSOperatorInstalled, err = IsExternalOLSOperatorReady(installCtx, helper)
+	} else if err == nil {
+		isOLSOperatorInstalled, err = EnsureOLSOperatorInstalled(installCtx, helper, instance)
+	}
+	span.SetAttributes(attribute.Bool("ols.installed", isOLSOperatorInstalled))
+	endReconcileSpan(span, err)
+	if err != nil && errors.Is(err, ErrOLSOperatorCSVForbidden) {
+		// The CSV was found in a namespace our RBAC does not cover. Retrying will not help until
+		// the OLS operator is installed in the expected namespace.
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
+			apiv1beta1.OpenShiftLightspeedOperatorCSVForbiddenReason,
+			condition.SeverityError,
+			apiv1beta1.OpenShiftLightspeedOperatorCSVForbiddenMessage,
+			err.Error(),
+			OLSOperatorNamespace,
+		))
+
+		return ctrl.Result{}, nil
+	} else if err != nil && errors.Is(err, ErrOLSCatalogSourceNotReady) {
+		// OLM cannot install the OLS operator until the catalog is available, e.g. until the
+		// mirrored catalog of a disconnected cluster is created
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
+			apiv1beta1.OpenShiftLightspeedOperatorCatalogSourceNotReadyReason,
+			condition.SeverityWarning,
+			apiv1beta1.OpenShiftLightspeedOperatorCatalogMessage,
+			err.Error(),
+		))
+
+		return ctrl.Result{RequeueAfter: r.getInstallPollInterval()}, nil
+	} else if err != nil && errors.Is(err, ErrOLSInstallPlanFailed) {
+		// OLM does not retry a failed InstallPlan. The InstallPlan and Subscription watches trigger a
+		// new reconcile once the InstallPlan is replaced.
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
+			apiv1beta1.OpenShiftLightspeedOperatorInstallPlanFailedReason,
+			condition.SeverityError,
+			apiv1beta1.OpenShiftLightspeedOperatorInstallPlanFailedMessage,
+			err.Error(),
+		))
+
+		return ctrl.Result{}, nil
+	} else if err != nil && errors.Is(err, ErrOLSOperatorDowngrade) {
+		// OLM never downgrades an operator, the installed OLS operator has to be removed first
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
+			apiv1beta1.OpenShiftLightspeedOperatorDowngradeReason,
+			condition.SeverityError,
+			apiv1beta1.OpenShiftLightspeedOperatorDowngradeMessage,
+			err.Error(),
+		))
+
+		return ctrl.Result{}, nil
+	} else if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
 			condition.ErrorReason,
@@ -175,6 +394,15 @@ func (r *OpenStackLightspeedReconciler) Reconcile(ctx context.Context, req ctrl.
 		))
 
 		return ctrl.Result{}, nil
+	} else if !isOLSOperatorInstalled && isOLSOperatorExternal {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			apiv1beta1.OpenShiftLightspeedOperatorExternalWaiting,
+		))
+
+		return ctrl.Result{RequeueAfter: r.getInstallPollInterval()}, nil
 	} else if !isOLSOperatorInstalled {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
@@ -183,52 +411,159 @@ func (r *OpenStackLightspeedReconciler) Reconcile(ctx context.Context, req ctrl.
 			apiv1beta1.OpenShiftLightspeedOperatorWaiting,
 		))
 
+		// OLM reports why it cannot install the OLS operator in the Subscription, and a broken
+		// catalog entry of the OLS package stalls the installation. Report them instead of
+		// waiting silently.
+		if err := r.checkOLSSubscriptionHealth(ctx, helper, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.checkOLSPackageInCatalog(ctx, helper, instance)
+
+		// An upgrade can leave the CSV looping in the Replacing or Pending phase, report it
+		// instead of waiting silently.
+		if err := r.checkStuckOLSOperatorCSV(ctx, helper, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+
 		// In this branch we know that the
-		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+		return ctrl.Result{Requeue: true, RequeueAfter: r.getInstallPollInterval()}, nil
 	}
 
 	// Mark the OpenShift Lightspeed Operator as ready in the status conditions.
-	instance.Status.Conditions.MarkTrue(
-		apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
-		apiv1beta1.OpenShiftLightspeedOperatorReady,
-	)
+	if isOLSOperatorExternal {
+		instance.Status.Conditions.MarkTrue(
+			apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
+			apiv1beta1.OpenShiftLightspeedOperatorExternalReady,
+		)
+	} else {
+		instance.Status.Conditions.MarkTrue(
+			apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
+			apiv1beta1.OpenShiftLightspeedOperatorReady,
+		)
+	}
 
-	// NOTE: We cannot consume the OLSConfig definition directly from the OLS operator's code due to
-	// a conflict in Go versions. When this comment was written, the min. required Go version for
-	// openstack-operator was 1.21 whereas OLS operator required at least Go version 1.23. Once the
-	// Go versions catch up with each other we should consider consuming OLSConfig directly from OLS
-	// operator and updating this code and any subsequent code that consumes this structure.
-	olsConfig := uns.Unstructured{}
-	olsConfigGVK := schema.GroupVersionKind{
-		Group:   "ols.openshift.io",
-		Version: "v1alpha1",
-		Kind:    "OLSConfig",
-	}
-
-	olsConfig.SetGroupVersionKind(olsConfigGVK)
-	olsConfig.SetName(OLSConfigName)
-
-	_, err = controllerutil.CreateOrPatch(ctx, r.Client, &olsConfig, func() error {
-		// Check if the OpenStackLightspeed instance that is being processed owns the OLSConfig. If
-		// it is owned by other OpenStackLightspeed instance stop the reconciliation.
-		olsConfigLabels := olsConfig.GetLabels()
-		ownerLabel := ""
-		if val, ok := olsConfigLabels[OpenStackLightspeedOwnerIDLabel]; ok {
-			ownerLabel = val
-		}
+	err = r.checkRAGImageCompatibility(ctx, helper, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 
-		if ownerLabel != "" && ownerLabel != string(instance.GetObjectMeta().GetUID()) {
-			return fmt.Errorf("OLSConfig is managed by different OpenStackLightspeed instance")
-		}
+	err = r.checkOLSConfigAPIVersion(ctx, helper, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 
-		err = PatchOLSConfig(helper, instance, &olsConfig)
-		if err != nil {
-			return err
+	// The OLSConfig CRD is registered by the OLS operator. Give the API server time to establish it
+	// before the first OLSConfig request.
+	isOLSConfigCRDEstablished, err := IsOLSConfigCRDEstablished(ctx, helper)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if !isOLSConfigCRDEstablished {
+		Log.Info("OLSConfig CRD is not established yet. Waiting...")
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenStackLightspeedReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			apiv1beta1.OpenStackLightspeedWaitingOLSConfigCRDMessage,
+		))
+		return ctrl.Result{RequeueAfter: r.getOLSConfigPollInterval()}, nil
+	}
+
+	r.checkRAGImageArchitecture(ctx, helper, instance)
+	r.updateRAGBuildInfo(ctx, helper, instance)
+
+	err = r.watchOLSConfig(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The model differs from the one we wrote last time, CreateOrPatchOLSConfig below switches OLS to
+	// the new model
+	if instance.Status.CurrentModel != "" && instance.Status.CurrentModel != instance.Spec.ModelName {
+		Log.Info("Model changed, refreshing OLSConfig",
+			"previousModel", instance.Status.CurrentModel, "model", instance.Spec.ModelName)
+
+		if err := r.checkConversationCacheRefresh(ctx, helper, instance); err != nil {
+			return ctrl.Result{}, err
 		}
+	}
 
-		return nil
-	})
+	missingGate, err := r.checkOLSGates(ctx, helper, instance)
 	if err != nil {
+		return ctrl.Result{}, err
+	} else if missingGate != nil {
+		// Secrets of the OLS namespace are watched, other objects are polled
+		if _, isSecret := missingGate.obj.(*corev1.Secret); isSecret {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{RequeueAfter: time.Second * time.Duration(10)}, nil
+	}
+
+	patchCtx, span := startReconcileSpan(ctx, SpanOLSConfigPatch, instance)
+	err = CreateOrPatchOLSConfig(patchCtx, helper, instance)
+	endReconcileSpan(span, err)
+	if err != nil && errors.Is(err, ErrOLSConfigWriteConflict) {
+		// Another instance wrote the OLSConfig in the meantime. Retry against the current OLSConfig,
+		// which also re-evaluates who owns it.
+		Log.Info("OLSConfig was modified concurrently. Retrying...")
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenStackLightspeedReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			apiv1beta1.OpenStackLightspeedOLSConfigWriteConflictMessage,
+		))
+		return ctrl.Result{RequeueAfter: time.Second * time.Duration(1)}, nil
+	} else if err != nil && errors.Is(err, ErrOLSConfigOwnershipConflict) {
+		// There is no point in requeueing, NotifyOLSConfigOwner triggers a new reconcile when the
+		// OLSConfig changes hands.
+		Log.Info("OLSConfig is managed by another instance", "error", err.Error())
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenStackLightspeedReadyCondition,
+			apiv1beta1.OLSConfigConflictingReason,
+			condition.SeverityError,
+			apiv1beta1.OpenStackLightspeedOLSConfigConflictingMessage,
+			err.Error()))
+		return ctrl.Result{}, nil
+	} else if err != nil && k8s_errors.IsInvalid(err) {
+		// The OLSConfig CRD schema rejected a field we rendered from the spec. There is no point in
+		// requeueing, a spec update triggers a new reconcile.
+		Log.Info("OLSConfig was rejected by the OLSConfig CRD validation", "error", err.Error())
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenStackLightspeedReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			apiv1beta1.OpenStackLightspeedOLSConfigRejectedMessage,
+			GetOLSConfigValidationFailures(err)))
+		return ctrl.Result{}, nil
+	} else if err != nil && errors.Is(err, ErrOLSConfigFieldsPruned) {
+		// The OLSConfig was written, but without fields we rendered from the spec. There is no point
+		// in requeueing, a spec update triggers a new reconcile.
+		Log.Info("OLSConfig fields were pruned by the API server", "error", err.Error())
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenStackLightspeedReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			apiv1beta1.OpenStackLightspeedOLSConfigPrunedMessage,
+			err.Error()))
+		return ctrl.Result{}, nil
+	} else if err != nil && errors.Is(err, ErrOLSConfigNoRAGSources) {
+		// There is no point in requeueing, a spec update triggers a new reconcile.
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenStackLightspeedReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			apiv1beta1.OpenStackLightspeedNoRAGSourcesMessage,
+			err.Error()))
+		return ctrl.Result{}, nil
+	} else if err != nil && errors.Is(err, ErrOLSConfigInvalidDefaults) {
+		// There is no point in requeueing, a spec update triggers a new reconcile.
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenStackLightspeedReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			apiv1beta1.OpenStackLightspeedInvalidOLSConfigDefaultsMessage,
+			err.Error()))
+		return ctrl.Result{}, nil
+	} else if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			apiv1beta1.OpenStackLightspeedReadyCondition,
 			condition.ErrorReason,
@@ -238,7 +573,39 @@ func (r *OpenStackLightspeedReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, err
 	}
 
-	OLSConfigReady, err := IsOLSConfigReady(ctx, helper)
+	instance.Status.CurrentModel = instance.Spec.ModelName
+
+	r.countManagedResources(ctx, helper, instance)
+
+	err = r.checkRAGImagePull(ctx, helper, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	startFallback, err := r.checkRAGlessFallback(ctx, helper, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if startFallback {
+		// Patch the OLSConfig without the RAG sources right away
+		return ctrl.Result{RequeueAfter: time.Second * time.Duration(1)}, nil
+	}
+
+	requiredOLSConditions := r.checkConsoleAvailable(ctx, helper, instance)
+
+	readinessCtx, span := startReconcileSpan(ctx, SpanReadinessCheck, instance)
+	OLSConfigReady, err := IsOLSConfigReady(readinessCtx, helper, requiredOLSConditions)
+	span.SetAttributes(attribute.Bool("olsconfig.ready", OLSConfigReady))
+	endReconcileSpan(span, err)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	olsConfigSynced, err := r.checkOLSConfigObservedGeneration(ctx, helper, instance, savedConditions)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.checkUsageBudget(ctx, helper, instance)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -249,15 +616,79 @@ func (r *OpenStackLightspeedReconciler) Reconcile(ctx context.Context, req ctrl.
 			apiv1beta1.OpenStackLightspeedReadyMessage,
 		)
 		Log.Info("OLSConfig is ready!")
+
+		// Keep polling until OLS catches up with our last patch to detect it falling behind
+		if !olsConfigSynced {
+			return ctrl.Result{RequeueAfter: r.getOLSConfigPollInterval()}, nil
+		}
 	} else {
-		Log.Info("OLSConfig is not ready yet. Waiting...")
-		return ctrl.Result{RequeueAfter: time.Second * time.Duration(5)}, nil
+		waitingVectorDB, err := r.isWaitingForVectorDB(ctx, helper, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if waitingVectorDB {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				apiv1beta1.OpenStackLightspeedReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				apiv1beta1.OpenStackLightspeedWaitingVectorDBMessage,
+			))
+			Log.Info("OLSConfig is not ready yet. Waiting for the vector DB pod...")
+		} else {
+			Log.Info("OLSConfig is not ready yet. Waiting...")
+		}
+		return ctrl.Result{RequeueAfter: r.getOLSConfigPollInterval()}, nil
 	}
 
 	Log.Info("OpenStackLightspeed Reconciled successfully")
+	reconcileSucceeded = true
 	return ctrl.Result{}, nil
 }
 
+// recordReconcileProgress records the time of the last successful reconcile and reports through the
+// ReconcileProgressCondition whether the instance was successfully reconciled within the
+// ReconcileStaleThreshold. A reconcile that keeps waiting or failing is reported as stalled.
+func recordReconcileProgress(instance *apiv1beta1.OpenStackLightspeed, succeeded bool) {
+	now := time.Now()
+
+	if succeeded {
+		lastSuccessfulReconciles.Record(client.ObjectKeyFromObject(instance), now)
+
+		lastSuccess := instance.Status.LastSuccessfulReconcileTime
+		if lastSuccess == nil || now.Sub(lastSuccess.Time) >= lastSuccessfulReconcileRefreshInterval {
+			instance.Status.LastSuccessfulReconcileTime = &metav1.Time{Time: now}
+		}
+
+		instance.Status.Conditions.MarkTrue(
+			apiv1beta1.ReconcileProgressCondition,
+			apiv1beta1.ReconcileProgressingMessage,
+		)
+		return
+	}
+
+	// An instance that was never reconciled successfully is stalled since its creation
+	lastSuccess := instance.CreationTimestamp
+	if instance.Status.LastSuccessfulReconcileTime != nil {
+		lastSuccess = *instance.Status.LastSuccessfulReconcileTime
+	}
+
+	if now.Sub(lastSuccess.Time) > ReconcileStaleThreshold {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.ReconcileProgressCondition,
+			apiv1beta1.ReconcileStalledReason,
+			condition.SeverityWarning,
+			apiv1beta1.ReconcileStalledMessage,
+			lastSuccess.UTC().Format(time.RFC3339),
+		))
+	} else if instance.Status.LastSuccessfulReconcileTime != nil {
+		instance.Status.Conditions.MarkTrue(
+			apiv1beta1.ReconcileProgressCondition,
+			apiv1beta1.ReconcileProgressingMessage,
+		)
+	}
+}
+
 // resolveOCPVersion detects and resolves the OCP version to use for RAG configuration.
 // Returns the active OCP version to use (or empty string if OCP RAG is disabled).
 func (r *OpenStackLightspeedReconciler) resolveOCPVersion(
@@ -278,7 +709,22 @@ func (r *OpenStackLightspeedReconciler) resolveOCPVersion(
 	}
 
 	// Step 1: Detect cluster version
-	detectedVersion, err := DetectOCPVersion(ctx, helper)
+	detectedVersion := ""
+	fullVersion, err := DetectOCPFullVersion(ctx, helper)
+	if err == nil {
+		detectedVersion, err = ocpversion.ParseMajorMinor(fullVersion)
+	}
+
+	// A pre-GA build might report a version that cannot be parsed. It is handled as an unsupported
+	// version rather than disabling OCP RAG.
+	var invalidVersionErr *ocpversion.InvalidVersionError
+	versionUnparsable := errors.As(err, &invalidVersionErr)
+	if versionUnparsable {
+		Log.Info("Failed to parse OCP version, handling it as unsupported",
+			"rawVersion", invalidVersionErr.Version)
+		detectedVersion = invalidVersionErr.Version
+		err = nil
+	}
 
 	if err != nil {
 		Log.Info("Failed to detect OCP version, disabling OCP RAG", "error", err)
@@ -294,10 +740,24 @@ func (r *OpenStackLightspeedReconciler) resolveOCPVersion(
 		return ""
 	}
 
-	Log.Info("Detected OCP cluster version", "version", detectedVersion)
+	Log.Info("Detected OCP cluster version", "version", detectedVersion, "fullVersion", fullVersion)
+
+	// The documentation of a pre-release build rarely matches any published version. The override
+	// states explicitly which documentation to use.
+	if instance.Spec.OCPRAGSkipPreRelease && instance.Spec.OCPRAGVersionOverride == "" &&
+		ocpversion.IsPreRelease(fullVersion) {
+		Log.Info("Cluster runs a pre-release OCP build, disabling OCP RAG", "fullVersion", fullVersion)
+		instance.Status.Conditions.MarkTrue(
+			apiv1beta1.OCPRAGCondition,
+			apiv1beta1.OCPRAGPreReleaseSkippedMessage,
+			fullVersion,
+		)
+		instance.Status.ActiveOCPRAGVersion = ""
+		return ""
+	}
 
 	// Step 2: Resolve which version to use (with override and fallback)
-	activeVersion, isFallback, err := ResolveOCPVersion(
+	activeVersion, isFallback, err := ocpversion.Resolve(
 		detectedVersion,
 		instance.Spec.OCPRAGVersionOverride,
 		instance.Spec.EnableOCPRAG,
@@ -319,59 +779,971 @@ func (r *OpenStackLightspeedReconciler) resolveOCPVersion(
 	}
 
 	// Step 3: Update status and conditions based on resolution
+	if isFallback && instance.Spec.OCPRAGFallbackBehavior == apiv1beta1.OCPRAGFallbackBehaviorReject {
+		// The admission webhook rejects this configuration, but the cluster might have been upgraded
+		// to an unsupported version after the instance was created.
+		Log.Info("Cluster version is not supported by the OCP documentation, disabling OCP RAG",
+			"detectedVersion", detectedVersion,
+			"supportedVersions", ocpversion.Supported)
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OCPRAGCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			apiv1beta1.OCPRAGVersionUnsupportedMessage,
+			detectedVersion,
+			ocpversion.Supported,
+		))
+		instance.Status.ActiveOCPRAGVersion = ""
+		return ""
+	}
+
 	instance.Status.ActiveOCPRAGVersion = activeVersion
 
 	if isFallback {
 		Log.Info("Using 'latest' OCP documentation as fallback",
 			"detectedVersion", detectedVersion,
-			"supportedVersions", SupportedOCPVersions)
+			"supportedVersions", ocpversion.Supported)
 
-		cond := condition.TrueCondition(
-			apiv1beta1.OCPRAGCondition,
-			"Fallback",
-		)
-		cond.Message = fmt.Sprintf(apiv1beta1.OCPRAGVersionFallbackMessage,
-			detectedVersion, SupportedOCPVersions)
-		instance.Status.Conditions.Set(cond)
+		if versionUnparsable {
+			instance.Status.Conditions.MarkTrue(
+				apiv1beta1.OCPRAGCondition,
+				apiv1beta1.OCPRAGVersionUnparsableMessage,
+				detectedVersion,
+			)
+		} else {
+			instance.Status.Conditions.MarkTrue(
+				apiv1beta1.OCPRAGCondition,
+				apiv1beta1.OCPRAGVersionFallbackMessage,
+				detectedVersion,
+				ocpversion.Supported,
+			)
+		}
 	} else {
 		Log.Info("Using OCP RAG documentation", "version", activeVersion)
-		cond := condition.TrueCondition(
+		instance.Status.Conditions.MarkTrue(
 			apiv1beta1.OCPRAGCondition,
-			"Resolved",
+			apiv1beta1.OCPRAGVersionResolvedMessage,
+			activeVersion,
 		)
-		cond.Message = fmt.Sprintf(apiv1beta1.OCPRAGVersionResolvedMessage, activeVersion)
-		instance.Status.Conditions.Set(cond)
 	}
 
 	return activeVersion
 }
 
-// reconcileDelete reconciles the deletion of OpenStackLightspeed instance
-func (r *OpenStackLightspeedReconciler) reconcileDelete(
+// checkRAGImageCompatibility compares the RAG image with the version of the installed OLS operator
+// and reports the result through the RAGImageCompatibilityCondition. An incompatible pairing is
+// reported as a warning, OLS is still configured with the requested RAG image.
+func (r *OpenStackLightspeedReconciler) checkRAGImageCompatibility(
 	ctx context.Context,
 	helper *common_helper.Helper,
 	instance *apiv1beta1.OpenStackLightspeed,
-) (ctrl.Result, error) {
+) error {
 	Log := r.GetLogger(ctx)
-	Log.Info("OpenStackLightspeed Reconciling Delete")
 
-	isRemoved, err := RemoveOLSConfig(ctx, helper, instance)
+	// There is no RAG image to check when RAG is disabled
+	if instance.Spec.RAGImage == "" {
+		return nil
+	}
+
+	OLSOperatorCSV, err := GetOLSOperatorCSV(ctx, helper)
 	if err != nil {
-		return ctrl.Result{}, err
-	} else if !isRemoved {
-		Log.Info("OLSConfig removal in progress ...")
-		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		return err
+	} else if OLSOperatorCSV == nil {
+		return nil
 	}
 
-	isUninstalled, err := UninstallInstanceOwnedOLSOperator(ctx, helper, instance)
+	olsVersion := GetOLSOperatorVersion(OLSOperatorCSV)
+	isCompatible, rule, err := CheckRAGImageCompatibility(instance.Spec.RAGImage, olsVersion)
 	if err != nil {
-		return ctrl.Result{}, err
-	} else if !isUninstalled {
-		Log.Info("OLS Operator uninstallation in progress ...")
-		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		Log.Info("Unable to check RAG image compatibility", "ragImage", instance.Spec.RAGImage, "error", err.Error())
+		instance.Status.Conditions.Set(condition.TrueCondition(
+			apiv1beta1.RAGImageCompatibilityCondition,
+			apiv1beta1.RAGImageCompatibilityUnknownMessage,
+			instance.Spec.RAGImage,
+			err.Error(),
+		))
+		return nil
 	}
 
-	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+	if !isCompatible {
+		Log.Info("RAG image is not compatible with the OLS operator",
+			"ragImage", instance.Spec.RAGImage,
+			"olsVersion", olsVersion,
+			"compatibleVersions", rule.String())
+
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.RAGImageCompatibilityCondition,
+			apiv1beta1.RAGImageIncompatibleReason,
+			condition.SeverityWarning,
+			apiv1beta1.RAGImageIncompatibleMessage,
+			instance.Spec.RAGImage,
+			olsVersion,
+			rule.String(),
+		))
+		return nil
+	}
+
+	instance.Status.Conditions.Set(condition.TrueCondition(
+		apiv1beta1.RAGImageCompatibilityCondition,
+		apiv1beta1.RAGImageCompatibleMessage,
+		instance.Spec.RAGImage,
+		olsVersion,
+	))
+	return nil
+}
+
+// checkDependency reports through the DependencyReadyCondition whether the resource referenced by
+// WaitFor reports its condition as True. Returns false while the reconcile has to wait for it.
+func (r *OpenStackLightspeedReconciler) checkDependency(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (bool, error) {
+	Log := r.GetLogger(ctx)
+	ref := instance.Spec.WaitFor
+	conditionType := ref.GetConditionType()
+
+	dependency, err := GetDependency(ctx, helper, instance)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.DependencyReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			apiv1beta1.DependencyErrorMessage,
+			ref.Kind,
+			ref.Name,
+			err.Error(),
+		))
+		return false, err
+	}
+
+	if dependency == nil {
+		Log.Info("Waiting for the dependency to be created", "kind", ref.Kind, "name", ref.Name)
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.DependencyReadyCondition,
+			apiv1beta1.WaitingForDependencyReason,
+			condition.SeverityInfo,
+			apiv1beta1.DependencyNotFoundMessage,
+			ref.Kind,
+			ref.Name,
+		))
+		return false, nil
+	}
+
+	if !IsDependencyReady(dependency, ref) {
+		Log.Info("Waiting for the dependency to be ready", "kind", ref.Kind, "name", ref.Name,
+			"conditionType", conditionType)
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.DependencyReadyCondition,
+			apiv1beta1.WaitingForDependencyReason,
+			condition.SeverityInfo,
+			apiv1beta1.DependencyNotReadyMessage,
+			ref.Kind,
+			ref.Name,
+			conditionType,
+		))
+		return false, nil
+	}
+
+	instance.Status.Conditions.MarkTrue(
+		apiv1beta1.DependencyReadyCondition,
+		apiv1beta1.DependencyReadyMessage,
+		ref.Kind,
+		ref.Name,
+		conditionType,
+	)
+	return true, nil
+}
+
+// checkRAGImageArchitecture warns through the RAGImageArchitectureCondition when the RAG image is
+// not built for the architecture of some of the cluster nodes. The check is best-effort and never
+// fails the reconcile: the registry lookup is cached, failures included, and the condition only
+// reports when the image architectures cannot be retrieved (e.g. in disconnected clusters without
+// a mirror of the RAG image).
+func (r *OpenStackLightspeedReconciler) checkRAGImageArchitecture(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) {
+	Log := r.GetLogger(ctx)
+
+	// There is no RAG image to check when RAG is disabled
+	if instance.Spec.RAGImage == "" {
+		return
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, ImageInspectionTimeout)
+	defer cancel()
+
+	nodeArchs, err := GetNodeArchitectures(checkCtx, helper)
+	if err != nil {
+		Log.Info("Unable to get the cluster node architectures", "error", err.Error())
+		return
+	}
+
+	inspection := GetImageInspection(checkCtx, helper, instance.Spec.RAGImage, instance.Spec.RAGImagePullSecret)
+	if inspection.Err != nil {
+		Log.Info("Unable to get the RAG image architectures", "ragImage", instance.Spec.RAGImage,
+			"error", inspection.Err.Error())
+		instance.Status.Conditions.Set(condition.TrueCondition(
+			apiv1beta1.RAGImageArchitectureCondition,
+			apiv1beta1.RAGImageArchitectureUnknownMessage,
+			instance.Spec.RAGImage,
+			inspection.Err.Error(),
+		))
+		return
+	}
+
+	imageArchs := inspection.Architectures
+
+	unsupportedArchs := GetUnsupportedArchitectures(imageArchs, nodeArchs)
+	if len(unsupportedArchs) > 0 {
+		Log.Info("RAG image is not built for all cluster node architectures",
+			"ragImage", instance.Spec.RAGImage,
+			"imageArchitectures", imageArchs,
+			"unsupportedArchitectures", unsupportedArchs)
+		instance.Status.Conditions.Set(condition.TrueCondition(
+			apiv1beta1.RAGImageArchitectureCondition,
+			apiv1beta1.RAGImageArchitectureMismatchMessage,
+			instance.Spec.RAGImage,
+			imageArchs,
+			unsupportedArchs,
+		))
+		return
+	}
+
+	instance.Status.Conditions.Set(condition.TrueCondition(
+		apiv1beta1.RAGImageArchitectureCondition,
+		apiv1beta1.RAGImageArchitectureSupportedMessage,
+		instance.Spec.RAGImage,
+	))
+}
+
+// updateRAGBuildInfo reports the build metadata of the RAG image in the status. The metadata is
+// read from the image labels only when the RAG image changes, through the same cached registry
+// lookup as checkRAGImageArchitecture. The lookup is best-effort, the build metadata is left unset
+// when the image labels cannot be retrieved.
+func (r *OpenStackLightspeedReconciler) updateRAGBuildInfo(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) {
+	Log := r.GetLogger(ctx)
+
+	if instance.Spec.RAGImage == "" {
+		instance.Status.RAGBuildInfo = nil
+		return
+	}
+
+	if instance.Status.RAGBuildInfo != nil && instance.Status.RAGBuildInfo.Image == instance.Spec.RAGImage {
+		return
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, ImageInspectionTimeout)
+	defer cancel()
+
+	inspection := GetImageInspection(lookupCtx, helper, instance.Spec.RAGImage, instance.Spec.RAGImagePullSecret)
+	if inspection.Err != nil {
+		Log.Info("Unable to get the RAG image labels", "ragImage", instance.Spec.RAGImage,
+			"error", inspection.Err.Error())
+		instance.Status.RAGBuildInfo = nil
+		return
+	}
+
+	instance.Status.RAGBuildInfo = NewRAGBuildInfo(instance.Spec.RAGImage, inspection.Labels)
+}
+
+// discoverModel fills the empty model name and LLM endpoint of the spec with the model discovered
+// from the InferenceServices and reports the outcome through the ModelDiscoveryCondition. Returns
+// false when no model was found.
+func (r *OpenStackLightspeedReconciler) discoverModel(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (bool, error) {
+	Log := r.GetLogger(ctx)
+
+	model, err := DiscoverDefaultModel(ctx, helper, instance.Spec.ModelName)
+	if err != nil {
+		return false, err
+	} else if model == nil {
+		Log.Info("No model found for the model discovery. Waiting...")
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.ModelDiscoveryCondition,
+			apiv1beta1.ModelNotFoundReason,
+			condition.SeverityWarning,
+			apiv1beta1.ModelNotFoundMessage,
+		))
+		return false, nil
+	}
+
+	instance.Spec.ModelName = model.Name
+	if instance.Spec.LLMEndpoint == "" {
+		instance.Spec.LLMEndpoint = model.Endpoint
+	}
+
+	instance.Status.Conditions.Set(condition.TrueCondition(
+		apiv1beta1.ModelDiscoveryCondition,
+		apiv1beta1.ModelDiscoveredMessage,
+		instance.Spec.ModelName,
+		instance.Spec.LLMEndpoint,
+		model.InferenceService.String(),
+	))
+	return true, nil
+}
+
+// checkOLSConfigAPIVersion reports through the OLSConfigAPIVersionCondition whether the OLSConfig
+// CRD serves the OLSConfig API version we write. A newer OLS might serve only a newer version with
+// a different schema, or convert our version to another storage version and drop the fields it does
+// not know. The condition is not set until the OLS operator registers the CRD.
+func (r *OpenStackLightspeedReconciler) checkOLSConfigAPIVersion(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) error {
+	Log := r.GetLogger(ctx)
+
+	servedVersions, storageVersion, err := GetOLSConfigCRDVersions(ctx, helper)
+	if err != nil {
+		return err
+	} else if len(servedVersions) == 0 {
+		return nil
+	}
+
+	apiVersion := GetOLSConfigGVK().Version
+	if !slices.Contains(servedVersions, apiVersion) {
+		Log.Info("OLSConfig CRD does not serve the OLSConfig API version",
+			"apiVersion", apiVersion, "servedVersions", servedVersions)
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OLSConfigAPIVersionCondition,
+			apiv1beta1.OLSConfigAPIVersionMismatchReason,
+			condition.SeverityWarning,
+			apiv1beta1.OLSConfigAPIVersionMismatchMessage,
+			apiVersion,
+			servedVersions,
+		))
+		return nil
+	}
+
+	if storageVersion != apiVersion {
+		instance.Status.Conditions.Set(condition.TrueCondition(
+			apiv1beta1.OLSConfigAPIVersionCondition,
+			apiv1beta1.OLSConfigAPIVersionConvertedMessage,
+			apiVersion,
+			storageVersion,
+			storageVersion,
+		))
+		return nil
+	}
+
+	instance.Status.Conditions.Set(condition.TrueCondition(
+		apiv1beta1.OLSConfigAPIVersionCondition,
+		apiv1beta1.OLSConfigAPIVersionMatchMessage,
+		apiVersion,
+	))
+	return nil
+}
+
+// checkRAGImagePull reports through the RAGImageReadyCondition whether the RAG image could be pulled
+// for the OLS pods. The condition is not set until OLS creates pods that use the RAG image.
+func (r *OpenStackLightspeedReconciler) checkRAGImagePull(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) error {
+	// There is no RAG image to pull when RAG is disabled
+	if instance.Spec.RAGImage == "" {
+		return nil
+	}
+
+	ragPods, err := GetRAGPods(ctx, helper, instance.Spec.RAGImage)
+	if err != nil {
+		return err
+	} else if len(ragPods) == 0 {
+		return nil
+	}
+
+	for _, pod := range ragPods {
+		if pullErr := GetRAGImagePullError(&pod, instance.Spec.RAGImage); pullErr != "" {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				apiv1beta1.RAGImageReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityError,
+				apiv1beta1.RAGImagePullErrorMessage,
+				instance.Spec.RAGImage,
+				pod.GetName(),
+				pullErr,
+			))
+			return nil
+		}
+	}
+
+	instance.Status.Conditions.MarkTrue(
+		apiv1beta1.RAGImageReadyCondition,
+		apiv1beta1.RAGImageReadyMessage,
+	)
+	return nil
+}
+
+// countManagedResources records in the status how many resources the operator created for the
+// instance. The count is only informational, failures to list the resources are logged and
+// otherwise ignored.
+func (r *OpenStackLightspeedReconciler) countManagedResources(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) {
+	resources, err := ListManagedResources(ctx, helper, instance)
+	if err != nil {
+		r.GetLogger(ctx).Info("Unable to list the managed resources", "error", err.Error())
+		return
+	}
+
+	instance.Status.ManagedResourceCount = len(resources)
+}
+
+// checkRAGlessFallback switches OLS to answering without the RAG sources when the instance allows it
+// and the RAG pods did not become ready within RAGPodReadyTimeout. The fallback is reported as a
+// warning through the RAGlessFallbackCondition. Returns true when the fallback has just started and
+// the OLSConfig still has to be patched.
+func (r *OpenStackLightspeedReconciler) checkRAGlessFallback(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (bool, error) {
+	if !instance.Spec.AllowRAGlessFallback || instance.Spec.RAGImage == "" {
+		instance.Status.RAGlessFallbackImage = ""
+		return false, nil
+	}
+
+	if IsRAGlessFallbackActive(instance) {
+		instance.Status.Conditions.Set(condition.TrueCondition(
+			apiv1beta1.RAGlessFallbackCondition,
+			apiv1beta1.RAGlessFallbackActiveMessage,
+			instance.Spec.RAGImage,
+			RAGPodReadyTimeout,
+		))
+		return false, nil
+	}
+
+	// The RAG image changed since the last fallback, give the new one a chance
+	instance.Status.RAGlessFallbackImage = ""
+
+	ragPods, err := GetRAGPods(ctx, helper, instance.Spec.RAGImage)
+	if err != nil {
+		return false, err
+	}
+
+	if !IsRAGPodReadyTimeoutExceeded(ragPods, RAGPodReadyTimeout) {
+		instance.Status.Conditions.MarkTrue(
+			apiv1beta1.RAGlessFallbackCondition,
+			apiv1beta1.RAGlessFallbackInactiveMessage,
+		)
+		return false, nil
+	}
+
+	r.GetLogger(ctx).Info("RAG pods did not become ready in time, falling back to OLS without RAG",
+		"ragImage", instance.Spec.RAGImage)
+	instance.Status.RAGlessFallbackImage = instance.Spec.RAGImage
+	instance.Status.Conditions.Set(condition.TrueCondition(
+		apiv1beta1.RAGlessFallbackCondition,
+		apiv1beta1.RAGlessFallbackActiveMessage,
+		instance.Spec.RAGImage,
+		RAGPodReadyTimeout,
+	))
+	return true, nil
+}
+
+// checkConsoleAvailable reports through the ConsoleAvailableCondition whether the OpenShift console
+// is installed and returns the OLSConfig status conditions gating the readiness of OLS. OLS never
+// reports the console plugin as ready on clusters without the console, so it is not waited for
+// there. The console is assumed to be available when its availability cannot be detected.
+func (r *OpenStackLightspeedReconciler) checkConsoleAvailable(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) []string {
+	consoleEnabled, err := DetectConsoleEnabled(ctx, helper)
+	if err != nil {
+		r.GetLogger(ctx).Info("Unable to check the OpenShift console availability", "error", err.Error())
+		instance.Status.Conditions.MarkTrue(
+			apiv1beta1.ConsoleAvailableCondition,
+			apiv1beta1.ConsoleDetectionFailedMessage,
+			err.Error(),
+		)
+		return instance.Spec.RequiredOLSConditions
+	}
+
+	if consoleEnabled {
+		instance.Status.Conditions.MarkTrue(
+			apiv1beta1.ConsoleAvailableCondition,
+			apiv1beta1.ConsoleAvailableMessage,
+		)
+	} else {
+		instance.Status.Conditions.MarkTrue(
+			apiv1beta1.ConsoleAvailableCondition,
+			apiv1beta1.ConsoleUnavailableMessage,
+		)
+	}
+
+	return GetRequiredOLSConditions(instance.Spec.RequiredOLSConditions, consoleEnabled)
+}
+
+// checkOLSConfigObservedGeneration reports through the OLSConfigSyncedCondition whether OLS has
+// processed the latest generation of the OLSConfig, which tells apart an OLS operator that is still
+// working on our last patch from one that ignores it. OLS is reported as behind when it has not
+// caught up within OLSConfigObservedGenerationTimeout. Returns false while OLS is lagging.
+func (r *OpenStackLightspeedReconciler) checkOLSConfigObservedGeneration(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+	savedConditions condition.Conditions,
+) (bool, error) {
+	generation, observedGeneration, found, err := GetOLSConfigGenerations(ctx, helper)
+	if err != nil {
+		return false, err
+	}
+
+	instance.Status.OLSConfigGeneration = generation
+	instance.Status.OLSConfigObservedGeneration = observedGeneration
+
+	// Older OLS versions do not report the observedGeneration, there is nothing to compare with
+	if !found {
+		return true, nil
+	}
+
+	if observedGeneration >= generation {
+		instance.Status.Conditions.MarkTrue(
+			apiv1beta1.OLSConfigSyncedCondition,
+			apiv1beta1.OLSConfigSyncedMessage,
+		)
+		return true, nil
+	}
+
+	// The lag started when the condition last turned False. Once reported as behind, OLS stays
+	// behind until it catches up.
+	lagSince := time.Now()
+	savedCond := savedConditions.Get(apiv1beta1.OLSConfigSyncedCondition)
+	if savedCond != nil && savedCond.Status == corev1.ConditionFalse {
+		lagSince = savedCond.LastTransitionTime.Time
+	}
+
+	if time.Since(lagSince) >= OLSConfigObservedGenerationTimeout ||
+		(savedCond != nil && savedCond.Reason == apiv1beta1.OLSConfigBehindReason) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OLSConfigSyncedCondition,
+			apiv1beta1.OLSConfigBehindReason,
+			condition.SeverityWarning,
+			apiv1beta1.OLSConfigBehindMessage,
+			OLSConfigObservedGenerationTimeout,
+		))
+		return false, nil
+	}
+
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		apiv1beta1.OLSConfigSyncedCondition,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		apiv1beta1.OLSConfigSyncingMessage,
+	))
+	return false, nil
+}
+
+// checkOLSSubscriptionHealth mirrors the most relevant failure the OLS operator Subscription reports
+// into the OpenShiftLightspeedOperatorReadyCondition. The Subscription condition type is used as the
+// reason, e.g. CatalogSourcesUnhealthy.
+func (r *OpenStackLightspeedReconciler) checkOLSSubscriptionHealth(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) error {
+	failure, err := GetOLSSubscriptionFailure(ctx, helper, instance)
+	if err != nil || failure == nil {
+		return err
+	}
+
+	message := failure.Message
+	if message == "" {
+		message = failure.Reason
+	}
+
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
+		condition.Reason(failure.Type),
+		condition.SeverityWarning,
+		apiv1beta1.OpenShiftLightspeedOperatorSubscriptionFailedMessage,
+		GetOLSSubscriptionName(instance),
+		failure.Type,
+		message,
+	))
+	return nil
+}
+
+// checkOLSPackageInCatalog reports through the OpenShiftLightspeedOperatorReadyCondition when the
+// configured catalog does not offer the OLS operator the Subscription asks for. The check is only a
+// diagnostic, failures to read the catalog are logged and otherwise ignored.
+func (r *OpenStackLightspeedReconciler) checkOLSPackageInCatalog(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) {
+	Log := r.GetLogger(ctx)
+
+	problem, err := CheckOLSPackageInCatalog(ctx, helper, instance)
+	if err != nil {
+		Log.Info("Unable to check the OLS package in the catalog", "error", err.Error())
+		return
+	} else if problem == "" {
+		return
+	}
+
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
+		apiv1beta1.OpenShiftLightspeedOperatorCatalogReason,
+		condition.SeverityWarning,
+		apiv1beta1.OpenShiftLightspeedOperatorCatalogMessage,
+		problem,
+	))
+}
+
+// checkStuckOLSOperatorCSV reports an instance-owned OLS operator CSV stuck in replacement through
+// the OpenShiftLightspeedOperatorReadyCondition and an event. The CSV is deleted so that OLM retries
+// the upgrade when DeleteStuckOLSOperatorCSV is enabled.
+func (r *OpenStackLightspeedReconciler) checkStuckOLSOperatorCSV(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) error {
+	stuckCSV, err := GetStuckOLSOperatorCSV(ctx, helper, instance)
+	if err != nil || stuckCSV == nil {
+		return err
+	}
+
+	message := apiv1beta1.OpenShiftLightspeedOperatorCSVStuckMessage
+	if instance.Spec.DeleteStuckOLSOperatorCSV {
+		if err := DeleteStuckOLSOperatorCSV(ctx, helper, instance, stuckCSV); err != nil {
+			return err
+		}
+		message = apiv1beta1.OpenShiftLightspeedOperatorCSVStuckDeletedMessage
+	}
+
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		apiv1beta1.OpenShiftLightspeedOperatorReadyCondition,
+		apiv1beta1.OpenShiftLightspeedOperatorCSVStuckReason,
+		condition.SeverityWarning,
+		message,
+		stuckCSV.GetName(),
+		stuckCSV.Status.Phase,
+		OLSOperatorCSVReplacementTimeout,
+	))
+
+	if r.Recorder != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(apiv1beta1.OpenShiftLightspeedOperatorCSVStuckReason),
+			message, stuckCSV.GetName(), stuckCSV.Status.Phase, OLSOperatorCSVReplacementTimeout)
+	}
+
+	return nil
+}
+
+// olsGate - object the OLSConfig depends on. The OLSConfig is not written until the object exists
+// and, when missingKeys is set, holds the keys it expects.
+type olsGate struct {
+	// obj names the object, it is read into obj
+	obj client.Object
+
+	// reason and message of the Ready condition while the object is missing. messageArgs are the
+	// arguments of message.
+	reason      condition.Reason
+	message     string
+	messageArgs []interface{}
+
+	// missingKeys returns the keys the object lacks. missingKeysMessage is reported with the
+	// messageArgs followed by the missing keys.
+	missingKeys        func(obj client.Object) []string
+	missingKeysMessage string
+}
+
+// getOLSGates returns the objects the OLSConfig of the instance depends on
+func getOLSGates(instance *apiv1beta1.OpenStackLightspeed) []olsGate {
+	gates := []olsGate{}
+
+	if cache := instance.Spec.ConversationCache; cache != nil && cache.Type == apiv1beta1.ConversationCacheTypePostgres {
+		gates = append(gates, olsGate{
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: cache.CredentialsSecretRef, Namespace: OLSOperatorNamespace},
+			},
+			reason:      condition.ErrorReason,
+			message:     apiv1beta1.OpenStackLightspeedConversationCacheSecretMissingMessage,
+			messageArgs: []interface{}{cache.CredentialsSecretRef, OLSOperatorNamespace},
+		})
+	}
+
+	if apiTLS := instance.Spec.APITLS; apiTLS != nil {
+		gates = append(gates, olsGate{
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: apiTLS.SecretName, Namespace: OLSOperatorNamespace},
+			},
+			reason:      condition.ErrorReason,
+			message:     apiv1beta1.OpenStackLightspeedAPITLSSecretMissingMessage,
+			messageArgs: []interface{}{apiTLS.SecretName, OLSOperatorNamespace},
+			missingKeys: func(obj client.Object) []string {
+				return GetAPITLSSecretMissingKeys(obj.(*corev1.Secret))
+			},
+			missingKeysMessage: apiv1beta1.OpenStackLightspeedAPITLSSecretKeysMissingMessage,
+		})
+	}
+
+	if ref := instance.Spec.OpenStackContextRef; ref != nil {
+		key := ref.GetKey()
+		gates = append(gates, olsGate{
+			obj: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: instance.Namespace},
+			},
+			reason:      condition.ErrorReason,
+			message:     apiv1beta1.OpenStackLightspeedOpenStackContextMissingMessage,
+			messageArgs: []interface{}{ref.Name, instance.Namespace},
+			missingKeys: func(obj client.Object) []string {
+				if _, found := obj.(*corev1.ConfigMap).Data[key]; !found {
+					return []string{key}
+				}
+				return nil
+			},
+			missingKeysMessage: apiv1beta1.OpenStackLightspeedOpenStackContextKeysMissingMessage,
+		})
+	}
+
+	// OLS would otherwise fail to answer without a clear reason
+	for _, credentials := range GetLLMCredentials(instance) {
+		gates = append(gates, olsGate{
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: credentials.SecretName, Namespace: OLSOperatorNamespace},
+			},
+			reason:      apiv1beta1.LLMCredentialsMissingSecretReason,
+			message:     apiv1beta1.OpenStackLightspeedLLMCredentialsMissingMessage,
+			messageArgs: []interface{}{credentials.SecretName, credentials.Provider, OLSOperatorNamespace},
+			missingKeys: func(obj client.Object) []string {
+				return GetLLMCredentialsMissingKeys(obj.(*corev1.Secret), credentials.Type)
+			},
+			missingKeysMessage: apiv1beta1.OpenStackLightspeedLLMCredentialsKeysMissingMessage,
+		})
+	}
+
+	return gates
+}
+
+// checkOLSGates returns the first object the OLSConfig depends on that is missing or lacks keys, and
+// sets the Ready condition to False with its message. Returns nil when all of them are in place.
+func (r *OpenStackLightspeedReconciler) checkOLSGates(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (*olsGate, error) {
+	for _, gate := range getOLSGates(instance) {
+		isPresent, err := isOLSObjectPresent(ctx, helper, gate.obj)
+		if err != nil {
+			return nil, err
+		}
+
+		message, messageArgs := gate.message, gate.messageArgs
+		if isPresent {
+			if gate.missingKeys == nil {
+				continue
+			}
+
+			missingKeys := gate.missingKeys(gate.obj)
+			if len(missingKeys) == 0 {
+				continue
+			}
+			message, messageArgs = gate.missingKeysMessage, append(slices.Clone(messageArgs), missingKeys)
+		}
+
+		r.GetLogger(ctx).Info("Dependency of the OLSConfig not ready. Waiting...",
+			"reason", fmt.Sprintf(message, messageArgs...))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.OpenStackLightspeedReadyCondition,
+			gate.reason,
+			condition.SeverityWarning,
+			message,
+			messageArgs...,
+		))
+		return &gate, nil
+	}
+
+	return nil, nil
+}
+
+// checkUsageBudget keeps the PrometheusRule alerting on the OLS token usage in line with the usage
+// budget and reports through the UsageBudgetCondition whether the alert is in place. The rule is
+// removed when the usage budget is unset.
+func (r *OpenStackLightspeedReconciler) checkUsageBudget(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) error {
+	budget := instance.Spec.UsageBudget
+	if budget == nil {
+		return RemoveUsageBudgetPrometheusRule(ctx, helper)
+	}
+
+	isRuleCreated, err := EnsureUsageBudgetPrometheusRule(ctx, helper, instance)
+	if err != nil {
+		return err
+	} else if !isRuleCreated {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			apiv1beta1.UsageBudgetCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			apiv1beta1.UsageBudgetMonitoringMissingMessage,
+		))
+		return nil
+	}
+
+	instance.Status.Conditions.Set(condition.TrueCondition(
+		apiv1beta1.UsageBudgetCondition,
+		apiv1beta1.UsageBudgetMessage,
+		GetUsageBudgetAlertTokens(budget),
+		budget.TokensPerDay,
+	))
+
+	return nil
+}
+
+// checkConversationCacheRefresh warns through the ConversationCacheCondition and an event when the
+// OLSConfig about to be refreshed keeps the conversation history in memory, as the history is lost
+// once OLS restarts with the new model. Persistent conversation caches are not reported.
+func (r *OpenStackLightspeedReconciler) checkConversationCacheRefresh(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) error {
+	olsConfig, err := GetOLSConfig(ctx, helper)
+	if err != nil && k8s_errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Only the OLSConfig managed by the instance is refreshed, see CreateOrPatchOLSConfig
+	if olsConfig.GetLabels()[OpenStackLightspeedOwnerIDLabel] != string(instance.GetUID()) ||
+		!olsConfig.GetDeletionTimestamp().IsZero() || !HasInMemoryConversationCache(&olsConfig) {
+		return nil
+	}
+
+	instance.Status.Conditions.Set(condition.TrueCondition(
+		apiv1beta1.ConversationCacheCondition,
+		apiv1beta1.ConversationCacheInMemoryMessage,
+	))
+
+	if r.Recorder != nil {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "ConversationHistoryLost",
+			apiv1beta1.ConversationCacheInMemoryMessage)
+	}
+
+	return nil
+}
+
+// isWaitingForVectorDB returns whether OLS pods consuming the vector DB from the RAG image exist but
+// none of them is ready yet.
+func (r *OpenStackLightspeedReconciler) isWaitingForVectorDB(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (bool, error) {
+	if instance.Spec.RAGImage == "" {
+		return false, nil
+	}
+
+	ragPods, err := GetRAGPods(ctx, helper, instance.Spec.RAGImage)
+	if err != nil {
+		return false, err
+	}
+
+	return len(ragPods) > 0 && !IsRAGPodReady(ragPods), nil
+}
+
+// reconcileDelete reconciles the deletion of OpenStackLightspeed instance
+func (r *OpenStackLightspeedReconciler) reconcileDelete(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+	Log.Info("OpenStackLightspeed Reconciling Delete")
+	instance.Status.Message = apiv1beta1.OpenStackLightspeedDeletingMessage
+
+	if instance.GetAnnotations()[ForceDeleteAnnotation] == "true" {
+		return r.reconcileForceDelete(ctx, helper, instance)
+	}
+
+	isRemoved, err := RemoveOLSConfig(ctx, helper, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if !isRemoved {
+		Log.Info("OLSConfig removal in progress ...")
+		return ctrl.Result{RequeueAfter: r.getInstallPollInterval()}, nil
+	}
+
+	err = RemoveUsageBudgetPrometheusRule(ctx, helper)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// An externally managed OLS operator is left in place
+	isOLSOperatorExternal, err := IsOLSOperatorExternallyManaged(ctx, helper, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !isOLSOperatorExternal {
+		isUninstalled, err := UninstallInstanceOwnedOLSOperator(ctx, helper, instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if !isUninstalled {
+			Log.Info("OLS Operator uninstallation in progress ...")
+			return ctrl.Result{RequeueAfter: r.getInstallPollInterval()}, nil
+		}
+	}
+
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+
+	Log.Info("OpenStackLightspeed Reconciling Delete completed")
+	return ctrl.Result{}, nil
+}
+
+// reconcileForceDelete removes the finalizer of an instance carrying the ForceDeleteAnnotation. The
+// removal of the OLSConfig and of the instance owned OLS operator is requested once, but neither
+// its errors nor its completion are waited for.
+func (r *OpenStackLightspeedReconciler) reconcileForceDelete(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+	Log.Info("OpenStackLightspeed force delete requested, the cleanup is not waited for")
+
+	if _, err := RemoveOLSConfig(ctx, helper, instance); err != nil {
+		Log.Error(err, "Failed to remove the OLSConfig during force delete")
+	}
+
+	if err := RemoveUsageBudgetPrometheusRule(ctx, helper); err != nil {
+		Log.Error(err, "Failed to remove the usage budget PrometheusRule during force delete")
+	}
+
+	isOLSOperatorExternal, err := IsOLSOperatorExternallyManaged(ctx, helper, instance)
+	if err != nil {
+		Log.Error(err, "Failed to check whether the OLS operator is externally managed during force delete")
+	} else if !isOLSOperatorExternal {
+		if _, err := UninstallInstanceOwnedOLSOperator(ctx, helper, instance); err != nil {
+			Log.Error(err, "Failed to uninstall the OLS operator during force delete")
+		}
+	}
+
+	if r.Recorder != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "ForceDeleted",
+			apiv1beta1.OpenStackLightspeedForceDeletedMessage, ForceDeleteAnnotation)
+	}
+
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
 
 	Log.Info("OpenStackLightspeed Reconciling Delete completed")
 	return ctrl.Result{}, nil
@@ -388,7 +1760,15 @@ func (r *OpenStackLightspeedReconciler) SetupWithManager(mgr ctrl.Manager) error
 		Kind:    "ClusterVersion",
 	})
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// Watch the cluster Proxy so that OLS follows changes of the egress proxy configuration
+	clusterProxy := &uns.Unstructured{}
+	clusterProxy.SetGroupVersionKind(GetClusterProxyGVK())
+
+	// The OLSConfig is watched from the first reconcile that finds its CRD established, as the CRD is
+	// only registered by the OLS operator we install.
+	r.cache = mgr.GetCache()
+
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&apiv1beta1.OpenStackLightspeed{}).
 		Owns(&operatorsv1alpha1.ClusterServiceVersion{}).
 		Owns(&operatorsv1alpha1.Subscription{}).
@@ -402,19 +1782,164 @@ func (r *OpenStackLightspeedReconciler) SetupWithManager(mgr ctrl.Manager) error
 			handler.EnqueueRequestsFromMapFunc(r.NotifyAllOpenStackLightspeeds),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
-		Complete(r)
+		Watches(
+			clusterProxy,
+			handler.EnqueueRequestsFromMapFunc(r.NotifyAllOpenStackLightspeeds),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.NotifyConfigMapOwners),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}, predicate.NewPredicateFuncs(r.IsWatchedConfigMap)),
+		).
+		// Only the metadata is cached, the Secrets are read through the raw client
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.NotifyOLSGateOwners),
+			builder.OnlyMetadata,
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}, predicate.NewPredicateFuncs(IsOLSSecret)),
+		).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+		}).
+		Build(r)
+	if err != nil {
+		return err
+	}
+
+	r.controller = c
+	return nil
+}
+
+// watchOLSConfig starts watching the OLSConfig, so that the status updates of the OLS operator
+// re-enqueue the OpenStackLightspeed instance managing it. It must only be called once the OLSConfig
+// CRD is established, the watch is started only once.
+func (r *OpenStackLightspeedReconciler) watchOLSConfig(ctx context.Context) error {
+	// The controller is not set up in unit tests
+	if r.controller == nil {
+		return nil
+	}
+
+	r.olsConfigWatchLock.Lock()
+	defer r.olsConfigWatchLock.Unlock()
+
+	if r.olsConfigWatched {
+		return nil
+	}
+
+	olsConfig := &uns.Unstructured{}
+	olsConfig.SetGroupVersionKind(GetOLSConfigGVK())
+
+	err := r.controller.Watch(source.Kind[client.Object](
+		r.cache,
+		olsConfig,
+		handler.EnqueueRequestsFromMapFunc(r.NotifyOLSConfigOwner),
+		predicate.ResourceVersionChangedPredicate{},
+	))
+	if err != nil {
+		return err
+	}
+
+	r.GetLogger(ctx).Info("Watching the OLSConfig", "gvk", olsConfig.GroupVersionKind().String())
+	r.olsConfigWatched = true
+	return nil
+}
+
+// NotifyOLSConfigOwner returns a reconcile request for the OpenStackLightspeed instance whose UID is
+// in the owner ID label of the OLSConfig, and for the instances waiting for the OLSConfig to be
+// released by its owner.
+func (r *OpenStackLightspeedReconciler) NotifyOLSConfigOwner(ctx context.Context, obj client.Object) []ctrl.Request {
+	ownerID := obj.GetLabels()[OpenStackLightspeedOwnerIDLabel]
+
+	var lightspeedList apiv1beta1.OpenStackLightspeedList
+	if err := r.List(ctx, &lightspeedList); err != nil {
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for _, item := range lightspeedList.Items {
+		cond := item.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+		isConflicting := cond != nil && cond.Reason == apiv1beta1.OLSConfigConflictingReason
+		if (ownerID != "" && string(item.GetUID()) == ownerID) || isConflicting {
+			requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+
+	return requests
+}
+
+// IsOLSSecret returns true for the Secrets of the OLS namespace. It is used as a predicate of the
+// Secret watch.
+func IsOLSSecret(obj client.Object) bool {
+	return obj.GetNamespace() == OLSOperatorNamespace
+}
+
+// NotifyOLSGateOwners returns a reconcile request for the OpenStackLightspeed instances whose
+// OLSConfig waits for the Secret
+func (r *OpenStackLightspeedReconciler) NotifyOLSGateOwners(ctx context.Context, obj client.Object) []ctrl.Request {
+	return r.getOLSGateOwners(ctx, obj, func(gateObj client.Object) bool {
+		_, isSecret := gateObj.(*corev1.Secret)
+		return isSecret
+	})
+}
+
+// IsWatchedConfigMap returns true for the trusted CA ConfigMap and for the ConfigMaps the OLSConfig
+// of an OpenStackLightspeed instance waits for, e.g. the OpenStack context. It is used as a
+// predicate of the ConfigMap watch.
+func (r *OpenStackLightspeedReconciler) IsWatchedConfigMap(obj client.Object) bool {
+	return IsTrustedCAConfigMap(obj) || len(r.NotifyConfigMapOwners(context.Background(), obj)) > 0
+}
+
+// NotifyConfigMapOwners returns a reconcile request for all OpenStackLightspeed instances when the
+// trusted CA ConfigMap changes, and for the instances whose OLSConfig waits for any other ConfigMap
+func (r *OpenStackLightspeedReconciler) NotifyConfigMapOwners(ctx context.Context, obj client.Object) []ctrl.Request {
+	if IsTrustedCAConfigMap(obj) {
+		return r.NotifyAllOpenStackLightspeeds(ctx, obj)
+	}
+
+	return r.getOLSGateOwners(ctx, obj, func(gateObj client.Object) bool {
+		_, isConfigMap := gateObj.(*corev1.ConfigMap)
+		return isConfigMap
+	})
+}
+
+// getOLSGateOwners returns a reconcile request for the OpenStackLightspeed instances with an OLS gate
+// on obj. isGateKind tells whether the object of a gate is of the kind of obj.
+func (r *OpenStackLightspeedReconciler) getOLSGateOwners(
+	ctx context.Context,
+	obj client.Object,
+	isGateKind func(gateObj client.Object) bool,
+) []ctrl.Request {
+	var lightspeedList apiv1beta1.OpenStackLightspeedList
+	if err := r.List(ctx, &lightspeedList); err != nil {
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for _, item := range lightspeedList.Items {
+		for _, gate := range getOLSGates(&item) {
+			if isGateKind(gate.obj) && client.ObjectKeyFromObject(gate.obj) == client.ObjectKeyFromObject(obj) {
+				requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+				break
+			}
+		}
+	}
+
+	return requests
 }
 
 // NotifyAllOpenStackLightspeeds returns a list of reconcile requests for all OpenStackLightspeed objects.
 // For namespace-scoped resources (like InstallPlan), it lists in the same namespace as the triggering object.
-// For cluster-scoped resources (like ClusterVersion), it lists in all namespaces the operator can access.
+// For cluster-scoped resources (like ClusterVersion) and resources of the OLS namespace (like the
+// trusted CA ConfigMap), it lists in all namespaces the operator can access.
 func (r *OpenStackLightspeedReconciler) NotifyAllOpenStackLightspeeds(ctx context.Context, obj client.Object) []ctrl.Request {
 	var lightspeedList apiv1beta1.OpenStackLightspeedList
 	var err error
 
 	// For cluster-scoped resources (no namespace), list without namespace filter
 	// The operator's cache is already restricted to the watch namespace, so this is safe
-	if obj.GetNamespace() == "" {
+	// Resources of the OLS namespace are referenced by the OLSConfig of whichever instance manages it
+	if obj.GetNamespace() == "" || obj.GetNamespace() == OLSOperatorNamespace {
 		err = r.List(ctx, &lightspeedList)
 	} else {
 		err = r.List(ctx, &lightspeedList, client.InNamespace(obj.GetNamespace()))
@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// apiVersionRegexp matches Kubernetes API versions such as "v1", "v1beta1" or "v1alpha1"
+var apiVersionRegexp = regexp.MustCompile(`^v[1-9][0-9]*((alpha|beta)[1-9][0-9]*)?$`)
+
+// ValidateOperatorEnv checks the environment variables the operator is configured through, so that
+// a misconfigured deployment fails at startup instead of on every reconcile. All the problems found
+// are returned joined in a single error.
+func ValidateOperatorEnv() error {
+	var errs []error
+
+	olsVersion := os.Getenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION")
+	if _, err := GetRecommendedOLSVersion(); err != nil {
+		errs = append(errs, err)
+	} else if olsVersion != "latest" {
+		if _, err := version.ParseSemantic(olsVersion); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"environment variable OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION must be \"latest\" or a semantic version: %w", err))
+		}
+	}
+
+	if apiVersion := os.Getenv("OLS_CONFIG_API_VERSION"); apiVersion != "" && !apiVersionRegexp.MatchString(apiVersion) {
+		errs = append(errs, fmt.Errorf(
+			"environment variable OLS_CONFIG_API_VERSION must be a Kubernetes API version (e.g. v1alpha1), got %q", apiVersion))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+)
+
+func TestValidateOperatorEnv(t *testing.T) {
+	tests := []struct {
+		name         string
+		olsVersion   string
+		olsConfigAPI string
+		shouldError  bool
+	}{
+		{
+			name:       "Latest OLS version",
+			olsVersion: "latest",
+		},
+		{
+			name:         "Pinned OLS version and OLSConfig API version",
+			olsVersion:   "1.0.6",
+			olsConfigAPI: "v1",
+		},
+		{
+			name:        "Missing OLS version",
+			olsVersion:  "",
+			shouldError: true,
+		},
+		{
+			name:        "Malformed OLS version",
+			olsVersion:  "one",
+			shouldError: true,
+		},
+		{
+			name:         "Malformed OLSConfig API version",
+			olsVersion:   "latest",
+			olsConfigAPI: "ols.openshift.io/v1alpha1",
+			shouldError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", tt.olsVersion)
+			t.Setenv("OLS_CONFIG_API_VERSION", tt.olsConfigAPI)
+
+			err := ValidateOperatorEnv()
+			if tt.shouldError && err == nil {
+				t.Errorf("ValidateOperatorEnv expected error, got nil")
+			} else if !tt.shouldError && err != nil {
+				t.Errorf("ValidateOperatorEnv unexpected error: %v", err)
+			}
+		})
+	}
+}
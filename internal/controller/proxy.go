@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// ClusterProxyName - name of the cluster wide Proxy object
+	ClusterProxyName = "cluster"
+
+	// TrustedCAConfigMapName - name of the ConfigMap in the OLS namespace the cluster network
+	// operator injects the trusted CA bundle into. OLS uses it to trust the egress proxy.
+	TrustedCAConfigMapName = "openstack-lightspeed-trusted-ca"
+
+	// TrustedCABundleKey - key of the injected trusted CA bundle
+	TrustedCABundleKey = "ca-bundle.crt"
+
+	// injectTrustedCABundleLabel - label that asks the cluster network operator to inject the
+	// trusted CA bundle into a ConfigMap
+	injectTrustedCABundleLabel = "config.openshift.io/inject-trusted-cabundle"
+)
+
+// GetClusterProxyGVK returns the GroupVersionKind of the cluster wide Proxy object
+func GetClusterProxyGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "Proxy",
+	}
+}
+
+// GetClusterProxyURL returns the URL of the cluster wide egress proxy. The HTTPS proxy is preferred
+// as the LLM endpoints are usually served over HTTPS. An empty string is returned when the cluster
+// does not use a proxy.
+func GetClusterProxyURL(ctx context.Context, helper *common_helper.Helper) (string, error) {
+	// Use raw client to access cluster-scoped resources
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return "", err
+	}
+
+	proxy := &uns.Unstructured{}
+	proxy.SetGroupVersionKind(GetClusterProxyGVK())
+	err = rawClient.Get(ctx, client.ObjectKey{Name: ClusterProxyName}, proxy)
+	if k8s_errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	// The status holds the proxy configuration in use, the spec might not have been applied yet
+	for _, field := range []string{"httpsProxy", "httpProxy"} {
+		proxyURL, _, err := uns.NestedString(proxy.Object, "status", field)
+		if err != nil {
+			return "", err
+		} else if proxyURL != "" {
+			return proxyURL, nil
+		}
+	}
+
+	return "", nil
+}
+
+// EnsureTrustedCAConfigMap creates the ConfigMap the cluster network operator injects the trusted CA
+// bundle into. Returns true once the bundle has been injected, the ConfigMap is empty until the
+// cluster network operator processes it.
+func EnsureTrustedCAConfigMap(ctx context.Context, helper *common_helper.Helper) (bool, error) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TrustedCAConfigMapName,
+			Namespace: OLSOperatorNamespace,
+		},
+	}
+
+	// The data is owned by the cluster network operator, only the injection label is managed here.
+	_, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), configMap, func() error {
+		labels := configMap.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[injectTrustedCABundleLabel] = "true"
+		configMap.SetLabels(labels)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return configMap.Data[TrustedCABundleKey] != "", nil
+}
+
+// IsTrustedCAConfigMap returns true for the ConfigMap the trusted CA bundle is injected into. It is
+// used as a predicate of the ConfigMap watch, the bundle injection triggers a new reconcile.
+func IsTrustedCAConfigMap(obj client.Object) bool {
+	return obj.GetNamespace() == OLSOperatorNamespace && obj.GetName() == TrustedCAConfigMapName
+}
+
+// PatchOLSConfigProxy configures OLS to reach the LLM through the cluster egress proxy. The proxy
+// CA is only referenced once the trusted CA bundle is available. The proxy settings are dropped when
+// proxyURL is empty.
+func PatchOLSConfigProxy(olsConfig *uns.Unstructured, proxyURL string, trustedCAInjected bool) error {
+	if proxyURL == "" {
+		uns.RemoveNestedField(olsConfig.Object, "spec", "ols", "proxyConfig")
+		return nil
+	}
+
+	err := uns.SetNestedField(olsConfig.Object, proxyURL, "spec", "ols", "proxyConfig", "proxyURL")
+	if err != nil {
+		return err
+	}
+
+	if !trustedCAInjected {
+		uns.RemoveNestedField(olsConfig.Object, "spec", "ols", "proxyConfig", "proxyCACertificate")
+		return nil
+	}
+
+	return uns.SetNestedMap(olsConfig.Object, map[string]interface{}{
+		"name": TrustedCAConfigMapName,
+		"key":  TrustedCABundleKey,
+	}, "spec", "ols", "proxyConfig", "proxyCACertificate")
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+// Image labels holding the RAG build metadata. The OCI annotations are preferred, the labels used
+// by the Red Hat container images are the fallback.
+var (
+	ragVersionLabels  = []string{"org.opencontainers.image.version", "version"}
+	ragRevisionLabels = []string{"org.opencontainers.image.revision", "vcs-ref"}
+	ragBuiltAtLabels  = []string{"org.opencontainers.image.created", "build-date"}
+)
+
+// GetImageLabels queries the registry for the labels of the image config. For multi-architecture
+// images the labels of the first listed platform are returned, they are shared by all platforms of
+// the RAG images. auth holds the base64 encoded basic auth credentials of the registry, anonymous
+// access is used when it is empty.
+func GetImageLabels(ctx context.Context, httpClient *http.Client, image string, auth string) (map[string]string, error) {
+	registry, repository, reference := ParseImageReference(image)
+	baseURL := fmt.Sprintf("https://%s/v2/%s", registry, repository)
+
+	var manifest imageManifest
+	authorization, err := registryGet(ctx, httpClient, baseURL+"/manifests/"+reference, "", auth, &manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range manifest.Manifests {
+		// Attestation manifests are listed with an unknown platform
+		arch := m.Platform.Architecture
+		if arch == "" || arch == "unknown" || m.Digest == "" {
+			continue
+		}
+
+		manifest = imageManifest{}
+		if _, err := registryGet(ctx, httpClient, baseURL+"/manifests/"+m.Digest, authorization, auth, &manifest); err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	if manifest.Config.Digest == "" {
+		return nil, fmt.Errorf("image %s has no config", image)
+	}
+
+	var config struct {
+		Config struct {
+			Labels map[string]string `json:"Labels"`
+		} `json:"config"`
+	}
+	if _, err := registryGet(ctx, httpClient, baseURL+"/blobs/"+manifest.Config.Digest, authorization, auth, &config); err != nil {
+		return nil, err
+	}
+
+	if config.Config.Labels == nil {
+		return map[string]string{}, nil
+	}
+
+	return config.Config.Labels, nil
+}
+
+// NewRAGBuildInfo returns the build metadata of the RAG image described by its labels. A build
+// time that is not in RFC 3339 format is ignored.
+func NewRAGBuildInfo(image string, labels map[string]string) *apiv1beta1.RAGBuildInfo {
+	buildInfo := &apiv1beta1.RAGBuildInfo{
+		Image:    image,
+		Version:  getFirstLabel(labels, ragVersionLabels),
+		Revision: getFirstLabel(labels, ragRevisionLabels),
+	}
+
+	if builtAt, err := time.Parse(time.RFC3339, getFirstLabel(labels, ragBuiltAtLabels)); err == nil {
+		buildInfo.BuiltAt = &metav1.Time{Time: builtAt}
+	}
+
+	return buildInfo
+}
+
+// getFirstLabel returns the value of the first of the keys that is set in labels
+func getFirstLabel(labels map[string]string, keys []string) string {
+	for _, key := range keys {
+		if value := labels[key]; value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetImageLabels(t *testing.T) {
+	const configBlob = `{"architecture": "amd64", "config": {"Labels": {"version": "2025.2"}}}`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/rag/multiarch/manifests/v1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"manifests": [
+			{"digest": "sha256:attestation", "platform": {"architecture": "unknown", "os": "unknown"}},
+			{"digest": "sha256:amd64", "platform": {"architecture": "amd64", "os": "linux"}}
+		]}`)
+	})
+	mux.HandleFunc("/v2/rag/multiarch/manifests/sha256:amd64", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"config": {"digest": "sha256:config"}}`)
+	})
+	mux.HandleFunc("/v2/rag/multiarch/blobs/sha256:config", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, configBlob)
+	})
+	mux.HandleFunc("/v2/rag/single/manifests/v1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"config": {"digest": "sha256:config"}}`)
+	})
+	mux.HandleFunc("/v2/rag/single/blobs/sha256:config", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, configBlob)
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	registryHost := strings.TrimPrefix(server.URL, "https://")
+
+	tests := []struct {
+		name           string
+		image          string
+		expectedLabels map[string]string
+		shouldError    bool
+	}{
+		{
+			name:           "Multi-architecture image",
+			image:          registryHost + "/rag/multiarch:v1",
+			expectedLabels: map[string]string{"version": "2025.2"},
+		},
+		{
+			name:           "Single architecture image",
+			image:          registryHost + "/rag/single:v1",
+			expectedLabels: map[string]string{"version": "2025.2"},
+		},
+		{
+			name:        "Missing image",
+			image:       registryHost + "/rag/missing:v1",
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels, err := GetImageLabels(context.Background(), server.Client(), tt.image, "")
+			if tt.shouldError {
+				if err == nil {
+					t.Errorf("GetImageLabels(%s) expected error, got nil", tt.image)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetImageLabels(%s) unexpected error: %v", tt.image, err)
+			}
+			if !maps.Equal(labels, tt.expectedLabels) {
+				t.Errorf("GetImageLabels(%s) = %v, want %v", tt.image, labels, tt.expectedLabels)
+			}
+		})
+	}
+}
+
+func TestNewRAGBuildInfo(t *testing.T) {
+	const ragImage = "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2"
+
+	tests := []struct {
+		name             string
+		labels           map[string]string
+		expectedVersion  string
+		expectedRevision string
+		expectedBuiltAt  string
+	}{
+		{
+			name: "OCI labels",
+			labels: map[string]string{
+				"org.opencontainers.image.version":  "2025.2",
+				"org.opencontainers.image.revision": "0123abc",
+				"org.opencontainers.image.created":  "2025-09-01T10:00:00Z",
+				"version":                           "ignored",
+			},
+			expectedVersion:  "2025.2",
+			expectedRevision: "0123abc",
+			expectedBuiltAt:  "2025-09-01T10:00:00Z",
+		},
+		{
+			name: "Red Hat labels",
+			labels: map[string]string{
+				"version":    "2025.2",
+				"vcs-ref":    "0123abc",
+				"build-date": "2025-09-01T10:00:00Z",
+			},
+			expectedVersion:  "2025.2",
+			expectedRevision: "0123abc",
+			expectedBuiltAt:  "2025-09-01T10:00:00Z",
+		},
+		{
+			name:            "Unparsable build date",
+			labels:          map[string]string{"version": "2025.2", "build-date": "yesterday"},
+			expectedVersion: "2025.2",
+		},
+		{
+			name: "No labels",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buildInfo := NewRAGBuildInfo(ragImage, tt.labels)
+			if buildInfo.Image != ragImage {
+				t.Errorf("Image = %q, want %q", buildInfo.Image, ragImage)
+			}
+			if buildInfo.Version != tt.expectedVersion {
+				t.Errorf("Version = %q, want %q", buildInfo.Version, tt.expectedVersion)
+			}
+			if buildInfo.Revision != tt.expectedRevision {
+				t.Errorf("Revision = %q, want %q", buildInfo.Revision, tt.expectedRevision)
+			}
+
+			var builtAt string
+			if buildInfo.BuiltAt != nil {
+				builtAt = buildInfo.BuiltAt.UTC().Format(time.RFC3339)
+			}
+			if builtAt != tt.expectedBuiltAt {
+				t.Errorf("BuiltAt = %q, want %q", builtAt, tt.expectedBuiltAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+// RAGImageCompatibilityRule describes the OLS operator versions that can consume the RAG images
+// whose tag starts with TagPrefix.
+type RAGImageCompatibilityRule struct {
+	// TagPrefix selects the RAG image tags the rule applies to
+	TagPrefix string
+
+	// MinOLSVersion is the oldest compatible OLS operator version (inclusive)
+	MinOLSVersion string
+
+	// MaxOLSVersion is the first OLS operator version that is no longer compatible (exclusive).
+	// There is no upper bound when empty.
+	MaxOLSVersion string
+}
+
+// RAGImageCompatibilityPolicy lists the known RAG image formats. The first rule whose TagPrefix
+// matches the RAG image tag is used. RAG images not matched by any rule are not checked.
+var RAGImageCompatibilityPolicy = []RAGImageCompatibilityRule{
+	// The 2025 documentation images ship the vector DB format introduced in OLS 1.0
+	{TagPrefix: "os-docs-2025.", MinOLSVersion: "1.0.0"},
+	// Older documentation images only require the BYOK RAG support introduced in OLS 0.3
+	{TagPrefix: "os-docs-", MinOLSVersion: "0.3.0"},
+}
+
+// String returns a human readable description of the OLS operator versions covered by the rule.
+func (rule RAGImageCompatibilityRule) String() string {
+	if rule.MaxOLSVersion == "" {
+		return fmt.Sprintf(">= %s", rule.MinOLSVersion)
+	}
+
+	return fmt.Sprintf(">= %s, < %s", rule.MinOLSVersion, rule.MaxOLSVersion)
+}
+
+// NormalizeRAGImageTag validates the RAG image reference and returns its tag. Images referenced
+// without a tag resolve to the "latest" tag. An empty tag is returned for images that are only
+// referenced by digest, as there is nothing to derive their format from.
+// Example: "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2" -> "os-docs-2025.2"
+func NormalizeRAGImageTag(image string) (string, error) {
+	image = strings.TrimSpace(image)
+	if errs := apiv1beta1.ValidateImageReference(image, field.NewPath("spec", "ragImage")); len(errs) > 0 {
+		return "", errs.ToAggregate()
+	}
+
+	name, _, hasDigest := strings.Cut(image, "@")
+
+	// A colon after the last slash separates the tag, any other colon belongs to the registry port.
+	lastSlash := strings.LastIndex(name, "/")
+	if idx := strings.LastIndex(name, ":"); idx > lastSlash {
+		return name[idx+1:], nil
+	}
+
+	if hasDigest {
+		return "", nil
+	}
+
+	return "latest", nil
+}
+
+// imageDigestRegexp matches the sha256 digest of an image reference
+var imageDigestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// IsDigestPinned returns whether the image is a valid reference pinned by its sha256 digest, with
+// or without a tag. Example: "quay.io/rag-content@sha256:0123...cdef" -> true
+func IsDigestPinned(image string) bool {
+	if errs := apiv1beta1.ValidateImageReference(image, field.NewPath("image")); len(errs) > 0 {
+		return false
+	}
+
+	_, digest, hasDigest := strings.Cut(image, "@")
+	return hasDigest && imageDigestRegexp.MatchString(digest)
+}
+
+// GetRAGImageCompatibilityRule returns the compatibility rule that applies to the RAG image tag
+// or nil when the tag is not covered by RAGImageCompatibilityPolicy.
+func GetRAGImageCompatibilityRule(tag string) *RAGImageCompatibilityRule {
+	for _, rule := range RAGImageCompatibilityPolicy {
+		if strings.HasPrefix(tag, rule.TagPrefix) {
+			return &rule
+		}
+	}
+
+	return nil
+}
+
+// CheckRAGImageCompatibility checks the RAG image against the OLS operator version using the
+// RAGImageCompatibilityPolicy. Returns (true, rule, nil) when the pairing is compatible or cannot
+// be judged because no rule covers the RAG image (rule is nil in that case), and
+// (false, rule, nil) when the pairing is known to be incompatible.
+func CheckRAGImageCompatibility(
+	ragImage string,
+	olsVersion string,
+) (bool, *RAGImageCompatibilityRule, error) {
+	tag, err := NormalizeRAGImageTag(ragImage)
+	if err != nil {
+		return false, nil, err
+	}
+
+	rule := GetRAGImageCompatibilityRule(tag)
+	if rule == nil {
+		return true, nil, nil
+	}
+
+	olsSemVer, err := version.ParseSemantic(olsVersion)
+	if err != nil {
+		return false, rule, fmt.Errorf("invalid OLS operator version %s: %w", olsVersion, err)
+	}
+
+	if !olsSemVer.AtLeast(version.MustParseSemantic(rule.MinOLSVersion)) {
+		return false, rule, nil
+	}
+
+	if rule.MaxOLSVersion != "" && olsSemVer.AtLeast(version.MustParseSemantic(rule.MaxOLSVersion)) {
+		return false, rule, nil
+	}
+
+	return true, rule, nil
+}
+
+// GetOLSOperatorVersion returns the version of the OLS operator described by the CSV. CSVs that do
+// not report spec.version fall back to the version encoded in the CSV name.
+// Example: "lightspeed-operator.v1.0.6" -> "1.0.6"
+func GetOLSOperatorVersion(csv *operatorsv1alpha1.ClusterServiceVersion) string {
+	if v := csv.Spec.Version.String(); v != "0.0.0" {
+		return v
+	}
+
+	return strings.TrimPrefix(csv.GetName(), OLSOperatorName+".v")
+}
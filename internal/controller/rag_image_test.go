@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+)
+
+func TestNormalizeRAGImageTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		image       string
+		expected    string
+		shouldError bool
+	}{
+		{
+			name:     "Tagged image",
+			image:    "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2",
+			expected: "os-docs-2025.2",
+		},
+		{
+			name:     "Registry with port",
+			image:    "registry.example.com:5000/rag-content:os-docs-2024.2",
+			expected: "os-docs-2024.2",
+		},
+		{
+			name:     "Untagged image",
+			image:    "registry.example.com:5000/rag-content",
+			expected: "latest",
+		},
+		{
+			name:     "Tag and digest",
+			image:    "quay.io/rag-content:os-docs-2025.2@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			expected: "os-docs-2025.2",
+		},
+		{
+			name:     "Digest only",
+			image:    "quay.io/rag-content@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			expected: "",
+		},
+		{
+			name:     "Surrounding whitespace",
+			image:    " quay.io/rag-content:os-docs-2025.2 ",
+			expected: "os-docs-2025.2",
+		},
+		{
+			name:        "Invalid reference",
+			image:       "quay.io/RAG content:latest",
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NormalizeRAGImageTag(tt.image)
+			if tt.shouldError {
+				if err == nil {
+					t.Errorf("NormalizeRAGImageTag(%s) expected error, got nil", tt.image)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeRAGImageTag(%s) unexpected error: %v", tt.image, err)
+			}
+			if result != tt.expected {
+				t.Errorf("NormalizeRAGImageTag(%s) = %s, want %s", tt.image, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckRAGImageCompatibility(t *testing.T) {
+	tests := []struct {
+		name         string
+		ragImage     string
+		olsVersion   string
+		compatible   bool
+		expectedRule string
+		shouldError  bool
+	}{
+		{
+			name:         "Current docs with OLS 1.0",
+			ragImage:     "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2",
+			olsVersion:   "1.0.6",
+			compatible:   true,
+			expectedRule: "os-docs-2025.",
+		},
+		{
+			name:         "Current docs with old OLS",
+			ragImage:     "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2",
+			olsVersion:   "0.3.5",
+			compatible:   false,
+			expectedRule: "os-docs-2025.",
+		},
+		{
+			name:         "Older docs with BYOK capable OLS",
+			ragImage:     "quay.io/openstack-lightspeed/rag-content:os-docs-2024.2",
+			olsVersion:   "0.3.0",
+			compatible:   true,
+			expectedRule: "os-docs-",
+		},
+		{
+			name:         "Older docs with OLS without BYOK",
+			ragImage:     "quay.io/openstack-lightspeed/rag-content:os-docs-2024.2",
+			olsVersion:   "0.2.1",
+			compatible:   false,
+			expectedRule: "os-docs-",
+		},
+		{
+			name:       "Image not covered by the policy",
+			ragImage:   "test-image:latest",
+			olsVersion: "0.1.0",
+			compatible: true,
+		},
+		{
+			name:       "Digest only image",
+			ragImage:   "quay.io/rag-content@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			olsVersion: "0.1.0",
+			compatible: true,
+		},
+		{
+			name:        "Invalid OLS version",
+			ragImage:    "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2",
+			olsVersion:  "unknown",
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compatible, rule, err := CheckRAGImageCompatibility(tt.ragImage, tt.olsVersion)
+			if tt.shouldError {
+				if err == nil {
+					t.Errorf("CheckRAGImageCompatibility(%s, %s) expected error, got nil", tt.ragImage, tt.olsVersion)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckRAGImageCompatibility(%s, %s) unexpected error: %v", tt.ragImage, tt.olsVersion, err)
+			}
+			if compatible != tt.compatible {
+				t.Errorf("CheckRAGImageCompatibility(%s, %s) = %v, want %v",
+					tt.ragImage, tt.olsVersion, compatible, tt.compatible)
+			}
+
+			ruleTagPrefix := ""
+			if rule != nil {
+				ruleTagPrefix = rule.TagPrefix
+			}
+			if ruleTagPrefix != tt.expectedRule {
+				t.Errorf("CheckRAGImageCompatibility(%s, %s) rule = %q, want %q",
+					tt.ragImage, tt.olsVersion, ruleTagPrefix, tt.expectedRule)
+			}
+		})
+	}
+}
+
+func TestIsDigestPinned(t *testing.T) {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		name     string
+		image    string
+		expected bool
+	}{
+		{
+			name:     "Tag only",
+			image:    "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2",
+			expected: false,
+		},
+		{
+			name:     "Untagged image",
+			image:    "quay.io/openstack-lightspeed/rag-content",
+			expected: false,
+		},
+		{
+			name:     "Digest",
+			image:    "quay.io/openstack-lightspeed/rag-content@" + digest,
+			expected: true,
+		},
+		{
+			name:     "Registry with port and digest",
+			image:    "registry.example.com:5000/rag-content@" + digest,
+			expected: true,
+		},
+		{
+			name:     "Tag and digest",
+			image:    "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2@" + digest,
+			expected: true,
+		},
+		{
+			name:     "Truncated digest",
+			image:    "quay.io/openstack-lightspeed/rag-content@sha256:0123456789abcdef",
+			expected: false,
+		},
+		{
+			name:     "Digest without algorithm",
+			image:    "quay.io/openstack-lightspeed/rag-content@0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			expected: false,
+		},
+		{
+			name:     "Malformed reference",
+			image:    "quay.io/RAG content@" + digest,
+			expected: false,
+		},
+		{
+			name:     "Empty",
+			image:    "",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsDigestPinned(tt.image); result != tt.expected {
+				t.Errorf("IsDigestPinned(%s) = %v, want %v", tt.image, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RAGPodReadyTimeout - Time after which a RAG pod that is not ready is considered unable to load the
+// vector DB.
+const RAGPodReadyTimeout = 10 * time.Minute
+
+// imagePullErrorReasons lists the waiting reasons of containers whose image cannot be pulled
+var imagePullErrorReasons = []string{
+	"ErrImagePull",
+	"ImagePullBackOff",
+	"InvalidImageName",
+	"ErrImageNeverPull",
+}
+
+// GetRAGPods returns the pods in the OLS namespace that run a container from the RAG image. OLS
+// consumes the vector DB from the RAG image through these containers.
+func GetRAGPods(ctx context.Context, helper *common_helper.Helper, ragImage string) ([]corev1.Pod, error) {
+	// Use a dedicated client as the OLS namespace might not be among the watched namespaces.
+	rawClient, err := GetRawClient(helper)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods corev1.PodList
+	if err := rawClient.List(ctx, &pods, client.InNamespace(OLSOperatorNamespace)); err != nil {
+		return nil, err
+	}
+
+	var ragPods []corev1.Pod
+	for _, pod := range pods.Items {
+		if len(getRAGContainerNames(&pod, ragImage)) > 0 {
+			ragPods = append(ragPods, pod)
+		}
+	}
+
+	return ragPods, nil
+}
+
+// GetRAGImagePullError returns a description of the image pull failure of the pod containers that
+// run the RAG image, or an empty string when the image was pulled or is still being pulled.
+func GetRAGImagePullError(pod *corev1.Pod, ragImage string) string {
+	ragContainers := getRAGContainerNames(pod, ragImage)
+	statuses := append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...)
+
+	for _, status := range statuses {
+		if !slices.Contains(ragContainers, status.Name) || status.State.Waiting == nil {
+			continue
+		}
+
+		waiting := status.State.Waiting
+		if slices.Contains(imagePullErrorReasons, waiting.Reason) {
+			return fmt.Sprintf("%s: %s", waiting.Reason, waiting.Message)
+		}
+	}
+
+	return ""
+}
+
+// IsRAGPodReady returns whether any of the RAG pods is ready. An empty ragPods is not ready.
+func IsRAGPodReady(ragPods []corev1.Pod) bool {
+	for _, pod := range ragPods {
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IsRAGPodReadyTimeoutExceeded returns whether none of the RAG pods is ready although the oldest
+// of them was created more than timeout ago. An empty ragPods has not exceeded the timeout.
+func IsRAGPodReadyTimeoutExceeded(ragPods []corev1.Pod, timeout time.Duration) bool {
+	if len(ragPods) == 0 || IsRAGPodReady(ragPods) {
+		return false
+	}
+
+	for _, pod := range ragPods {
+		if time.Since(pod.CreationTimestamp.Time) >= timeout {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getRAGContainerNames returns the names of the pod containers (including init containers) that
+// run the RAG image.
+func getRAGContainerNames(pod *corev1.Pod, ragImage string) []string {
+	var names []string
+	for _, container := range append(slices.Clone(pod.Spec.InitContainers), pod.Spec.Containers...) {
+		if container.Image == ragImage {
+			names = append(names, container.Name)
+		}
+	}
+
+	return names
+}
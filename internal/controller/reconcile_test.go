@@ -0,0 +1,2748 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+	"github.com/openstack-lightspeed/operator/internal/ocpversion"
+)
+
+func TestReconcileRegistersFinalizer(t *testing.T) {
+	instance := newTestInstance()
+	cl := newTestClient(t, instance)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	res, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if res.RequeueAfter != 0 {
+		t.Errorf("expected no requeue after registering the finalizer, got %v", res.RequeueAfter)
+	}
+
+	if !slices.Contains(instance.Finalizers, "openstack.org/openstacklightspeed") {
+		t.Errorf("expected the finalizer to be registered, got %v", instance.Finalizers)
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+	if cond == nil || cond.Message != apiv1beta1.OpenStackLightspeedRegisteringFinalizerMessage {
+		t.Errorf("expected OpenStackLightspeedReadyCondition registering the finalizer, got %+v", cond)
+	}
+}
+
+func TestReconcileOCPRAGToggle(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", "latest")
+
+	instance := newTestInstance()
+	instance.Spec.EnableOCPRAG = true
+	cl := newTestClient(t, instance, newTestClusterVersion("4.16.3"))
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	// The first reconcile only registers the finalizer.
+	if _, _, err := reconcileTestInstance(t, r); err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	_, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OCPRAGCondition)
+	if cond == nil || cond.Status != corev1.ConditionTrue || cond.Message != "OCP RAG version resolved: 4.16" {
+		t.Fatalf("expected resolved OCPRAGCondition, got %+v", cond)
+	}
+	if instance.Status.ActiveOCPRAGVersion != ocpversion.Version416 {
+		t.Errorf("ActiveOCPRAGVersion = %s, want %s", instance.Status.ActiveOCPRAGVersion, ocpversion.Version416)
+	}
+
+	// Disable the OCP RAG and make sure the condition does not keep the resolved state.
+	instance.Spec.EnableOCPRAG = false
+	if err := cl.Update(context.Background(), instance); err != nil {
+		t.Fatalf("failed to update instance: %v", err)
+	}
+
+	_, instance, err = reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	cond = instance.Status.Conditions.Get(apiv1beta1.OCPRAGCondition)
+	if cond == nil || cond.Status != corev1.ConditionTrue || cond.Message != apiv1beta1.OCPRAGDisabledMessage {
+		t.Fatalf("expected disabled OCPRAGCondition, got %+v", cond)
+	}
+	if instance.Status.ActiveOCPRAGVersion != "" {
+		t.Errorf("ActiveOCPRAGVersion = %s, want empty", instance.Status.ActiveOCPRAGVersion)
+	}
+}
+
+func TestReconcileOCPRAG(t *testing.T) {
+	tests := []struct {
+		name                string
+		enableOCPRAG        bool
+		versionOverride     string
+		clusterVersion      string
+		expectedVersion     string
+		expectedMessage     string
+		expectedByokRAGOnly bool
+	}{
+		{
+			name:                "Disabled",
+			clusterVersion:      "4.16.3",
+			expectedMessage:     apiv1beta1.OCPRAGDisabledMessage,
+			expectedByokRAGOnly: true,
+		},
+		{
+			name:                "Enabled",
+			enableOCPRAG:        true,
+			clusterVersion:      "4.16.3",
+			expectedVersion:     ocpversion.Version416,
+			expectedMessage:     "OCP RAG version resolved: 4.16",
+			expectedByokRAGOnly: false,
+		},
+		{
+			name:                "Enabled with version override",
+			enableOCPRAG:        true,
+			versionOverride:     ocpversion.Version418,
+			clusterVersion:      "4.16.3",
+			expectedVersion:     ocpversion.Version418,
+			expectedMessage:     "OCP RAG version resolved: 4.18",
+			expectedByokRAGOnly: false,
+		},
+		{
+			name:            "Fallback",
+			enableOCPRAG:    true,
+			clusterVersion:  "4.17.3",
+			expectedVersion: ocpversion.Latest,
+			expectedMessage: fmt.Sprintf(apiv1beta1.OCPRAGVersionFallbackMessage, "4.17",
+				ocpversion.Supported),
+			expectedByokRAGOnly: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.EnableOCPRAG = tt.enableOCPRAG
+			instance.Spec.OCPRAGVersionOverride = tt.versionOverride
+
+			objs := newTestOLSOperatorObjects(instance)
+			setTestOLSOperatorVersion(t, objs, testOLSVersion)
+
+			cl := newTestClient(t, append(objs, instance, newTestClusterVersion(tt.clusterVersion))...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.OCPRAGCondition)
+			if cond == nil || cond.Status != corev1.ConditionTrue {
+				t.Fatalf("expected True OCPRAGCondition, got %+v", cond)
+			}
+			if cond.Message != tt.expectedMessage {
+				t.Errorf("OCPRAGCondition message = %q, want %q", cond.Message, tt.expectedMessage)
+			}
+			if instance.Status.ActiveOCPRAGVersion != tt.expectedVersion {
+				t.Errorf("ActiveOCPRAGVersion = %s, want %s", instance.Status.ActiveOCPRAGVersion, tt.expectedVersion)
+			}
+
+			olsConfig, err := getTestOLSConfig(t, cl)
+			if err != nil {
+				t.Fatalf("expected OLSConfig to be created, got %v", err)
+			}
+			byokRAGOnly, _, _ := uns.NestedBool(olsConfig.Object, "spec", "ols", "byokRAGOnly")
+			if byokRAGOnly != tt.expectedByokRAGOnly {
+				t.Errorf("byokRAGOnly = %v, want %v", byokRAGOnly, tt.expectedByokRAGOnly)
+			}
+
+			rag, _, _ := uns.NestedSlice(olsConfig.Object, "spec", "ols", "rag")
+			if expected := len(BuildRAGConfigs(instance, tt.expectedVersion)); len(rag) != expected {
+				t.Errorf("expected %d RAG sources, got %v", expected, rag)
+			}
+		})
+	}
+}
+
+func TestReconcileModelChangeRefreshesOLSConfig(t *testing.T) {
+	const refreshMarker = "test/refresh-marker"
+
+	tests := []struct {
+		name         string
+		currentModel string
+	}{
+		{
+			name:         "First deployment",
+			currentModel: "",
+		},
+		{
+			name:         "Model unchanged",
+			currentModel: "test-model",
+		},
+		{
+			name:         "Model changed",
+			currentModel: "previous-model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Status.CurrentModel = tt.currentModel
+
+			// The marker annotation only survives if the OLSConfig is patched in place.
+			olsConfig := newTestOLSConfig(instance, true)
+			olsConfig.SetAnnotations(map[string]string{refreshMarker: "true"})
+			_ = uns.SetNestedField(olsConfig.Object, tt.currentModel, "spec", "ols", "defaultModel")
+
+			objs := append(newTestOLSOperatorObjects(instance), instance, olsConfig)
+			cl := newTestClient(t, objs...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			olsConfig, err = getTestOLSConfig(t, cl)
+			if err != nil {
+				t.Fatalf("failed to get OLSConfig: %v", err)
+			}
+
+			if _, hasMarker := olsConfig.GetAnnotations()[refreshMarker]; !hasMarker {
+				t.Errorf("expected the OLSConfig to be patched in place, not recreated")
+			}
+			if model, _, _ := uns.NestedString(olsConfig.Object, "spec", "ols", "defaultModel"); model != instance.Spec.ModelName {
+				t.Errorf("defaultModel = %s, want %s", model, instance.Spec.ModelName)
+			}
+
+			if instance.Status.CurrentModel != instance.Spec.ModelName {
+				t.Errorf("CurrentModel = %s, want %s", instance.Status.CurrentModel, instance.Spec.ModelName)
+			}
+		})
+	}
+}
+
+func TestReconcileModelChangeWarnsInMemoryConversationCache(t *testing.T) {
+	tests := []struct {
+		name            string
+		cacheType       string
+		expectedWarning bool
+	}{
+		{
+			name:            "In-memory conversation cache",
+			cacheType:       OLSConfigInMemoryCacheType,
+			expectedWarning: true,
+		},
+		{
+			name:            "Persistent conversation cache",
+			cacheType:       "postgres",
+			expectedWarning: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Status.CurrentModel = "previous-model"
+
+			olsConfig := newTestOLSConfig(instance, true)
+			_ = uns.SetNestedField(olsConfig.Object, tt.cacheType, "spec", "ols", "conversationCache", "type")
+
+			objs := append(newTestOLSOperatorObjects(instance), instance, olsConfig)
+			cl := newTestClient(t, objs...)
+			recorder := record.NewFakeRecorder(10)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme(), Recorder: recorder}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.ConversationCacheCondition)
+			if tt.expectedWarning && (cond == nil || cond.Message != apiv1beta1.ConversationCacheInMemoryMessage) {
+				t.Errorf("expected the in-memory conversation cache warning, got %+v", cond)
+			} else if !tt.expectedWarning && cond != nil {
+				t.Errorf("expected no ConversationCacheCondition, got %+v", cond)
+			}
+
+			select {
+			case event := <-recorder.Events:
+				if !tt.expectedWarning || !strings.Contains(event, "ConversationHistoryLost") {
+					t.Errorf("unexpected event %q", event)
+				}
+			default:
+				if tt.expectedWarning {
+					t.Errorf("expected a ConversationHistoryLost event")
+				}
+			}
+		})
+	}
+}
+
+func TestReconcilePollIntervals(t *testing.T) {
+	tests := []struct {
+		name                  string
+		olsOperatorInstalling bool
+		olsConfigPollInterval time.Duration
+		installPollInterval   time.Duration
+		expected              time.Duration
+	}{
+		{
+			name:     "OLSConfig not ready with the default interval",
+			expected: DefaultOLSConfigPollInterval,
+		},
+		{
+			name:                  "OLSConfig not ready with a custom interval",
+			olsConfigPollInterval: time.Minute,
+			installPollInterval:   2 * time.Minute,
+			expected:              time.Minute,
+		},
+		{
+			name:                  "OLS operator installing with the default interval",
+			olsOperatorInstalling: true,
+			expected:              DefaultInstallPollInterval,
+		},
+		{
+			name:                  "OLS operator installing with a custom interval",
+			olsOperatorInstalling: true,
+			olsConfigPollInterval: time.Minute,
+			installPollInterval:   2 * time.Minute,
+			expected:              2 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+			// OLM has not created the CSV of the approved InstallPlan yet
+			objs := []client.Object{instance}
+			for _, obj := range newTestOLSOperatorObjects(instance) {
+				if _, isCSV := obj.(*operatorsv1alpha1.ClusterServiceVersion); !isCSV || !tt.olsOperatorInstalling {
+					objs = append(objs, obj)
+				}
+			}
+
+			cl := newTestClient(t, objs...)
+			r := &OpenStackLightspeedReconciler{
+				Client:                cl,
+				Scheme:                cl.Scheme(),
+				OLSConfigPollInterval: tt.olsConfigPollInterval,
+				InstallPollInterval:   tt.installPollInterval,
+			}
+
+			result, _, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+			if result.RequeueAfter != tt.expected {
+				t.Errorf("RequeueAfter = %v, want %v", result.RequeueAfter, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReconcileOLSOperatorCSVForbidden(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+	// Reject CSV updates the same way the API server does when the CSV lives outside of the
+	// namespace covered by our RBAC rules.
+	funcs := interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if csv, ok := obj.(*operatorsv1alpha1.ClusterServiceVersion); ok {
+				return k8s_errors.NewForbidden(
+					operatorsv1alpha1.Resource("clusterserviceversions"), csv.GetName(), nil)
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}
+
+	objs := newTestOLSOperatorObjects(instance)
+	for _, obj := range objs {
+		if _, ok := obj.(*operatorsv1alpha1.ClusterServiceVersion); ok {
+			obj.SetNamespace("openshift-operators")
+		}
+	}
+	cl := newTestClientWithInterceptor(t, funcs, append(objs, instance)...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	_, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OpenShiftLightspeedOperatorReadyCondition)
+	if cond == nil || cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected False OpenShiftLightspeedOperatorReadyCondition, got %+v", cond)
+	}
+	if cond.Reason != apiv1beta1.OpenShiftLightspeedOperatorCSVForbiddenReason {
+		t.Errorf("Reason = %s, want %s", cond.Reason, apiv1beta1.OpenShiftLightspeedOperatorCSVForbiddenReason)
+	}
+	for _, namespace := range []string{"openshift-operators", OLSOperatorNamespace} {
+		if !strings.Contains(cond.Message, namespace) {
+			t.Errorf("expected the message to mention the %s namespace, got %q", namespace, cond.Message)
+		}
+	}
+}
+
+func TestReconcileOLSOperatorDowngradeRefused(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", "0.9.0")
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+	cl := newTestClient(t, append(newTestOLSOperatorObjects(instance), instance)...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	_, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OpenShiftLightspeedOperatorReadyCondition)
+	if cond == nil || cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected False OpenShiftLightspeedOperatorReadyCondition, got %+v", cond)
+	}
+	if cond.Reason != apiv1beta1.OpenShiftLightspeedOperatorDowngradeReason {
+		t.Errorf("Reason = %s, want %s", cond.Reason, apiv1beta1.OpenShiftLightspeedOperatorDowngradeReason)
+	}
+	if !strings.Contains(cond.Message, "installed version 1.0.0 is newer than the recommended version 0.9.0") {
+		t.Errorf("expected the message to report both versions, got %q", cond.Message)
+	}
+}
+
+func TestReconcileOLSInstallPlanFailed(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+	// OLM failed to execute the approved InstallPlan, the CSV is never created
+	objs := []client.Object{instance}
+	for _, obj := range newTestOLSOperatorObjects(instance) {
+		if _, isCSV := obj.(*operatorsv1alpha1.ClusterServiceVersion); isCSV {
+			continue
+		}
+		if installPlan, ok := obj.(*operatorsv1alpha1.InstallPlan); ok {
+			installPlan.Status.Phase = operatorsv1alpha1.InstallPlanPhaseFailed
+			installPlan.Status.Conditions = []operatorsv1alpha1.InstallPlanCondition{{
+				Type:    operatorsv1alpha1.InstallPlanInstalled,
+				Status:  corev1.ConditionFalse,
+				Reason:  operatorsv1alpha1.InstallPlanReasonComponentFailed,
+				Message: "error creating csv lightspeed-operator.v1.0.6: admission webhook denied the request",
+			}}
+		}
+		objs = append(objs, obj)
+	}
+
+	cl := newTestClient(t, objs...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	result, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("expected no requeue for a failed InstallPlan, got %v", result.RequeueAfter)
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OpenShiftLightspeedOperatorReadyCondition)
+	if cond == nil || cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected False OpenShiftLightspeedOperatorReadyCondition, got %+v", cond)
+	}
+	if cond.Reason != apiv1beta1.OpenShiftLightspeedOperatorInstallPlanFailedReason {
+		t.Errorf("Reason = %s, want %s", cond.Reason, apiv1beta1.OpenShiftLightspeedOperatorInstallPlanFailedReason)
+	}
+	if !strings.Contains(cond.Message, "admission webhook denied the request") {
+		t.Errorf("expected the message to carry the InstallPlan failure, got %q", cond.Message)
+	}
+}
+
+func TestReconcileOLSSubscriptionFailure(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+	// OLM cannot reach the catalog, so there is neither an InstallPlan nor a CSV
+	var objs []client.Object
+	for _, obj := range newTestOLSOperatorObjects(instance) {
+		switch o := obj.(type) {
+		case *operatorsv1alpha1.Subscription:
+			o.Status.InstallPlanRef = nil
+			o.Status.Conditions = []operatorsv1alpha1.SubscriptionCondition{
+				{
+					Type:   operatorsv1alpha1.SubscriptionInstallPlanPending,
+					Status: corev1.ConditionTrue,
+				},
+				{
+					Type:    operatorsv1alpha1.SubscriptionCatalogSourcesUnhealthy,
+					Status:  corev1.ConditionTrue,
+					Reason:  "UnhealthyCatalogSourceFound",
+					Message: "targeted catalogsource openshift-marketplace/redhat-operators unhealthy",
+				},
+			}
+			objs = append(objs, o)
+		case *operatorsv1alpha1.InstallPlan, *operatorsv1alpha1.ClusterServiceVersion:
+		default:
+			objs = append(objs, obj)
+		}
+	}
+
+	cl := newTestClient(t, append(objs, instance)...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	res, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if res.RequeueAfter == 0 {
+		t.Errorf("expected the reconcile to be requeued")
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OpenShiftLightspeedOperatorReadyCondition)
+	if cond == nil || cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected False OpenShiftLightspeedOperatorReadyCondition, got %+v", cond)
+	}
+	if cond.Reason != condition.Reason(operatorsv1alpha1.SubscriptionCatalogSourcesUnhealthy) {
+		t.Errorf("Reason = %s, want %s", cond.Reason, operatorsv1alpha1.SubscriptionCatalogSourcesUnhealthy)
+	}
+	if !strings.Contains(cond.Message, "redhat-operators unhealthy") {
+		t.Errorf("expected the message to carry the Subscription condition message, got %q", cond.Message)
+	}
+}
+
+func TestReconcileOLSOperatorCSVStuckReplacing(t *testing.T) {
+	tests := []struct {
+		name              string
+		deleteStuckCSV    bool
+		owned             bool
+		lastTransition    time.Duration
+		expectedReason    condition.Reason
+		expectedCSVExists bool
+	}{
+		{
+			name:              "Replacing within the timeout",
+			owned:             true,
+			lastTransition:    OLSOperatorCSVReplacementTimeout / 2,
+			expectedReason:    condition.RequestedReason,
+			expectedCSVExists: true,
+		},
+		{
+			name:              "Stuck in Replacing",
+			owned:             true,
+			lastTransition:    2 * OLSOperatorCSVReplacementTimeout,
+			expectedReason:    apiv1beta1.OpenShiftLightspeedOperatorCSVStuckReason,
+			expectedCSVExists: true,
+		},
+		{
+			name:              "Stuck in Replacing and deleted",
+			deleteStuckCSV:    true,
+			owned:             true,
+			lastTransition:    2 * OLSOperatorCSVReplacementTimeout,
+			expectedReason:    apiv1beta1.OpenShiftLightspeedOperatorCSVStuckReason,
+			expectedCSVExists: false,
+		},
+		{
+			name:              "Stuck CSV not owned by the instance",
+			deleteStuckCSV:    true,
+			owned:             false,
+			lastTransition:    2 * OLSOperatorCSVReplacementTimeout,
+			expectedReason:    condition.RequestedReason,
+			expectedCSVExists: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.DeleteStuckOLSOperatorCSV = tt.deleteStuckCSV
+
+			objs := newTestOLSOperatorObjects(instance)
+			var stuckCSV *operatorsv1alpha1.ClusterServiceVersion
+			for _, obj := range objs {
+				if csv, ok := obj.(*operatorsv1alpha1.ClusterServiceVersion); ok {
+					stuckCSV = csv
+				}
+			}
+			stuckCSV.Status.Phase = operatorsv1alpha1.CSVPhaseReplacing
+			stuckCSV.Status.LastTransitionTime = ptr.To(metav1.NewTime(time.Now().Add(-tt.lastTransition)))
+			if !tt.owned {
+				// The instance still owns the Subscription, so it is not a user installed operator.
+				stuckCSV.SetOwnerReferences(nil)
+			}
+
+			// Keep the CSV ownership untouched, the instance would otherwise adopt the CSV.
+			funcs := interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if _, ok := obj.(*operatorsv1alpha1.ClusterServiceVersion); ok {
+						return nil
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+			}
+			cl := newTestClientWithInterceptor(t, funcs, append(objs, instance)...)
+			recorder := record.NewFakeRecorder(10)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme(), Recorder: recorder}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.OpenShiftLightspeedOperatorReadyCondition)
+			if cond == nil || cond.Status != corev1.ConditionFalse {
+				t.Fatalf("expected False OpenShiftLightspeedOperatorReadyCondition, got %+v", cond)
+			}
+			if cond.Reason != tt.expectedReason {
+				t.Errorf("Reason = %s, want %s", cond.Reason, tt.expectedReason)
+			}
+
+			isStuck := tt.expectedReason == apiv1beta1.OpenShiftLightspeedOperatorCSVStuckReason
+			select {
+			case event := <-recorder.Events:
+				if !isStuck || !strings.Contains(event, string(apiv1beta1.OpenShiftLightspeedOperatorCSVStuckReason)) {
+					t.Errorf("unexpected event %q", event)
+				}
+			default:
+				if isStuck {
+					t.Errorf("expected a %s event", apiv1beta1.OpenShiftLightspeedOperatorCSVStuckReason)
+				}
+			}
+
+			err = cl.Get(context.Background(), client.ObjectKeyFromObject(stuckCSV), &operatorsv1alpha1.ClusterServiceVersion{})
+			if tt.expectedCSVExists && err != nil {
+				t.Errorf("expected the CSV to be kept, got %v", err)
+			} else if !tt.expectedCSVExists && !k8s_errors.IsNotFound(err) {
+				t.Errorf("expected the stuck CSV to be deleted, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileRAGImageCompatibility(t *testing.T) {
+	tests := []struct {
+		name            string
+		olsVersion      string
+		expectedStatus  corev1.ConditionStatus
+		expectedMessage string
+	}{
+		{
+			name:           "Compatible OLS operator",
+			olsVersion:     "1.0.6",
+			expectedStatus: corev1.ConditionTrue,
+			expectedMessage: "RAG image quay.io/openstack-lightspeed/rag-content:os-docs-2025.2 is compatible " +
+				"with OpenShift Lightspeed operator 1.0.6",
+		},
+		{
+			name:           "OLS operator predating the RAG image format",
+			olsVersion:     "0.3.5",
+			expectedStatus: corev1.ConditionFalse,
+			expectedMessage: "RAG image quay.io/openstack-lightspeed/rag-content:os-docs-2025.2 is not compatible " +
+				"with OpenShift Lightspeed operator 0.3.5. Compatible OpenShift Lightspeed operator versions: >= 1.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.RAGImage = "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2"
+
+			objs := newTestOLSOperatorObjects(instance)
+			setTestOLSOperatorVersion(t, objs, tt.olsVersion)
+
+			cl := newTestClient(t, append(objs, instance)...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.RAGImageCompatibilityCondition)
+			if cond == nil || cond.Status != tt.expectedStatus {
+				t.Fatalf("expected %s RAGImageCompatibilityCondition, got %+v", tt.expectedStatus, cond)
+			}
+			if cond.Message != tt.expectedMessage {
+				t.Errorf("Message = %q, want %q", cond.Message, tt.expectedMessage)
+			}
+			if tt.expectedStatus == corev1.ConditionFalse && cond.Severity != condition.SeverityWarning {
+				t.Errorf("Severity = %q, want %q", cond.Severity, condition.SeverityWarning)
+			}
+		})
+	}
+}
+
+func TestReconcileRequireDigestPinnedImages(t *testing.T) {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		name           string
+		requirePinned  bool
+		ragImage       string
+		expectNotReady bool
+	}{
+		{
+			name:     "Tagged image accepted by default",
+			ragImage: "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2",
+		},
+		{
+			name:           "Tagged image rejected",
+			requirePinned:  true,
+			ragImage:       "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2",
+			expectNotReady: true,
+		},
+		{
+			name:          "Digest pinned image accepted",
+			requirePinned: true,
+			ragImage:      "quay.io/openstack-lightspeed/rag-content@" + digest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.RequireDigestPinnedImages = tt.requirePinned
+			instance.Spec.RAGImage = tt.ragImage
+
+			cl := newTestClient(t, append(newTestOLSOperatorObjects(instance), instance)...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+			if cond == nil {
+				t.Fatalf("expected OpenStackLightspeedReadyCondition")
+			}
+			isNotPinned := cond.Status == corev1.ConditionFalse && cond.Reason == apiv1beta1.ImageNotPinnedReason
+			if isNotPinned != tt.expectNotReady {
+				t.Errorf("expected ImageNotPinned = %v, got %+v", tt.expectNotReady, cond)
+			}
+
+			// A rejected image never reaches the OLSConfig
+			_, err = getTestOLSConfig(t, cl)
+			if tt.expectNotReady && !k8s_errors.IsNotFound(err) {
+				t.Errorf("expected no OLSConfig for a rejected RAG image, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileWaitsForOLSConfigCRD(t *testing.T) {
+	tests := []struct {
+		name string
+		crd  client.Object
+	}{
+		{
+			name: "CRD not established",
+			crd:  newTestOLSConfigCRD(false),
+		},
+		{
+			name: "CRD missing",
+			crd:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+			objs := []client.Object{instance}
+			for _, obj := range newTestOLSOperatorObjects(instance) {
+				if obj.GetName() == OLSConfigCRDName {
+					continue
+				}
+				objs = append(objs, obj)
+			}
+			if tt.crd != nil {
+				objs = append(objs, tt.crd)
+			}
+
+			cl := newTestClient(t, objs...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			res, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+			if res.RequeueAfter == 0 {
+				t.Errorf("expected the reconcile to be requeued")
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+			if cond == nil || cond.Status != corev1.ConditionFalse ||
+				cond.Message != apiv1beta1.OpenStackLightspeedWaitingOLSConfigCRDMessage {
+				t.Errorf("expected OpenStackLightspeedReadyCondition waiting for the CRD, got %+v", cond)
+			}
+
+			if _, err := getTestOLSConfig(t, cl); !k8s_errors.IsNotFound(err) {
+				t.Errorf("expected no OLSConfig before the CRD is established, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileOLSConfigAPIVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		versions        []interface{}
+		expectedStatus  corev1.ConditionStatus
+		expectedMessage string
+		expectOLSConfig bool
+	}{
+		{
+			name: "CRD stores the version we write",
+			versions: []interface{}{
+				map[string]interface{}{"name": OLSConfigDefaultAPIVersion, "served": true, "storage": true},
+			},
+			expectedStatus: corev1.ConditionTrue,
+			expectedMessage: fmt.Sprintf(apiv1beta1.OLSConfigAPIVersionMatchMessage,
+				OLSConfigDefaultAPIVersion),
+			expectOLSConfig: true,
+		},
+		{
+			name: "CRD converts the version we write",
+			versions: []interface{}{
+				map[string]interface{}{"name": OLSConfigDefaultAPIVersion, "served": true, "storage": false},
+				map[string]interface{}{"name": "v1", "served": true, "storage": true},
+			},
+			expectedStatus: corev1.ConditionTrue,
+			expectedMessage: fmt.Sprintf(apiv1beta1.OLSConfigAPIVersionConvertedMessage,
+				OLSConfigDefaultAPIVersion, "v1", "v1"),
+			expectOLSConfig: true,
+		},
+		{
+			name: "CRD serves only a newer version",
+			versions: []interface{}{
+				map[string]interface{}{"name": OLSConfigDefaultAPIVersion, "served": false, "storage": false},
+				map[string]interface{}{"name": "v1", "served": true, "storage": true},
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedMessage: fmt.Sprintf(apiv1beta1.OLSConfigAPIVersionMismatchMessage,
+				OLSConfigDefaultAPIVersion, []string{"v1"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+			crd := newTestOLSConfigCRD(true)
+			_ = uns.SetNestedSlice(crd.Object, tt.versions, "spec", "versions")
+
+			objs := []client.Object{instance, crd}
+			for _, obj := range newTestOLSOperatorObjects(instance) {
+				if obj.GetName() != OLSConfigCRDName {
+					objs = append(objs, obj)
+				}
+			}
+
+			cl := newTestClient(t, objs...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.OLSConfigAPIVersionCondition)
+			if cond == nil || cond.Status != tt.expectedStatus || cond.Message != tt.expectedMessage {
+				t.Errorf("expected OLSConfigAPIVersionCondition %s with message %q, got %+v",
+					tt.expectedStatus, tt.expectedMessage, cond)
+			}
+
+			_, err = getTestOLSConfig(t, cl)
+			if tt.expectOLSConfig && err != nil {
+				t.Errorf("expected the OLSConfig to be created, got %v", err)
+			} else if !tt.expectOLSConfig && !k8s_errors.IsNotFound(err) {
+				t.Errorf("expected no OLSConfig when its API version is not served, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileOLSConfigRejected(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+	rejectOLSConfig := func(obj client.Object) error {
+		if obj.GetObjectKind().GroupVersionKind().Kind != "OLSConfig" {
+			return nil
+		}
+		return k8s_errors.NewInvalid(schema.GroupKind{Group: OLSConfigGroup, Kind: "OLSConfig"}, OLSConfigName,
+			field.ErrorList{field.Invalid(field.NewPath("spec", "ols", "maxTokensForResponse"), int64(0),
+				"should be greater than or equal to 1")})
+	}
+	funcs := interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if err := rejectOLSConfig(obj); err != nil {
+				return err
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if err := rejectOLSConfig(obj); err != nil {
+				return err
+			}
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	}
+
+	cl := newTestClientWithInterceptor(t, funcs, append(newTestOLSOperatorObjects(instance), instance)...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	result, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("expected no requeue for a rejected OLSConfig, got %v", result.RequeueAfter)
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+	if cond == nil || cond.Status != corev1.ConditionFalse || cond.Severity != condition.SeverityError {
+		t.Fatalf("expected False OpenStackLightspeedReady condition with Error severity, got %+v", cond)
+	}
+	expectedMessage := "OLSConfig was rejected by the OLSConfig CRD validation: spec.ols.maxTokensForResponse: " +
+		"Invalid value: 0: should be greater than or equal to 1"
+	if cond.Message != expectedMessage {
+		t.Errorf("Message = %q, want %q", cond.Message, expectedMessage)
+	}
+}
+
+func TestReconcileOLSConfigPruned(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+	// Emulate an OLSConfig CRD schema that does not declare spec.ols.byokRAGOnly
+	pruneOLSConfig := func(obj client.Object) {
+		if olsConfig, ok := obj.(*uns.Unstructured); ok && olsConfig.GetKind() == "OLSConfig" {
+			uns.RemoveNestedField(olsConfig.Object, "spec", "ols", "byokRAGOnly")
+		}
+	}
+	funcs := interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			err := c.Create(ctx, obj, opts...)
+			pruneOLSConfig(obj)
+			return err
+		},
+	}
+
+	cl := newTestClientWithInterceptor(t, funcs, append(newTestOLSOperatorObjects(instance), instance)...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	result, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("expected no requeue for a pruned OLSConfig, got %v", result.RequeueAfter)
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+	if cond == nil || cond.Status != corev1.ConditionFalse || cond.Severity != condition.SeverityError {
+		t.Fatalf("expected False OpenStackLightspeedReady condition with Error severity, got %+v", cond)
+	}
+	expectedMessage := "OLSConfig fields were dropped by the API server: OLSConfig CRD schema does not " +
+		"declare the fields: spec.ols.byokRAGOnly"
+	if cond.Message != expectedMessage {
+		t.Errorf("Message = %q, want %q", cond.Message, expectedMessage)
+	}
+}
+
+func TestReconcileWaitForDependency(t *testing.T) {
+	tests := []struct {
+		name             string
+		inferenceService *uns.Unstructured
+		expectedStatus   corev1.ConditionStatus
+		expectedMessage  string
+	}{
+		{
+			name:            "Dependency not created",
+			expectedStatus:  corev1.ConditionFalse,
+			expectedMessage: "Waiting for InferenceService granite to be created",
+		},
+		{
+			// Dependencies are only looked up in the namespace of the instance
+			name:             "Dependency in another namespace",
+			inferenceService: newTestInferenceService("models", "granite", "https://granite.example.com", true, false),
+			expectedStatus:   corev1.ConditionFalse,
+			expectedMessage:  "Waiting for InferenceService granite to be created",
+		},
+		{
+			name:             "Dependency not ready",
+			inferenceService: newTestInferenceService(testInstanceNamespace, "granite", "https://granite.example.com", false, false),
+			expectedStatus:   corev1.ConditionFalse,
+			expectedMessage:  "Waiting for InferenceService granite to report Ready",
+		},
+		{
+			name:             "Dependency ready",
+			inferenceService: newTestInferenceService(testInstanceNamespace, "granite", "https://granite.example.com", true, false),
+			expectedStatus:   corev1.ConditionTrue,
+			expectedMessage:  "InferenceService granite reports Ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.WaitFor = &apiv1beta1.DependencyRef{
+				APIVersion: "serving.kserve.io/v1beta1",
+				Kind:       "InferenceService",
+				Name:       "granite",
+			}
+
+			objs := append(newTestOLSOperatorObjects(instance), instance)
+			if tt.inferenceService != nil {
+				objs = append(objs, tt.inferenceService)
+			}
+			cl := newTestClient(t, objs...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			result, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.DependencyReadyCondition)
+			if cond == nil || cond.Status != tt.expectedStatus {
+				t.Fatalf("expected %s DependencyReadyCondition, got %+v", tt.expectedStatus, cond)
+			}
+			if cond.Message != tt.expectedMessage {
+				t.Errorf("Message = %q, want %q", cond.Message, tt.expectedMessage)
+			}
+
+			_, olsConfigErr := getTestOLSConfig(t, cl)
+			if tt.expectedStatus == corev1.ConditionTrue {
+				if olsConfigErr != nil {
+					t.Errorf("expected OLSConfig to be created once the dependency is ready, got %v", olsConfigErr)
+				}
+				return
+			}
+
+			if cond.Reason != apiv1beta1.WaitingForDependencyReason {
+				t.Errorf("Reason = %q, want %q", cond.Reason, apiv1beta1.WaitingForDependencyReason)
+			}
+			if result.RequeueAfter != DependencyRetryInterval {
+				t.Errorf("RequeueAfter = %v, want %v", result.RequeueAfter, DependencyRetryInterval)
+			}
+			if !k8s_errors.IsNotFound(olsConfigErr) {
+				t.Errorf("expected no OLSConfig while waiting for the dependency, got %v", olsConfigErr)
+			}
+		})
+	}
+}
+
+func TestReconcileRAGImageArchitecture(t *testing.T) {
+	const ragImage = "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2"
+
+	tests := []struct {
+		name            string
+		imageArchs      []string
+		lookupErr       error
+		expectedMessage string
+	}{
+		{
+			name:            "Image built for all node architectures",
+			imageArchs:      []string{"amd64", "arm64"},
+			expectedMessage: "RAG image " + ragImage + " supports the architectures of the cluster nodes",
+		},
+		{
+			name:       "amd64 only image on a cluster with arm64 nodes",
+			imageArchs: []string{"amd64"},
+			expectedMessage: "RAG image " + ragImage + " is built for [amd64] and will likely not run on the " +
+				"cluster nodes with architecture [arm64]",
+		},
+		{
+			name:            "Image lookup failure",
+			lookupErr:       errors.New("registry unreachable"),
+			expectedMessage: "Unable to check the architectures of the RAG image " + ragImage + ": registry unreachable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.RAGImage = ragImage
+
+			objs := append(newTestOLSOperatorObjects(instance), instance,
+				newTestNode("worker-0", "amd64"), newTestNode("worker-1", "arm64"))
+			cl := newTestClient(t, objs...)
+			stubImageInspection(t, &ImageInspection{Architectures: tt.imageArchs, Err: tt.lookupErr})
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.RAGImageArchitectureCondition)
+			if cond == nil || cond.Status != corev1.ConditionTrue {
+				t.Fatalf("expected True RAGImageArchitectureCondition, got %+v", cond)
+			}
+			if cond.Message != tt.expectedMessage {
+				t.Errorf("Message = %q, want %q", cond.Message, tt.expectedMessage)
+			}
+
+			// The check is only a warning, OLS is configured regardless.
+			if _, err := getTestOLSConfig(t, cl); err != nil {
+				t.Errorf("expected OLSConfig to be created, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileDisableRAG(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+	instance.Spec.RAGImage = ""
+	instance.Spec.DisableRAG = true
+
+	cl := newTestClient(t, append(newTestOLSOperatorObjects(instance), instance)...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	_, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	if instance.Spec.RAGImage != "" {
+		t.Errorf("expected no default RAG image when RAG is disabled, got %s", instance.Spec.RAGImage)
+	}
+
+	olsConfig, err := getTestOLSConfig(t, cl)
+	if err != nil {
+		t.Fatalf("expected OLSConfig to be created, got %v", err)
+	}
+	if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "rag"); found {
+		t.Errorf("expected no RAG section when RAG is disabled")
+	}
+}
+
+func TestReconcileRAGBuildInfo(t *testing.T) {
+	const ragImage = "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2"
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+	instance.Spec.RAGImage = ragImage
+
+	cl := newTestClient(t, append(newTestOLSOperatorObjects(instance), instance)...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	lookups := 0
+	stubImageInspection(t, &ImageInspection{})
+	inspectImage = func(_ context.Context, sources []string, _ map[string]string) *ImageInspection {
+		lookups++
+		if !slices.Equal(sources, []string{ragImage}) {
+			return &ImageInspection{Err: fmt.Errorf("unexpected image sources %v", sources)}
+		}
+		return &ImageInspection{
+			Architectures: []string{"amd64"},
+			Labels: map[string]string{
+				"org.opencontainers.image.version":  "2025.2",
+				"org.opencontainers.image.revision": "0123abc",
+				"org.opencontainers.image.created":  "2025-09-01T10:00:00Z",
+			},
+		}
+	}
+
+	for range 2 {
+		if _, _, err := reconcileTestInstance(t, r); err != nil {
+			t.Fatalf("unexpected reconcile error: %v", err)
+		}
+	}
+
+	_, instance, _ = reconcileTestInstance(t, r)
+	buildInfo := instance.Status.RAGBuildInfo
+	if buildInfo == nil {
+		t.Fatalf("expected RAG build info in the status, got nil")
+	}
+	if buildInfo.Image != ragImage || buildInfo.Version != "2025.2" || buildInfo.Revision != "0123abc" {
+		t.Errorf("unexpected RAG build info %+v", buildInfo)
+	}
+	expectedBuiltAt := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+	if buildInfo.BuiltAt == nil || !buildInfo.BuiltAt.Time.Equal(expectedBuiltAt) {
+		t.Errorf("BuiltAt = %v, want %v", buildInfo.BuiltAt, expectedBuiltAt)
+	}
+
+	// The architecture check and the build info share a single registry lookup, which is only
+	// repeated when the RAG image changes
+	if lookups != 1 {
+		t.Errorf("expected a single image lookup, got %d", lookups)
+	}
+}
+
+func TestReconcileForceDelete(t *testing.T) {
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		expectedFinished bool
+	}{
+		{
+			name:             "Force delete annotation set",
+			annotations:      map[string]string{ForceDeleteAnnotation: "true"},
+			expectedFinished: true,
+		},
+		{
+			name:             "Force delete annotation not true",
+			annotations:      map[string]string{ForceDeleteAnnotation: "false"},
+			expectedFinished: false,
+		},
+		{
+			name:             "No annotation",
+			expectedFinished: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.DeletionTimestamp = ptr.To(metav1.Now())
+			instance.SetAnnotations(tt.annotations)
+
+			// The finalizer of the OLS operator keeps the OLSConfig removal in progress
+			olsConfig := newTestOLSConfig(instance, true)
+			olsConfig.SetFinalizers(append(olsConfig.GetFinalizers(), "ols.openshift.io/finalizer"))
+
+			objs := append(newTestOLSOperatorObjects(instance), instance, olsConfig)
+			cl := newTestClient(t, objs...)
+			recorder := record.NewFakeRecorder(10)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme(), Recorder: recorder}
+
+			key := types.NamespacedName{Name: testInstanceName, Namespace: testInstanceNamespace}
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			err = cl.Get(context.Background(), key, &apiv1beta1.OpenStackLightspeed{})
+			if tt.expectedFinished {
+				if !k8s_errors.IsNotFound(err) {
+					t.Errorf("expected the instance to be deleted in a single pass, got %v", err)
+				}
+				if result.RequeueAfter != 0 {
+					t.Errorf("expected no requeue, got %v", result.RequeueAfter)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("expected the instance to wait for the OLSConfig removal, got %v", err)
+				}
+				if result.RequeueAfter != DefaultInstallPollInterval {
+					t.Errorf("expected a requeue after %v, got %v", DefaultInstallPollInterval, result.RequeueAfter)
+				}
+			}
+
+			select {
+			case event := <-recorder.Events:
+				if !tt.expectedFinished || !strings.Contains(event, "ForceDeleted") {
+					t.Errorf("unexpected event %q", event)
+				}
+			default:
+				if tt.expectedFinished {
+					t.Errorf("expected a ForceDeleted event")
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileDeleteKeepsForeignOLSConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		ownerLabel string
+	}{
+		{
+			name:       "User owned OLSConfig",
+			ownerLabel: "",
+		},
+		{
+			name:       "OLSConfig owned by another instance",
+			ownerLabel: "another-instance-uid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.DeletionTimestamp = ptr.To(metav1.Now())
+
+			olsConfig := newTestOLSConfig(instance, true)
+			olsConfig.SetFinalizers(nil)
+			olsConfig.SetLabels(nil)
+			if tt.ownerLabel != "" {
+				olsConfig.SetLabels(map[string]string{OpenStackLightspeedOwnerIDLabel: tt.ownerLabel})
+			}
+
+			cl := newTestClient(t, instance, olsConfig)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			key := types.NamespacedName{Name: testInstanceName, Namespace: testInstanceNamespace}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			olsConfig, err := getTestOLSConfig(t, cl)
+			if err != nil {
+				t.Fatalf("expected the OLSConfig to be kept, got %v", err)
+			}
+			if !olsConfig.GetDeletionTimestamp().IsZero() {
+				t.Errorf("expected the OLSConfig not to be deleted")
+			}
+
+			// The instance deletion itself must still complete.
+			err = cl.Get(context.Background(), key, &apiv1beta1.OpenStackLightspeed{})
+			if !k8s_errors.IsNotFound(err) {
+				t.Errorf("expected the instance to be deleted, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileRAGImageReady(t *testing.T) {
+	const ragImage = "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2"
+
+	tests := []struct {
+		name            string
+		pods            []client.Object
+		expectedStatus  corev1.ConditionStatus
+		expectedMessage string
+	}{
+		{
+			name: "No OLS pod using the RAG image yet",
+			pods: []client.Object{newTestRAGPod("other-pod", "other-image:latest", "ImagePullBackOff", "")},
+		},
+		{
+			name:            "RAG image pulled",
+			pods:            []client.Object{newTestRAGPod("lightspeed-app-server-0", ragImage, "", "")},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedMessage: "RAG image is ready",
+		},
+		{
+			name: "RAG image pull back-off",
+			pods: []client.Object{newTestRAGPod("lightspeed-app-server-0", ragImage,
+				"ImagePullBackOff", `Back-off pulling image "`+ragImage+`"`)},
+			expectedStatus: corev1.ConditionFalse,
+			expectedMessage: "RAG image " + ragImage + " cannot be pulled for pod lightspeed-app-server-0: " +
+				`ImagePullBackOff: Back-off pulling image "` + ragImage + `"`,
+		},
+		{
+			name: "Only one of the OLS pods fails to pull",
+			pods: []client.Object{
+				newTestRAGPod("lightspeed-app-server-0", ragImage, "", ""),
+				newTestRAGPod("lightspeed-app-server-1", ragImage, "ErrImagePull", "manifest unknown"),
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedMessage: "RAG image " + ragImage + " cannot be pulled for pod lightspeed-app-server-1: " +
+				"ErrImagePull: manifest unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.RAGImage = ragImage
+
+			objs := append(newTestOLSOperatorObjects(instance), instance)
+			cl := newTestClient(t, append(objs, tt.pods...)...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.RAGImageReadyCondition)
+			if tt.expectedStatus == "" {
+				if cond != nil {
+					t.Fatalf("expected no RAGImageReadyCondition, got %+v", cond)
+				}
+				return
+			}
+
+			if cond == nil || cond.Status != tt.expectedStatus {
+				t.Fatalf("expected %s RAGImageReadyCondition, got %+v", tt.expectedStatus, cond)
+			}
+			if cond.Message != tt.expectedMessage {
+				t.Errorf("Message = %q, want %q", cond.Message, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+func TestReconcileWaitsForVectorDB(t *testing.T) {
+	const ragImage = "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2"
+
+	readyPod := newTestRAGPod("lightspeed-app-server-0", ragImage, "", "")
+	readyPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+
+	tests := []struct {
+		name           string
+		pods           []client.Object
+		waitingMessage bool
+	}{
+		{
+			name:           "Vector DB pod pending",
+			pods:           []client.Object{newTestRAGPod("lightspeed-app-server-0", ragImage, "ContainerCreating", "")},
+			waitingMessage: true,
+		},
+		{
+			name:           "Vector DB pod ready",
+			pods:           []client.Object{readyPod},
+			waitingMessage: false,
+		},
+		{
+			name:           "No vector DB pod yet",
+			waitingMessage: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.RAGImage = ragImage
+
+			objs := append(newTestOLSOperatorObjects(instance), instance)
+			cl := newTestClient(t, append(objs, tt.pods...)...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			result, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+			if result.RequeueAfter == 0 {
+				t.Errorf("expected a requeue while OLSConfig is not ready, got %+v", result)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+			if cond == nil || cond.Status == corev1.ConditionTrue {
+				t.Fatalf("expected OpenStackLightspeedReadyCondition not to be True, got %+v", cond)
+			}
+
+			waitingMessage := cond.Message == apiv1beta1.OpenStackLightspeedWaitingVectorDBMessage
+			if waitingMessage != tt.waitingMessage {
+				t.Errorf("Message = %q, waiting for vector DB message expected: %v", cond.Message, tt.waitingMessage)
+			}
+		})
+	}
+}
+
+func TestReconcileOCPRAGUnsupportedVersionRejected(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", "latest")
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+	instance.Spec.EnableOCPRAG = true
+	instance.Spec.OCPRAGFallbackBehavior = apiv1beta1.OCPRAGFallbackBehaviorReject
+	cl := newTestClient(t, instance, newTestClusterVersion("4.17.3"))
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	_, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OCPRAGCondition)
+	if cond == nil || cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected False OCPRAGCondition, got %+v", cond)
+	}
+	if !strings.HasPrefix(cond.Message, "Cluster version 4.17 is not supported") {
+		t.Errorf("unexpected OCPRAGCondition message %q", cond.Message)
+	}
+	if instance.Status.ActiveOCPRAGVersion != "" {
+		t.Errorf("ActiveOCPRAGVersion = %s, want empty", instance.Status.ActiveOCPRAGVersion)
+	}
+}
+
+func TestReconcileOCPRAGUnparsableVersion(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", "latest")
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+	instance.Spec.EnableOCPRAG = true
+	cl := newTestClient(t, instance, newTestClusterVersion("v4-ec.next"))
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	_, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OCPRAGCondition)
+	if cond == nil || cond.Status != corev1.ConditionTrue {
+		t.Fatalf("expected True OCPRAGCondition, got %+v", cond)
+	}
+	if !strings.Contains(cond.Message, `"v4-ec.next" could not be parsed`) {
+		t.Errorf("expected the raw version in the OCPRAGCondition message, got %q", cond.Message)
+	}
+	if instance.Status.ActiveOCPRAGVersion != ocpversion.Latest {
+		t.Errorf("ActiveOCPRAGVersion = %s, want %s", instance.Status.ActiveOCPRAGVersion, ocpversion.Latest)
+	}
+}
+
+func TestReconcileOCPRAGSkipPreRelease(t *testing.T) {
+	tests := []struct {
+		name                  string
+		clusterVersion        string
+		skipPreRelease        bool
+		expectedActive        string
+		expectedMessagePrefix string
+	}{
+		{
+			name:                  "GA version",
+			clusterVersion:        "4.18.3",
+			skipPreRelease:        true,
+			expectedActive:        ocpversion.Version418,
+			expectedMessagePrefix: "OCP RAG version resolved",
+		},
+		{
+			name:                  "Pre-release version skipped",
+			clusterVersion:        "4.99.0-0.nightly-2024-01-15-123456",
+			skipPreRelease:        true,
+			expectedMessagePrefix: "OCP RAG is disabled for the pre-release cluster version 4.99.0-0.nightly",
+		},
+		{
+			name:                  "Pre-release version not skipped",
+			clusterVersion:        "4.99.0-0.nightly-2024-01-15-123456",
+			expectedActive:        ocpversion.Latest,
+			expectedMessagePrefix: "Cluster version 4.99 is not explicitly supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", "latest")
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.EnableOCPRAG = true
+			instance.Spec.OCPRAGSkipPreRelease = tt.skipPreRelease
+			cl := newTestClient(t, instance, newTestClusterVersion(tt.clusterVersion))
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.OCPRAGCondition)
+			if cond == nil || cond.Status != corev1.ConditionTrue {
+				t.Fatalf("expected True OCPRAGCondition, got %+v", cond)
+			}
+			if !strings.HasPrefix(cond.Message, tt.expectedMessagePrefix) {
+				t.Errorf("OCPRAGCondition message = %q, want prefix %q", cond.Message, tt.expectedMessagePrefix)
+			}
+			if instance.Status.ActiveOCPRAGVersion != tt.expectedActive {
+				t.Errorf("ActiveOCPRAGVersion = %q, want %q", instance.Status.ActiveOCPRAGVersion, tt.expectedActive)
+			}
+		})
+	}
+}
+
+func TestReconcileOLSGates(t *testing.T) {
+	openStackContext := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "openstack-context", Namespace: testInstanceNamespace},
+		Data:       map[string]string{"services": testOpenStackContext},
+	}
+
+	tests := []struct {
+		name            string
+		contextKey      string
+		objs            []client.Object
+		expectedMessage string
+	}{
+		{
+			name:       "All dependencies present",
+			contextKey: "services",
+			objs:       []client.Object{openStackContext},
+		},
+		{
+			name:       "OpenStack context ConfigMap missing",
+			contextKey: "services",
+			expectedMessage: "OpenStack context ConfigMap openstack-context not found in namespace " +
+				testInstanceNamespace,
+		},
+		{
+			name:       "OpenStack context key missing",
+			contextKey: "release",
+			objs:       []client.Object{openStackContext},
+			expectedMessage: "OpenStack context ConfigMap openstack-context in namespace " +
+				testInstanceNamespace + " is missing the keys [release]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.OpenStackContextRef = &apiv1beta1.OpenStackContextRef{
+				Name: "openstack-context",
+				Key:  tt.contextKey,
+			}
+
+			objs := append(newTestOLSOperatorObjects(instance), instance)
+			cl := newTestClient(t, append(objs, tt.objs...)...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			res, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			_, err = getTestOLSConfig(t, cl)
+			if tt.expectedMessage == "" {
+				if err != nil {
+					t.Errorf("expected the OLSConfig to be created, got %v", err)
+				}
+				return
+			}
+
+			if !k8s_errors.IsNotFound(err) {
+				t.Errorf("expected no OLSConfig with a missing dependency, got %v", err)
+			}
+			if res.RequeueAfter == 0 {
+				t.Errorf("expected the reconcile to be requeued")
+			}
+			cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+			if cond == nil || cond.Status != corev1.ConditionFalse || cond.Message != tt.expectedMessage {
+				t.Errorf("expected Ready False with message %q, got %+v", tt.expectedMessage, cond)
+			}
+		})
+	}
+}
+
+func TestReconcileConversationCacheSecret(t *testing.T) {
+	credentialsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ols-postgres-credentials", Namespace: OLSOperatorNamespace},
+	}
+
+	tests := []struct {
+		name            string
+		cacheType       string
+		objs            []client.Object
+		expectOLSConfig bool
+	}{
+		{
+			name:            "Postgres credentials secret present",
+			cacheType:       apiv1beta1.ConversationCacheTypePostgres,
+			objs:            []client.Object{credentialsSecret},
+			expectOLSConfig: true,
+		},
+		{
+			name:      "Postgres credentials secret missing",
+			cacheType: apiv1beta1.ConversationCacheTypePostgres,
+		},
+		{
+			name:            "Memory backend without secret",
+			cacheType:       apiv1beta1.ConversationCacheTypeMemory,
+			expectOLSConfig: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.ConversationCache = &apiv1beta1.ConversationCache{
+				Type:                 tt.cacheType,
+				CredentialsSecretRef: "ols-postgres-credentials",
+			}
+
+			objs := append(newTestOLSOperatorObjects(instance), instance)
+			cl := newTestClient(t, append(objs, tt.objs...)...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			res, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			_, err = getTestOLSConfig(t, cl)
+			if tt.expectOLSConfig {
+				if err != nil {
+					t.Errorf("expected the OLSConfig to be created, got %v", err)
+				}
+				return
+			}
+
+			if !k8s_errors.IsNotFound(err) {
+				t.Errorf("expected no OLSConfig without the conversation cache credentials, got %v", err)
+			}
+			if res.RequeueAfter != 0 {
+				t.Errorf("expected the watched secret not to be polled, got RequeueAfter %v", res.RequeueAfter)
+			}
+			cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+			if cond == nil || cond.Status != corev1.ConditionFalse ||
+				!strings.Contains(cond.Message, "ols-postgres-credentials not found") {
+				t.Errorf("expected Ready False about the missing secret, got %+v", cond)
+			}
+		})
+	}
+}
+
+func TestReconcileAPITLSSecret(t *testing.T) {
+	tests := []struct {
+		name            string
+		secretData      map[string][]byte
+		secretMissing   bool
+		expectedMessage string
+	}{
+		{
+			name:       "Certificate secret present",
+			secretData: map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")},
+		},
+		{
+			name:            "Certificate secret missing",
+			secretMissing:   true,
+			expectedMessage: "API TLS secret ols-api-cert not found in namespace " + OLSOperatorNamespace,
+		},
+		{
+			name:       "Key missing",
+			secretData: map[string][]byte{"tls.crt": []byte("cert")},
+			expectedMessage: "API TLS secret ols-api-cert in namespace " + OLSOperatorNamespace +
+				" is missing the keys [tls.key]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.APITLS = &apiv1beta1.APITLS{SecretName: "ols-api-cert"}
+
+			objs := append(newTestOLSOperatorObjects(instance), instance)
+			if !tt.secretMissing {
+				objs = append(objs, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "ols-api-cert", Namespace: OLSOperatorNamespace},
+					Data:       tt.secretData,
+				})
+			}
+			cl := newTestClient(t, objs...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			res, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			_, err = getTestOLSConfig(t, cl)
+			if tt.expectedMessage == "" {
+				if err != nil {
+					t.Errorf("expected the OLSConfig to be created, got %v", err)
+				}
+				return
+			}
+
+			if !k8s_errors.IsNotFound(err) {
+				t.Errorf("expected no OLSConfig without a complete API TLS secret, got %v", err)
+			}
+			if res.RequeueAfter != 0 {
+				t.Errorf("expected the watched secret not to be polled, got RequeueAfter %v", res.RequeueAfter)
+			}
+			cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+			if cond == nil || cond.Status != corev1.ConditionFalse || !strings.Contains(cond.Message, tt.expectedMessage) {
+				t.Errorf("expected Ready False with %q, got %+v", tt.expectedMessage, cond)
+			}
+		})
+	}
+}
+
+func TestReconcileLLMCredentials(t *testing.T) {
+	tests := []struct {
+		name               string
+		credentialsMissing bool
+		credentialsData    map[string][]byte
+		providers          []apiv1beta1.ProviderSpec
+		expectedMessage    string
+	}{
+		{
+			name: "Credentials secret present",
+		},
+		{
+			name:               "Credentials secret missing",
+			credentialsMissing: true,
+			expectedMessage: "LLM credentials secret llm-credentials of provider " +
+				OpenStackLightspeedDefaultProvider + " not found in namespace " + OLSOperatorNamespace,
+		},
+		{
+			name:            "API token missing",
+			credentialsData: map[string][]byte{"token": []byte("test-token")},
+			expectedMessage: "LLM credentials secret llm-credentials of provider " +
+				OpenStackLightspeedDefaultProvider + " in namespace " + OLSOperatorNamespace +
+				" is missing the keys [apitoken]",
+		},
+		{
+			name: "Credentials secret of a provider missing",
+			providers: []apiv1beta1.ProviderSpec{{
+				Name:              "fallback",
+				Type:              "openai",
+				URL:               "https://fallback.example.com/v1",
+				CredentialsSecret: "fallback-credentials",
+				Models:            []apiv1beta1.ProviderModel{{Name: "granite"}},
+			}},
+			expectedMessage: "LLM credentials secret fallback-credentials of provider fallback not found in " +
+				"namespace " + OLSOperatorNamespace,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.Providers = tt.providers
+
+			objs := newTestOLSOperatorObjects(instance)
+			for i, obj := range objs {
+				if secret, isSecret := obj.(*corev1.Secret); isSecret && tt.credentialsData != nil {
+					secret.Data = tt.credentialsData
+				} else if isSecret && tt.credentialsMissing {
+					objs = slices.Delete(objs, i, i+1)
+					break
+				}
+			}
+
+			cl := newTestClient(t, append(objs, instance)...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			res, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			_, err = getTestOLSConfig(t, cl)
+			if tt.expectedMessage == "" {
+				if err != nil {
+					t.Errorf("expected the OLSConfig to be created, got %v", err)
+				}
+				return
+			}
+
+			if !k8s_errors.IsNotFound(err) {
+				t.Errorf("expected no OLSConfig without valid LLM credentials, got %v", err)
+			}
+			if res.RequeueAfter != 0 {
+				t.Errorf("expected the watched secret not to be polled, got RequeueAfter %v", res.RequeueAfter)
+			}
+			cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+			if cond == nil || cond.Status != corev1.ConditionFalse ||
+				cond.Reason != apiv1beta1.LLMCredentialsMissingSecretReason {
+				t.Fatalf("expected Ready False with reason MissingSecret, got %+v", cond)
+			}
+			if cond.Message != tt.expectedMessage {
+				t.Errorf("Message = %q, want %q", cond.Message, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+func TestReconcileProgress(t *testing.T) {
+	t.Run("Successful reconcile", func(t *testing.T) {
+		t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+		instance := newTestInstance()
+		instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+		previousSuccess := metav1.NewTime(time.Now().Add(-time.Hour))
+		instance.Status.LastSuccessfulReconcileTime = &previousSuccess
+
+		objs := append(newTestOLSOperatorObjects(instance), instance, newTestOLSConfig(instance, true))
+		cl := newTestClient(t, objs...)
+		r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+		_, instance, err := reconcileTestInstance(t, r)
+		if err != nil {
+			t.Fatalf("unexpected reconcile error: %v", err)
+		}
+
+		lastSuccess := instance.Status.LastSuccessfulReconcileTime
+		if lastSuccess == nil || !lastSuccess.After(previousSuccess.Time) {
+			t.Errorf("expected LastSuccessfulReconcileTime to be updated, got %v", lastSuccess)
+		}
+		if _, found := lastSuccessfulReconciles.Get(client.ObjectKeyFromObject(instance)); !found {
+			t.Errorf("expected the last successful reconcile to be exposed as a metric")
+		}
+
+		cond := instance.Status.Conditions.Get(apiv1beta1.ReconcileProgressCondition)
+		if cond == nil || cond.Status != corev1.ConditionTrue {
+			t.Errorf("expected True ReconcileProgressCondition, got %+v", cond)
+		}
+	})
+
+	t.Run("Stalled reconcile", func(t *testing.T) {
+		t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+		instance := newTestInstance()
+		instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+		previousSuccess := metav1.NewTime(time.Now().Add(-2 * ReconcileStaleThreshold).Truncate(time.Second))
+		instance.Status.LastSuccessfulReconcileTime = &previousSuccess
+
+		// The OLS operator is not installed, so the reconcile keeps waiting
+		cl := newTestClient(t, instance)
+		r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+		_, instance, err := reconcileTestInstance(t, r)
+		if err != nil {
+			t.Fatalf("unexpected reconcile error: %v", err)
+		}
+
+		if lastSuccess := instance.Status.LastSuccessfulReconcileTime; lastSuccess == nil ||
+			!lastSuccess.Equal(&previousSuccess) {
+			t.Errorf("expected LastSuccessfulReconcileTime to be kept, got %v", lastSuccess)
+		}
+
+		cond := instance.Status.Conditions.Get(apiv1beta1.ReconcileProgressCondition)
+		if cond == nil || cond.Status != corev1.ConditionFalse || cond.Reason != apiv1beta1.ReconcileStalledReason {
+			t.Errorf("expected False ReconcileProgressCondition with reason %s, got %+v",
+				apiv1beta1.ReconcileStalledReason, cond)
+		}
+	})
+}
+
+func TestReconcileClusterProxy(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+	proxy := newTestClusterProxy("http://proxy.example.com:3128")
+	objs := append(newTestOLSOperatorObjects(instance), instance, proxy)
+	cl := newTestClient(t, objs...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	getProxyConfig := func(t *testing.T) map[string]interface{} {
+		t.Helper()
+
+		olsConfig, err := getTestOLSConfig(t, cl)
+		if err != nil {
+			t.Fatalf("failed to get OLSConfig: %v", err)
+		}
+		proxyConfig, _, _ := uns.NestedMap(olsConfig.Object, "spec", "ols", "proxyConfig")
+		return proxyConfig
+	}
+
+	if _, _, err := reconcileTestInstance(t, r); err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	// The trusted CA bundle is not injected yet, only the proxy URL is configured.
+	expected := map[string]interface{}{"proxyURL": "http://proxy.example.com:3128"}
+	if proxyConfig := getProxyConfig(t); !equality.Semantic.DeepEqual(proxyConfig, expected) {
+		t.Errorf("proxyConfig = %v, want %v", proxyConfig, expected)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: OLSOperatorNamespace, Name: TrustedCAConfigMapName}
+	if err := cl.Get(context.Background(), key, configMap); err != nil {
+		t.Fatalf("expected the trusted CA ConfigMap to be created, got %v", err)
+	}
+	if configMap.Labels["config.openshift.io/inject-trusted-cabundle"] != "true" {
+		t.Errorf("expected the trusted CA ConfigMap to request the CA bundle injection, got %v", configMap.Labels)
+	}
+
+	// The cluster network operator injects the bundle and the proxy moves to a new URL.
+	configMap.Data = map[string]string{TrustedCABundleKey: "-----BEGIN CERTIFICATE-----"}
+	if err := cl.Update(context.Background(), configMap); err != nil {
+		t.Fatalf("failed to update the trusted CA ConfigMap: %v", err)
+	}
+
+	// The bundle injection enqueues the instance although it lives in another namespace.
+	requests := r.NotifyAllOpenStackLightspeeds(context.Background(), configMap)
+	if len(requests) != 1 || requests[0].NamespacedName != client.ObjectKeyFromObject(instance) {
+		t.Fatalf("expected the trusted CA ConfigMap change to enqueue %s, got %v", instance.Name, requests)
+	}
+
+	_ = uns.SetNestedField(proxy.Object, "https://proxy.example.com:3129", "status", "httpsProxy")
+	if err := cl.Update(context.Background(), proxy); err != nil {
+		t.Fatalf("failed to update the cluster Proxy: %v", err)
+	}
+
+	// A Proxy change enqueues the instance.
+	requests = r.NotifyAllOpenStackLightspeeds(context.Background(), proxy)
+	if len(requests) != 1 || requests[0].NamespacedName != client.ObjectKeyFromObject(instance) {
+		t.Fatalf("expected the Proxy change to enqueue %s, got %v", instance.Name, requests)
+	}
+
+	if _, _, err := reconcileTestInstance(t, r); err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	expected = map[string]interface{}{
+		"proxyURL": "https://proxy.example.com:3129",
+		"proxyCACertificate": map[string]interface{}{
+			"name": TrustedCAConfigMapName,
+			"key":  TrustedCABundleKey,
+		},
+	}
+	if proxyConfig := getProxyConfig(t); !equality.Semantic.DeepEqual(proxyConfig, expected) {
+		t.Errorf("proxyConfig = %v, want %v", proxyConfig, expected)
+	}
+
+	// The proxy is removed from the cluster.
+	if err := cl.Delete(context.Background(), proxy); err != nil {
+		t.Fatalf("failed to delete the cluster Proxy: %v", err)
+	}
+	if _, _, err := reconcileTestInstance(t, r); err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if proxyConfig := getProxyConfig(t); proxyConfig != nil {
+		t.Errorf("expected the proxy configuration to be dropped, got %v", proxyConfig)
+	}
+}
+
+func TestReconcileOLSConfigObservedGeneration(t *testing.T) {
+	tests := []struct {
+		name               string
+		observedGeneration *int64
+		lagSince           *time.Duration
+		expectedStatus     corev1.ConditionStatus
+		expectedReason     condition.Reason
+		expectRequeue      bool
+	}{
+		{
+			name:               "OLS caught up",
+			observedGeneration: ptr.To(int64(3)),
+			expectedStatus:     corev1.ConditionTrue,
+			expectedReason:     condition.ReadyReason,
+		},
+		{
+			name:               "OLS lagging behind the last patch",
+			observedGeneration: ptr.To(int64(2)),
+			expectedStatus:     corev1.ConditionFalse,
+			expectedReason:     condition.RequestedReason,
+			expectRequeue:      true,
+		},
+		{
+			name:               "OLS lagging for too long",
+			observedGeneration: ptr.To(int64(2)),
+			lagSince:           ptr.To(2 * OLSConfigObservedGenerationTimeout),
+			expectedStatus:     corev1.ConditionFalse,
+			expectedReason:     apiv1beta1.OLSConfigBehindReason,
+			expectRequeue:      true,
+		},
+		{
+			name: "OLS does not report the observedGeneration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			if tt.lagSince != nil {
+				lagCond := condition.FalseCondition(
+					apiv1beta1.OLSConfigSyncedCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					apiv1beta1.OLSConfigSyncingMessage,
+				)
+				lagCond.LastTransitionTime = metav1.NewTime(time.Now().Add(-*tt.lagSince))
+				instance.Status.Conditions = condition.Conditions{*lagCond}
+			}
+
+			olsConfig := newTestOLSConfig(instance, true)
+			olsConfig.SetGeneration(3)
+			if tt.observedGeneration != nil {
+				_ = uns.SetNestedField(olsConfig.Object, *tt.observedGeneration, "status", "observedGeneration")
+			}
+
+			cl := newTestClient(t, append(newTestOLSOperatorObjects(instance), instance, olsConfig)...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			result, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+			if (result.RequeueAfter != 0) != tt.expectRequeue {
+				t.Errorf("RequeueAfter = %s, requeue expected: %v", result.RequeueAfter, tt.expectRequeue)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.OLSConfigSyncedCondition)
+			if tt.expectedStatus == "" {
+				if cond != nil {
+					t.Fatalf("expected no OLSConfigSyncedCondition, got %+v", cond)
+				}
+				return
+			}
+
+			if cond == nil || cond.Status != tt.expectedStatus {
+				t.Fatalf("expected %s OLSConfigSyncedCondition, got %+v", tt.expectedStatus, cond)
+			}
+			if cond.Reason != tt.expectedReason {
+				t.Errorf("Reason = %s, want %s", cond.Reason, tt.expectedReason)
+			}
+			if instance.Status.OLSConfigGeneration != 3 || instance.Status.OLSConfigObservedGeneration != *tt.observedGeneration {
+				t.Errorf("OLSConfig generations = %d/%d, want 3/%d", instance.Status.OLSConfigGeneration,
+					instance.Status.OLSConfigObservedGeneration, *tt.observedGeneration)
+			}
+		})
+	}
+}
+
+func TestReconcileRAGlessFallback(t *testing.T) {
+	const ragImage = "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2"
+
+	tests := []struct {
+		name           string
+		allowFallback  bool
+		podAge         time.Duration
+		expectFallback bool
+	}{
+		{
+			name:           "RAG pod failing for too long",
+			allowFallback:  true,
+			podAge:         2 * RAGPodReadyTimeout,
+			expectFallback: true,
+		},
+		{
+			name:          "RAG pod still starting",
+			allowFallback: true,
+			podAge:        time.Minute,
+		},
+		{
+			name:   "Fallback not allowed",
+			podAge: 2 * RAGPodReadyTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.RAGImage = ragImage
+			instance.Spec.AllowRAGlessFallback = tt.allowFallback
+
+			pod := newTestRAGPod("lightspeed-app-server-0", ragImage, "CrashLoopBackOff", "")
+			pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-tt.podAge))
+
+			objs := append(newTestOLSOperatorObjects(instance), instance, pod)
+			cl := newTestClient(t, objs...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			// The first reconcile detects the failing RAG pod, the second one patches the OLSConfig
+			if _, _, err := reconcileTestInstance(t, r); err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			if fallback := instance.Status.RAGlessFallbackImage == ragImage; fallback != tt.expectFallback {
+				t.Errorf("RAGlessFallbackImage = %q, fallback expected: %v", instance.Status.RAGlessFallbackImage, tt.expectFallback)
+			}
+
+			olsConfig, err := getTestOLSConfig(t, cl)
+			if err != nil {
+				t.Fatalf("failed to get OLSConfig: %v", err)
+			}
+			_, hasRAG, _ := uns.NestedSlice(olsConfig.Object, "spec", "ols", "rag")
+			if hasRAG == tt.expectFallback {
+				t.Errorf("OLSConfig RAG sources present: %v, fallback expected: %v", hasRAG, tt.expectFallback)
+			}
+			byokRAGOnly, _, _ := uns.NestedBool(olsConfig.Object, "spec", "ols", "byokRAGOnly")
+			if byokRAGOnly == tt.expectFallback {
+				t.Errorf("byokRAGOnly = %v, fallback expected: %v", byokRAGOnly, tt.expectFallback)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.RAGlessFallbackCondition)
+			if !tt.allowFallback {
+				if cond != nil {
+					t.Errorf("expected no RAGlessFallbackCondition, got %+v", cond)
+				}
+				return
+			}
+			if cond == nil || cond.Status != corev1.ConditionTrue {
+				t.Fatalf("expected True RAGlessFallbackCondition, got %+v", cond)
+			}
+			if strings.Contains(cond.Message, "did not become ready") != tt.expectFallback {
+				t.Errorf("Message = %q, fallback expected: %v", cond.Message, tt.expectFallback)
+			}
+		})
+	}
+}
+
+func TestReconcileExternalOLSLifecycle(t *testing.T) {
+	tests := []struct {
+		name          string
+		lifecycleMode string
+		csvAnnotated  bool
+	}{
+		{
+			name:          "External lifecycle mode",
+			lifecycleMode: apiv1beta1.OLSLifecycleModeExternal,
+		},
+		{
+			name:          "OLS operator CSV annotated as externally managed",
+			lifecycleMode: apiv1beta1.OLSLifecycleModeManage,
+			csvAnnotated:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.Spec.OLSLifecycleMode = tt.lifecycleMode
+
+			// The OLS operator was installed by another operator
+			csv := &operatorsv1alpha1.ClusterServiceVersion{
+				ObjectMeta: metav1.ObjectMeta{Name: testOLSCSVName, Namespace: testOLSNamespace},
+				Status:     operatorsv1alpha1.ClusterServiceVersionStatus{Phase: operatorsv1alpha1.CSVPhaseSucceeded},
+			}
+			if tt.csvAnnotated {
+				csv.Annotations = map[string]string{OLSExternallyManagedAnnotation: "true"}
+			}
+
+			// Any write to the OLM resources means we are fighting over the OLS lifecycle
+			var olmWrites []string
+			recordOLMWrite := func(verb string, obj client.Object) {
+				switch obj.(type) {
+				case *operatorsv1alpha1.Subscription, *operatorsv1alpha1.InstallPlan, *operatorsv1alpha1.ClusterServiceVersion:
+					olmWrites = append(olmWrites, verb+" "+obj.GetName())
+				}
+			}
+			cl := newTestClientWithInterceptor(t, interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					recordOLMWrite("create", obj)
+					return c.Create(ctx, obj, opts...)
+				},
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					recordOLMWrite("update", obj)
+					return c.Update(ctx, obj, opts...)
+				},
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					recordOLMWrite("patch", obj)
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					recordOLMWrite("delete", obj)
+					return c.Delete(ctx, obj, opts...)
+				},
+			}, instance, csv, newTestOLSConfigCRD(true), newTestLLMCredentialsSecret(instance))
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.OpenShiftLightspeedOperatorReadyCondition)
+			if cond == nil || cond.Status != corev1.ConditionTrue {
+				t.Fatalf("expected True OpenShiftLightspeedOperatorReadyCondition, got %+v", cond)
+			}
+			if cond.Message != apiv1beta1.OpenShiftLightspeedOperatorExternalReady {
+				t.Errorf("Message = %q, want %q", cond.Message, apiv1beta1.OpenShiftLightspeedOperatorExternalReady)
+			}
+			if _, err := getTestOLSConfig(t, cl); err != nil {
+				t.Errorf("expected the OLSConfig to be configured: %v", err)
+			}
+
+			// Deleting the instance leaves the OLS operator in place
+			if err := cl.Delete(context.Background(), instance); err != nil {
+				t.Fatalf("failed to delete OpenStackLightspeed instance: %v", err)
+			}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)}); err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			if err := cl.Get(context.Background(), client.ObjectKeyFromObject(csv), csv); err != nil {
+				t.Errorf("expected the OLS operator CSV to be kept: %v", err)
+			}
+			if len(olmWrites) != 0 {
+				t.Errorf("expected no Subscription, InstallPlan or CSV writes, got %v", olmWrites)
+			}
+		})
+	}
+}
+
+func TestReconcileUsageBudget(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+	instance.Spec.UsageBudget = &apiv1beta1.UsageBudget{TokensPerDay: 1000, AlertThresholdPercent: 80}
+
+	cl := newTestClient(t, append(newTestOLSOperatorObjects(instance), instance, newTestOLSConfig(instance, true))...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	_, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	expectedMessage := fmt.Sprintf(apiv1beta1.UsageBudgetMessage, 800, 1000)
+	cond := instance.Status.Conditions.Get(apiv1beta1.UsageBudgetCondition)
+	if cond == nil || cond.Status != corev1.ConditionTrue || cond.Message != expectedMessage {
+		t.Errorf("expected UsageBudgetCondition with message %q, got %+v", expectedMessage, cond)
+	}
+
+	rule := &uns.Unstructured{}
+	rule.SetGroupVersionKind(testPrometheusRuleGVK)
+	key := client.ObjectKey{Name: UsageBudgetPrometheusRuleName, Namespace: OLSOperatorNamespace}
+	if err := cl.Get(context.Background(), key, rule); err != nil {
+		t.Fatalf("expected the usage budget PrometheusRule to be created: %v", err)
+	}
+
+	// The usage is alerted on, not limited
+	olsConfig, err := getTestOLSConfig(t, cl)
+	if err != nil {
+		t.Fatalf("expected OLSConfig to be created, got %v", err)
+	}
+	if _, found, _ := uns.NestedFieldNoCopy(olsConfig.Object, "spec", "ols", "quotaHandlersConfig"); found {
+		t.Errorf("expected no OLS quota limiter for the usage budget")
+	}
+
+	// Unsetting the usage budget removes the alert
+	instance.Spec.UsageBudget = nil
+	if err := cl.Update(context.Background(), instance); err != nil {
+		t.Fatalf("failed to update instance: %v", err)
+	}
+	_, instance, err = reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if cond := instance.Status.Conditions.Get(apiv1beta1.UsageBudgetCondition); cond != nil {
+		t.Errorf("expected no UsageBudgetCondition without usage budget, got %+v", cond)
+	}
+	if err := cl.Get(context.Background(), key, rule); err == nil {
+		t.Errorf("expected the usage budget PrometheusRule to be removed")
+	}
+}
+
+func TestReconcileEmptyModelNameSkipsOLSConfig(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+	olsConfig := newTestOLSConfig(instance, true)
+	instance.Spec.ModelName = ""
+
+	var olsConfigWrites []string
+	recordOLSConfigWrite := func(verb string, obj client.Object) {
+		if obj.GetObjectKind().GroupVersionKind().Kind == "OLSConfig" {
+			olsConfigWrites = append(olsConfigWrites, verb)
+		}
+	}
+	cl := newTestClientWithInterceptor(t, interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			recordOLSConfigWrite("create", obj)
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			recordOLSConfigWrite("update", obj)
+			return c.Update(ctx, obj, opts...)
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			recordOLSConfigWrite("patch", obj)
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	}, append(newTestOLSOperatorObjects(instance), instance, olsConfig)...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	_, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	if len(olsConfigWrites) != 0 {
+		t.Errorf("expected no OLSConfig write without a model name, got %v", olsConfigWrites)
+	}
+
+	cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+	if cond == nil || cond.Status != corev1.ConditionFalse || !strings.Contains(cond.Message, "spec.modelName") {
+		t.Errorf("expected OpenStackLightspeedReadyCondition rejecting the empty model name, got %+v", cond)
+	}
+}
+
+func TestReconcileWithoutConsole(t *testing.T) {
+	tests := []struct {
+		name            string
+		capabilities    []interface{}
+		expectedReady   bool
+		expectedMessage string
+	}{
+		{
+			name:            "Cluster with the console",
+			capabilities:    []interface{}{"Console", "Insights"},
+			expectedReady:   false,
+			expectedMessage: "OpenShift console is available",
+		},
+		{
+			name:            "Cluster without the console",
+			capabilities:    []interface{}{"Insights"},
+			expectedReady:   true,
+			expectedMessage: "OpenShift console is not available, OLS readiness does not wait for the console plugin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+			clusterVersion := newTestClusterVersion("4.18.0")
+			_ = uns.SetNestedSlice(clusterVersion.Object, tt.capabilities,
+				"status", "capabilities", "enabledCapabilities")
+
+			// OLS never reports the console plugin as ready without the console
+			olsConfig := newTestOLSConfig(instance, false)
+			_ = uns.SetNestedField(olsConfig.Object, "NotReady", "status", "overallStatus")
+			_ = uns.SetNestedSlice(olsConfig.Object, []interface{}{
+				map[string]interface{}{"type": "ApiReady", "status": "True"},
+				map[string]interface{}{"type": "CacheReady", "status": "True"},
+				map[string]interface{}{"type": "ConsolePluginReady", "status": "False"},
+				map[string]interface{}{"type": "Reconciled", "status": "True"},
+			}, "status", "conditions")
+
+			objs := append(newTestOLSOperatorObjects(instance), instance, clusterVersion, olsConfig)
+			cl := newTestClient(t, objs...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			cond := instance.Status.Conditions.Get(apiv1beta1.ConsoleAvailableCondition)
+			if cond == nil || cond.Status != corev1.ConditionTrue || cond.Message != tt.expectedMessage {
+				t.Errorf("expected ConsoleAvailableCondition with message %q, got %+v", tt.expectedMessage, cond)
+			}
+
+			cond = instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+			if cond == nil || (cond.Status == corev1.ConditionTrue) != tt.expectedReady {
+				t.Errorf("expected OpenStackLightspeedReadyCondition ready=%v, got %+v", tt.expectedReady, cond)
+			}
+		})
+	}
+}
+
+func TestReconcileStatusMessage(t *testing.T) {
+	tests := []struct {
+		name            string
+		olsConfigReady  bool
+		expectedMessage string
+	}{
+		{
+			name:            "OLS ready",
+			olsConfigReady:  true,
+			expectedMessage: condition.ReadyMessage,
+		},
+		{
+			name:            "OLS not ready",
+			olsConfigReady:  false,
+			expectedMessage: apiv1beta1.OpenStackLightspeedReadyInitMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+			instance := newTestInstance()
+			instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+			instance.CreationTimestamp = metav1.Now()
+
+			objs := append(newTestOLSOperatorObjects(instance), instance, newTestOLSConfig(instance, tt.olsConfigReady))
+			cl := newTestClient(t, objs...)
+			r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+			_, instance, err := reconcileTestInstance(t, r)
+			if err != nil {
+				t.Fatalf("unexpected reconcile error: %v", err)
+			}
+
+			if instance.Status.Message != tt.expectedMessage {
+				t.Errorf("Message = %q, want %q", instance.Status.Message, tt.expectedMessage)
+			}
+			if cond := instance.Status.Conditions.Get(condition.ReadyCondition); instance.Status.Message != cond.Message {
+				t.Errorf("Message = %q, want the Ready condition message %q", instance.Status.Message, cond.Message)
+			}
+		})
+	}
+
+	t.Run("Deleting", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+		instance.DeletionTimestamp = ptr.To(metav1.Now())
+
+		// Another finalizer keeps the OLSConfig removal in progress
+		olsConfig := newTestOLSConfig(instance, true)
+		olsConfig.SetFinalizers(append(olsConfig.GetFinalizers(), "example.com/keep"))
+
+		cl := newTestClient(t, instance, olsConfig)
+		r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+		_, instance, err := reconcileTestInstance(t, r)
+		if err != nil {
+			t.Fatalf("unexpected reconcile error: %v", err)
+		}
+
+		if instance.Status.Message != apiv1beta1.OpenStackLightspeedDeletingMessage {
+			t.Errorf("Message = %q, want %q", instance.Status.Message, apiv1beta1.OpenStackLightspeedDeletingMessage)
+		}
+	})
+}
+
+func TestReconcileOLSConfigOwnershipConflict(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+
+	owner := newTestInstance()
+	owner.Namespace = "team-a"
+	owner.UID = "owner-uid"
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+
+	olsConfig := newTestOLSConfig(owner, true)
+	olsConfig.SetFinalizers(nil)
+
+	objs := append(newTestOLSOperatorObjects(instance), owner, instance, olsConfig)
+	cl := newTestClient(t, objs...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	// The instance keeps waiting without touching the OLSConfig of the other instance
+	for range 2 {
+		result, instance, err := reconcileTestInstance(t, r)
+		if err != nil {
+			t.Fatalf("unexpected reconcile error: %v", err)
+		}
+		if result.RequeueAfter != 0 {
+			t.Errorf("expected no requeue while the OLSConfig is managed by another instance, got %v", result)
+		}
+
+		cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition)
+		if cond == nil || cond.Status != corev1.ConditionFalse || cond.Reason != apiv1beta1.OLSConfigConflictingReason {
+			t.Fatalf("expected Conflicting OpenStackLightspeedReadyCondition, got %+v", cond)
+		}
+		expectedMessage := "Waiting for the OLSConfig to be released: OLSConfig is managed by different " +
+			"OpenStackLightspeed instance " + testInstanceName + " in namespace team-a"
+		if cond.Message != expectedMessage {
+			t.Errorf("Message = %q, want %q", cond.Message, expectedMessage)
+		}
+
+		current, err := getTestOLSConfig(t, cl)
+		if err != nil {
+			t.Fatalf("failed to get OLSConfig: %v", err)
+		}
+		if current.GetResourceVersion() != olsConfig.GetResourceVersion() {
+			t.Errorf("expected the OLSConfig of the other instance to be left untouched")
+		}
+	}
+
+	// A change of the OLSConfig wakes up the instance waiting for it
+	requests := r.NotifyOLSConfigOwner(context.Background(), olsConfig)
+	if !slices.Contains(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(instance)}) {
+		t.Errorf("expected the OLSConfig change to enqueue the waiting instance, got %v", requests)
+	}
+
+	// The other instance is deleted along with its OLSConfig
+	if err := cl.Delete(context.Background(), owner); err != nil {
+		t.Fatalf("failed to delete the other instance: %v", err)
+	}
+	if err := cl.Delete(context.Background(), olsConfig); err != nil {
+		t.Fatalf("failed to delete the OLSConfig: %v", err)
+	}
+
+	_, instance, err := reconcileTestInstance(t, r)
+	if err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+	if cond := instance.Status.Conditions.Get(apiv1beta1.OpenStackLightspeedReadyCondition); cond != nil &&
+		cond.Reason == apiv1beta1.OLSConfigConflictingReason {
+		t.Errorf("expected the conflict to be resolved, got %+v", cond)
+	}
+
+	current, err := getTestOLSConfig(t, cl)
+	if err != nil {
+		t.Fatalf("expected the OLSConfig to be created, got %v", err)
+	}
+	if current.GetLabels()[OpenStackLightspeedOwnerIDLabel] != string(instance.UID) {
+		t.Errorf("expected the OLSConfig to be managed by %s, got labels %v", instance.UID, current.GetLabels())
+	}
+}
+
+func TestNotifyOLSConfigOwner(t *testing.T) {
+	instance := newTestInstance()
+	other := newTestInstance()
+	other.Name = "other-lightspeed"
+	other.UID = "fedcba98-7654-3210-fedc-ba9876543210"
+
+	cl := newTestClient(t, instance, other)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	requests := r.NotifyOLSConfigOwner(context.Background(), newTestOLSConfig(instance, false))
+	if len(requests) != 1 || requests[0].NamespacedName != client.ObjectKeyFromObject(instance) {
+		t.Errorf("expected the OLSConfig change to enqueue %s, got %v", instance.Name, requests)
+	}
+
+	unmanaged := newTestOLSConfig(instance, false)
+	unmanaged.SetLabels(nil)
+	if requests := r.NotifyOLSConfigOwner(context.Background(), unmanaged); len(requests) != 0 {
+		t.Errorf("expected an unmanaged OLSConfig to enqueue nothing, got %v", requests)
+	}
+}
+
+func TestNotifyOLSGateOwners(t *testing.T) {
+	instance := newTestInstance()
+	instance.Spec.APITLS = &apiv1beta1.APITLS{SecretName: "ols-api-tls"}
+	other := newTestInstance()
+	other.Name = "other-lightspeed"
+
+	cl := newTestClient(t, instance, other)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	secret := &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: "ols-api-tls", Namespace: OLSOperatorNamespace},
+	}
+	requests := r.NotifyOLSGateOwners(context.Background(), secret)
+	if len(requests) != 1 || requests[0].NamespacedName != client.ObjectKeyFromObject(instance) {
+		t.Errorf("expected the secret change to enqueue %s, got %v", instance.Name, requests)
+	}
+
+	secret.Name = "unrelated"
+	if requests := r.NotifyOLSGateOwners(context.Background(), secret); len(requests) != 0 {
+		t.Errorf("expected an unreferenced secret to enqueue nothing, got %v", requests)
+	}
+}
+
+func TestNotifyConfigMapOwners(t *testing.T) {
+	instance := newTestInstance()
+	instance.Spec.OpenStackContextRef = &apiv1beta1.OpenStackContextRef{Name: "openstack-context"}
+	other := newTestInstance()
+	other.Name = "other-lightspeed"
+
+	cl := newTestClient(t, instance, other)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "openstack-context", Namespace: instance.Namespace},
+	}
+	if !r.IsWatchedConfigMap(configMap) {
+		t.Errorf("expected the OpenStack context ConfigMap to be watched")
+	}
+	requests := r.NotifyConfigMapOwners(context.Background(), configMap)
+	if len(requests) != 1 || requests[0].NamespacedName != client.ObjectKeyFromObject(instance) {
+		t.Errorf("expected the OpenStack context change to enqueue %s, got %v", instance.Name, requests)
+	}
+
+	trustedCA := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: TrustedCAConfigMapName, Namespace: OLSOperatorNamespace},
+	}
+	if !r.IsWatchedConfigMap(trustedCA) {
+		t.Errorf("expected the trusted CA ConfigMap to be watched")
+	}
+	if requests := r.NotifyConfigMapOwners(context.Background(), trustedCA); len(requests) != 2 {
+		t.Errorf("expected the trusted CA change to enqueue both instances, got %v", requests)
+	}
+
+	configMap.Name = "unrelated"
+	if r.IsWatchedConfigMap(configMap) {
+		t.Errorf("expected an unreferenced ConfigMap not to be watched")
+	}
+}
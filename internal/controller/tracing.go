@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+const (
+	// TracingEnabledEnvVar - Environment variable that enables the OpenTelemetry tracing of the
+	// reconciles when set to "true"
+	TracingEnabledEnvVar = "ENABLE_TRACING"
+
+	// otlpEndpointEnvVar - Standard OpenTelemetry environment variable holding the OTLP endpoint the
+	// spans are exported to
+	otlpEndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	// tracerName - Name of the tracer creating the reconcile spans
+	tracerName = "github.com/openstack-lightspeed/operator"
+)
+
+// Names of the spans created around the reconcile phases
+const (
+	SpanOperatorInstall = "operator-install"
+	SpanOLSConfigPatch  = "olsconfig-patch"
+	SpanOCPDetect       = "ocp-detect"
+	SpanReadinessCheck  = "readiness-check"
+)
+
+// SetupTracing installs the global tracer provider that exports the reconcile spans through OTLP/gRPC
+// to the endpoint in OTEL_EXPORTER_OTLP_ENDPOINT. Nothing is installed unless ENABLE_TRACING is
+// "true", in which case the spans are no-ops. The returned function flushes the pending spans and
+// must be called before the operator exits.
+func SetupTracing(ctx context.Context) (func(context.Context) error, error) {
+	if os.Getenv(TracingEnabledEnvVar) != "true" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	if os.Getenv(otlpEndpointEnvVar) == "" {
+		return nil, fmt.Errorf("%s must be set when %s is true", otlpEndpointEnvVar, TracingEnabledEnvVar)
+	}
+
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "openstack-lightspeed-operator"),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// startReconcileSpan starts the span of a reconcile phase of the instance. The span carries the
+// instance UID and the OLS operator version so that the traces of an instance can be told apart.
+func startReconcileSpan(
+	ctx context.Context,
+	name string,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(
+		attribute.String("openstacklightspeed.name", instance.GetName()),
+		attribute.String("openstacklightspeed.namespace", instance.GetNamespace()),
+		attribute.String("openstacklightspeed.uid", string(instance.GetUID())),
+		attribute.String("ols.version", os.Getenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION")),
+	))
+}
+
+// endReconcileSpan records the error of the reconcile phase, if any, and ends its span
+func endReconcileSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
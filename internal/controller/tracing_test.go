@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordTestSpans installs a tracer provider recording the spans for the duration of the test
+func recordTestSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return recorder
+}
+
+func TestSetupTracingDisabled(t *testing.T) {
+	t.Setenv(TracingEnabledEnvVar, "")
+
+	previous := otel.GetTracerProvider()
+	shutdown, err := SetupTracing(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if otel.GetTracerProvider() != previous {
+		t.Errorf("expected the tracer provider to be left untouched when tracing is disabled")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("unexpected shutdown error: %v", err)
+	}
+}
+
+func TestSetupTracingWithoutEndpoint(t *testing.T) {
+	t.Setenv(TracingEnabledEnvVar, "true")
+	t.Setenv(otlpEndpointEnvVar, "")
+
+	if _, err := SetupTracing(context.Background()); err == nil {
+		t.Errorf("expected an error when the OTLP endpoint is not set")
+	}
+}
+
+func TestReconcileTracingSpans(t *testing.T) {
+	t.Setenv("OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION", testOLSVersion)
+	recorder := recordTestSpans(t)
+
+	instance := newTestInstance()
+	instance.Finalizers = []string{"openstack.org/openstacklightspeed"}
+	cl := newTestClient(t, append(newTestOLSOperatorObjects(instance), instance)...)
+	r := &OpenStackLightspeedReconciler{Client: cl, Scheme: cl.Scheme()}
+
+	if _, _, err := reconcileTestInstance(t, r); err != nil {
+		t.Fatalf("unexpected reconcile error: %v", err)
+	}
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+
+		attrs := attribute.NewSet(span.Attributes()...)
+		if uid, _ := attrs.Value("openstacklightspeed.uid"); uid.AsString() != string(instance.GetUID()) {
+			t.Errorf("span %s: openstacklightspeed.uid = %q, want %q", span.Name(), uid.AsString(), instance.GetUID())
+		}
+		if version, _ := attrs.Value("ols.version"); version.AsString() != testOLSVersion {
+			t.Errorf("span %s: ols.version = %q, want %q", span.Name(), version.AsString(), testOLSVersion)
+		}
+	}
+
+	expected := []string{SpanOCPDetect, SpanOperatorInstall, SpanOLSConfigPatch, SpanReadinessCheck}
+	if !slices.Equal(names, expected) {
+		t.Errorf("spans = %v, want %v", names, expected)
+	}
+}
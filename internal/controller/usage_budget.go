@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	common_helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+const (
+	// UsageBudgetPrometheusRuleName - name of the PrometheusRule alerting on the OLS token usage
+	UsageBudgetPrometheusRuleName = "openstack-lightspeed-usage-budget"
+
+	// UsageBudgetAlertThresholdPercentDefault - alert threshold used when the usage budget does not
+	// set one
+	UsageBudgetAlertThresholdPercentDefault = 80
+
+	// UsageBudgetTokensMetric - recorded number of tokens OLS exchanged with the LLM over the last day
+	UsageBudgetTokensMetric = "openstack_lightspeed:ols_llm_tokens:increase1d"
+
+	// UsageBudgetAlertName - alert raised when the OLS token usage crosses the alert threshold
+	UsageBudgetAlertName = "OpenStackLightspeedUsageBudgetThresholdCrossed"
+)
+
+// GetPrometheusRuleGVK returns the GroupVersionKind of the PrometheusRule
+func GetPrometheusRuleGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "monitoring.coreos.com",
+		Version: "v1",
+		Kind:    "PrometheusRule",
+	}
+}
+
+// GetUsageBudgetAlertTokens returns the number of tokens per day above which the usage is alerted on
+func GetUsageBudgetAlertTokens(budget *apiv1beta1.UsageBudget) int64 {
+	threshold := int64(budget.AlertThresholdPercent)
+	if threshold == 0 {
+		threshold = UsageBudgetAlertThresholdPercentDefault
+	}
+
+	return budget.TokensPerDay * threshold / 100
+}
+
+// EnsureUsageBudgetPrometheusRule creates the PrometheusRule recording the daily token usage OLS
+// reports through its ols_llm_token_sent_total and ols_llm_token_received_total metrics, and
+// alerting when it crosses the alert threshold of the usage budget. The usage is not limited.
+// Returns false when the cluster does not serve the PrometheusRule API.
+func EnsureUsageBudgetPrometheusRule(
+	ctx context.Context,
+	helper *common_helper.Helper,
+	instance *apiv1beta1.OpenStackLightspeed,
+) (bool, error) {
+	budget := instance.Spec.UsageBudget
+
+	rule := &uns.Unstructured{}
+	rule.SetGroupVersionKind(GetPrometheusRuleGVK())
+	rule.SetName(UsageBudgetPrometheusRuleName)
+	rule.SetNamespace(OLSOperatorNamespace)
+
+	_, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), rule, func() error {
+		groups := []interface{}{
+			map[string]interface{}{
+				"name": "openstack-lightspeed-usage-budget",
+				"rules": []interface{}{
+					map[string]interface{}{
+						"record": UsageBudgetTokensMetric,
+						"expr": "sum(increase(ols_llm_token_sent_total[1d])) + " +
+							"sum(increase(ols_llm_token_received_total[1d]))",
+					},
+					map[string]interface{}{
+						"alert": UsageBudgetAlertName,
+						"expr":  fmt.Sprintf("%s > %d", UsageBudgetTokensMetric, GetUsageBudgetAlertTokens(budget)),
+						"labels": map[string]interface{}{
+							"severity": "warning",
+						},
+						"annotations": map[string]interface{}{
+							"summary": "OpenStack Lightspeed token usage crossed the alert threshold",
+							"description": fmt.Sprintf("OLS used {{ $value }} tokens over the last day, the usage "+
+								"budget of %s/%s is %d tokens per day.",
+								instance.Namespace, instance.Name, budget.TokensPerDay),
+						},
+					},
+				},
+			},
+		}
+		return uns.SetNestedSlice(rule.Object, groups, "spec", "groups")
+	})
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RemoveUsageBudgetPrometheusRule deletes the PrometheusRule alerting on the OLS token usage
+func RemoveUsageBudgetPrometheusRule(ctx context.Context, helper *common_helper.Helper) error {
+	rule := &uns.Unstructured{}
+	rule.SetGroupVersionKind(GetPrometheusRuleGVK())
+	rule.SetName(UsageBudgetPrometheusRuleName)
+	rule.SetNamespace(OLSOperatorNamespace)
+
+	err := helper.GetClient().Delete(ctx, rule)
+	if k8s_errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+
+	return err
+}
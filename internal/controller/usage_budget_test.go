@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+func TestGetUsageBudgetAlertTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		budget   apiv1beta1.UsageBudget
+		expected int64
+	}{
+		{
+			name:     "Explicit threshold",
+			budget:   apiv1beta1.UsageBudget{TokensPerDay: 1000, AlertThresholdPercent: 50},
+			expected: 500,
+		},
+		{
+			name:     "Default threshold",
+			budget:   apiv1beta1.UsageBudget{TokensPerDay: 1000},
+			expected: 800,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tokens := GetUsageBudgetAlertTokens(&tt.budget); tokens != tt.expected {
+				t.Errorf("GetUsageBudgetAlertTokens() = %d, want %d", tokens, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEnsureUsageBudgetPrometheusRule(t *testing.T) {
+	t.Run("PrometheusRule API served", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.UsageBudget = &apiv1beta1.UsageBudget{TokensPerDay: 100000, AlertThresholdPercent: 90}
+
+		cl := newTestClient(t, instance)
+		helper := newTestHelper(t, cl, instance)
+
+		isCreated, err := EnsureUsageBudgetPrometheusRule(context.Background(), helper, instance)
+		if err != nil || !isCreated {
+			t.Fatalf("EnsureUsageBudgetPrometheusRule() = (%v, %v), want (true, nil)", isCreated, err)
+		}
+
+		rule := &uns.Unstructured{}
+		rule.SetGroupVersionKind(testPrometheusRuleGVK)
+		key := client.ObjectKey{Name: UsageBudgetPrometheusRuleName, Namespace: OLSOperatorNamespace}
+		if err := cl.Get(context.Background(), key, rule); err != nil {
+			t.Fatalf("expected the PrometheusRule to be created: %v", err)
+		}
+
+		groups, _, _ := uns.NestedSlice(rule.Object, "spec", "groups")
+		if len(groups) != 1 {
+			t.Fatalf("expected a single rule group, got %v", groups)
+		}
+		rules, _, _ := uns.NestedSlice(groups[0].(map[string]interface{}), "rules")
+		if len(rules) != 2 {
+			t.Fatalf("expected a recording and an alerting rule, got %v", rules)
+		}
+		alert := rules[1].(map[string]interface{})
+		if alert["alert"] != UsageBudgetAlertName || alert["expr"] != UsageBudgetTokensMetric+" > 90000" {
+			t.Errorf("unexpected alerting rule %v", alert)
+		}
+
+		if err := RemoveUsageBudgetPrometheusRule(context.Background(), helper); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := cl.Get(context.Background(), key, rule); err == nil {
+			t.Errorf("expected the PrometheusRule to be removed")
+		}
+	})
+
+	t.Run("PrometheusRule API not served", func(t *testing.T) {
+		instance := newTestInstance()
+		instance.Spec.UsageBudget = &apiv1beta1.UsageBudget{TokensPerDay: 100000}
+
+		noMatch := &meta.NoKindMatchError{GroupKind: testPrometheusRuleGVK.GroupKind()}
+		cl := newTestClientWithInterceptor(t, interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if obj.GetObjectKind().GroupVersionKind() == testPrometheusRuleGVK {
+					return noMatch
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if obj.GetObjectKind().GroupVersionKind() == testPrometheusRuleGVK {
+					return noMatch
+				}
+				return c.Delete(ctx, obj, opts...)
+			},
+		}, instance)
+		helper := newTestHelper(t, cl, instance)
+
+		isCreated, err := EnsureUsageBudgetPrometheusRule(context.Background(), helper, instance)
+		if err != nil || isCreated {
+			t.Errorf("EnsureUsageBudgetPrometheusRule() = (%v, %v), want (false, nil)", isCreated, err)
+		}
+		if err := RemoveUsageBudgetPrometheusRule(context.Background(), helper); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ocpversion detects the OpenShift cluster version and resolves the OCP documentation version
+// of the RAG database. It is shared by the controller and the admission webhook.
+package ocpversion
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Supported OCP versions in the RAG database
+const (
+	Version416 = "4.16"
+	Version418 = "4.18"
+	Latest     = "latest"
+)
+
+// preReleaseVersionRegexp matches the versions with a pre-release suffix
+var preReleaseVersionRegexp = regexp.MustCompile(`^\d+\.\d+\.\d+-.+`)
+
+// majorMinorVersionRegexp matches the major.minor prefix of a version
+var majorMinorVersionRegexp = regexp.MustCompile(`^(\d+\.\d+)`)
+
+// Supported lists the OCP versions available in the RAG database
+var Supported = []string{Version416, Version418, Latest}
+
+// InvalidVersionError is returned when the cluster version does not start with major.minor
+type InvalidVersionError struct {
+	// Version as reported by the cluster
+	Version string
+}
+
+func (e *InvalidVersionError) Error() string {
+	return fmt.Sprintf("invalid version format: %s", e.Version)
+}
+
+// GetClusterVersion reads the major.minor OpenShift cluster version from the ClusterVersion object.
+// The reader must not be restricted to the watched namespaces.
+func GetClusterVersion(ctx context.Context, reader client.Reader) (string, error) {
+	version, err := GetClusterFullVersion(ctx, reader)
+	if err != nil {
+		return "", err
+	}
+
+	// Parse version to get major.minor (e.g., "4.15.0" -> "4.15")
+	majorMinor, err := ParseMajorMinor(version)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse version %s: %w", version, err)
+	}
+
+	return majorMinor, nil
+}
+
+// GetClusterFullVersion reads the full OpenShift cluster version from the ClusterVersion object.
+// The reader must not be restricted to the watched namespaces.
+func GetClusterFullVersion(ctx context.Context, reader client.Reader) (string, error) {
+	clusterVersion := &uns.Unstructured{}
+	clusterVersion.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "ClusterVersion",
+	})
+
+	err := reader.Get(ctx, client.ObjectKey{Name: "version"}, clusterVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to get ClusterVersion: %w", err)
+	}
+
+	// Extract version from status.desired.version
+	// NOTE: We intentionally use desired.version rather than history[0].version because:
+	// - During OCP upgrades, desired.version reflects the target version
+	// - Users troubleshooting upgrade issues need docs for the NEW version
+	// - This provides proactive access to relevant documentation
+	version, found, err := uns.NestedString(clusterVersion.Object, "status", "desired", "version")
+	if err != nil {
+		return "", fmt.Errorf("failed to extract version from ClusterVersion: %w", err)
+	}
+	if !found {
+		return "", fmt.Errorf("version field not found in ClusterVersion status.desired.version")
+	}
+
+	return version, nil
+}
+
+// ParseMajorMinor extracts major.minor version from full version string
+// Example: "4.15.0-0.nightly-2024-01-15-123456" -> "4.15"
+func ParseMajorMinor(fullVersion string) (string, error) {
+	matches := majorMinorVersionRegexp.FindStringSubmatch(fullVersion)
+	if len(matches) < 2 {
+		return "", &InvalidVersionError{Version: fullVersion}
+	}
+
+	return matches[1], nil
+}
+
+// IsPreRelease returns whether the full version is a pre-release build, i.e. carries a pre-release
+// suffix after major.minor.patch
+// Example: "4.18.0-0.nightly-2024-01-15-123456", "4.18.0-ec.2" and "4.18.0-rc.1" are pre-releases,
+// "4.18.3" is not
+func IsPreRelease(fullVersion string) bool {
+	return preReleaseVersionRegexp.MatchString(fullVersion)
+}
+
+// IsSupported checks if the version is explicitly supported in RAG DB
+func IsSupported(version string) bool {
+	return slices.Contains(Supported, version)
+}
+
+// Resolve determines the OCP version to use for RAG configuration
+// Returns (version, isFallback, error)
+// - version: The version to use (might be "latest" as fallback)
+// - isFallback: true if falling back to "latest" for unsupported version
+// - error: any error during version resolution
+func Resolve(detectedVersion, overrideVersion string, enableOCPRAG bool) (string, bool, error) {
+	if !enableOCPRAG {
+		return "", false, nil
+	}
+
+	// Use override if provided
+	if overrideVersion != "" {
+		return overrideVersion, false, nil
+	}
+
+	if detectedVersion == "" {
+		return "", false, fmt.Errorf("no OCP version detected")
+	}
+
+	// Check if detected version is supported
+	if IsSupported(detectedVersion) {
+		return detectedVersion, false, nil
+	}
+
+	// Fallback to latest for unsupported versions
+	return Latest, true, nil
+}
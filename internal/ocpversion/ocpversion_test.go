@@ -0,0 +1,247 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocpversion
+
+import (
+	"testing"
+)
+
+func TestParseMajorMinor(t *testing.T) {
+	tests := []struct {
+		name        string
+		fullVersion string
+		expected    string
+		shouldError bool
+	}{
+		{
+			name:        "Standard version",
+			fullVersion: "4.16.0",
+			expected:    "4.16",
+			shouldError: false,
+		},
+		{
+			name:        "Version with build",
+			fullVersion: "4.18.0-0.nightly-2024-01-15-123456",
+			expected:    "4.18",
+			shouldError: false,
+		},
+		{
+			name:        "Invalid version",
+			fullVersion: "invalid",
+			expected:    "",
+			shouldError: true,
+		},
+		{
+			name:        "Empty version",
+			fullVersion: "",
+			expected:    "",
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseMajorMinor(tt.fullVersion)
+			if tt.shouldError {
+				if err == nil {
+					t.Errorf("ParseMajorMinor(%s) expected error, got nil", tt.fullVersion)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("ParseMajorMinor(%s) unexpected error: %v", tt.fullVersion, err)
+				}
+				if result != tt.expected {
+					t.Errorf("ParseMajorMinor(%s) = %s, want %s", tt.fullVersion, result, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name             string
+		detected         string
+		override         string
+		enableOCPRAG     bool
+		expectedVer      string
+		expectedFallback bool
+		shouldError      bool
+	}{
+		{
+			name:             "OCP RAG disabled",
+			detected:         "4.16",
+			override:         "",
+			enableOCPRAG:     false,
+			expectedVer:      "",
+			expectedFallback: false,
+			shouldError:      false,
+		},
+		{
+			name:             "Supported version detected",
+			detected:         "4.16",
+			override:         "",
+			enableOCPRAG:     true,
+			expectedVer:      "4.16",
+			expectedFallback: false,
+			shouldError:      false,
+		},
+		{
+			name:             "Unsupported version - fallback",
+			detected:         "4.17",
+			override:         "",
+			enableOCPRAG:     true,
+			expectedVer:      "latest",
+			expectedFallback: true,
+			shouldError:      false,
+		},
+		{
+			name:             "Version override",
+			detected:         "4.18",
+			override:         "4.16",
+			enableOCPRAG:     true,
+			expectedVer:      "4.16",
+			expectedFallback: false,
+			shouldError:      false,
+		},
+		{
+			name:             "Custom override (any version allowed)",
+			detected:         "4.16",
+			override:         "4.99",
+			enableOCPRAG:     true,
+			expectedVer:      "4.99",
+			expectedFallback: false,
+			shouldError:      false,
+		},
+		{
+			name:             "No version detected",
+			detected:         "",
+			override:         "",
+			enableOCPRAG:     true,
+			expectedVer:      "",
+			expectedFallback: false,
+			shouldError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, isFallback, err := Resolve(tt.detected, tt.override, tt.enableOCPRAG)
+			if tt.shouldError {
+				if err == nil {
+					t.Errorf("Resolve expected error, got nil")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Resolve unexpected error: %v", err)
+				}
+				if version != tt.expectedVer {
+					t.Errorf("Resolve version = %s, want %s", version, tt.expectedVer)
+				}
+				if isFallback != tt.expectedFallback {
+					t.Errorf("Resolve isFallback = %v, want %v", isFallback, tt.expectedFallback)
+				}
+			}
+		})
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		expected bool
+	}{
+		{
+			name:     "Supported version 4.16",
+			version:  "4.16",
+			expected: true,
+		},
+		{
+			name:     "Supported version 4.18",
+			version:  "4.18",
+			expected: true,
+		},
+		{
+			name:     "Supported version latest",
+			version:  "latest",
+			expected: true,
+		},
+		{
+			name:     "Unsupported version 4.17",
+			version:  "4.17",
+			expected: false,
+		},
+		{
+			name:     "Unsupported version 4.19",
+			version:  "4.19",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := IsSupported(tt.version)
+			if result != tt.expected {
+				t.Errorf("IsSupported(%s) = %v, want %v", tt.version, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsPreRelease(t *testing.T) {
+	tests := []struct {
+		name        string
+		fullVersion string
+		expected    bool
+	}{
+		{
+			name:        "GA version",
+			fullVersion: "4.18.3",
+			expected:    false,
+		},
+		{
+			name:        "Nightly build",
+			fullVersion: "4.99.0-0.nightly-2024-01-15-123456",
+			expected:    true,
+		},
+		{
+			name:        "Engineering candidate",
+			fullVersion: "4.19.0-ec.2",
+			expected:    true,
+		},
+		{
+			name:        "Release candidate",
+			fullVersion: "4.19.0-rc.1",
+			expected:    true,
+		},
+		{
+			name:        "Major and minor only",
+			fullVersion: "4.18",
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := IsPreRelease(tt.fullVersion)
+			if result != tt.expected {
+				t.Errorf("IsPreRelease(%s) = %v, want %v", tt.fullVersion, result, tt.expected)
+			}
+		})
+	}
+}
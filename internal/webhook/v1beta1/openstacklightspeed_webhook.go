@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+	"github.com/openstack-lightspeed/operator/internal/ocpversion"
+)
+
+var openstacklightspeedlog = logf.Log.WithName("openstacklightspeed-resource")
+
+// SetupOpenStackLightspeedWebhookWithManager registers the webhook for OpenStackLightspeed in the manager.
+func SetupOpenStackLightspeedWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&apiv1beta1.OpenStackLightspeed{}).
+		WithValidator(&OpenStackLightspeedCustomValidator{Reader: mgr.GetAPIReader()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-lightspeed-openstack-org-v1beta1-openstacklightspeed,mutating=false,failurePolicy=fail,sideEffects=None,groups=lightspeed.openstack.org,resources=openstacklightspeeds,verbs=create;update,versions=v1beta1,name=vopenstacklightspeed-v1beta1.kb.io,admissionReviewVersions=v1
+
+// OpenStackLightspeedCustomValidator validates the OpenStackLightspeed resources when they are
+// created or updated.
+type OpenStackLightspeedCustomValidator struct {
+	// Reader is used to look up cluster wide resources such as the ClusterVersion. It must not be
+	// restricted to the watched namespaces.
+	Reader client.Reader
+}
+
+var _ admission.CustomValidator = &OpenStackLightspeedCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type OpenStackLightspeed.
+func (v *OpenStackLightspeedCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	instance, ok := obj.(*apiv1beta1.OpenStackLightspeed)
+	if !ok {
+		return nil, fmt.Errorf("expected an OpenStackLightspeed object but got %T", obj)
+	}
+	openstacklightspeedlog.Info("Validation for OpenStackLightspeed upon creation", "name", instance.GetName())
+
+	return v.validate(ctx, instance, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type OpenStackLightspeed.
+func (v *OpenStackLightspeedCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	instance, ok := newObj.(*apiv1beta1.OpenStackLightspeed)
+	if !ok {
+		return nil, fmt.Errorf("expected an OpenStackLightspeed object for the newObj but got %T", newObj)
+	}
+	oldInstance, ok := oldObj.(*apiv1beta1.OpenStackLightspeed)
+	if !ok {
+		return nil, fmt.Errorf("expected an OpenStackLightspeed object for the oldObj but got %T", oldObj)
+	}
+	openstacklightspeedlog.Info("Validation for OpenStackLightspeed upon update", "name", instance.GetName())
+
+	// The updates of an instance being deleted, e.g. the removal of its finalizer, must never be
+	// blocked
+	if !instance.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return v.validate(ctx, instance, oldInstance)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type OpenStackLightspeed.
+func (v *OpenStackLightspeedCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate runs the checks shared by create and update. oldInstance is nil on create.
+func (v *OpenStackLightspeedCustomValidator) validate(
+	ctx context.Context,
+	instance *apiv1beta1.OpenStackLightspeed,
+	oldInstance *apiv1beta1.OpenStackLightspeed,
+) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+
+	// The cluster might have been upgraded to an unsupported OCP version after the OCP RAG settings
+	// were accepted. The other updates of the instance are not blocked for that reason.
+	enforceOCPRAGVersion := oldInstance == nil || isOCPRAGSpecChanged(&oldInstance.Spec, &instance.Spec)
+	warnings, allErrs := v.validateOCPRAGVersion(ctx, &instance.Spec, specPath, enforceOCPRAGVersion)
+	allErrs = append(allErrs, instance.Spec.ValidateAdmission(specPath)...)
+	if len(allErrs) > 0 {
+		return warnings, k8s_errors.NewInvalid(
+			apiv1beta1.GroupVersion.WithKind("OpenStackLightspeed").GroupKind(),
+			instance.GetName(), allErrs)
+	}
+
+	return warnings, nil
+}
+
+// isOCPRAGSpecChanged returns whether the fields selecting the OCP documentation differ
+func isOCPRAGSpecChanged(oldSpec, newSpec *apiv1beta1.OpenStackLightspeedSpec) bool {
+	return oldSpec.EnableOCPRAG != newSpec.EnableOCPRAG ||
+		oldSpec.OCPRAGVersionOverride != newSpec.OCPRAGVersionOverride ||
+		oldSpec.OCPRAGFallbackBehavior != newSpec.OCPRAGFallbackBehavior
+}
+
+// validateOCPRAGVersion resolves the OCP documentation version the same way the reconcile does and
+// warns about or rejects (depending on OCPRAGFallbackBehavior) OCP versions that have no
+// documentation in the RAG image. Unsupported versions are only warned about when enforce is unset.
+func (v *OpenStackLightspeedCustomValidator) validateOCPRAGVersion(
+	ctx context.Context,
+	spec *apiv1beta1.OpenStackLightspeedSpec,
+	basePath *field.Path,
+	enforce bool,
+) (admission.Warnings, field.ErrorList) {
+	// Nothing to resolve, the override is used as is
+	if !spec.EnableOCPRAG || spec.OCPRAGVersionOverride != "" {
+		return nil, nil
+	}
+
+	detectedVersion, err := ocpversion.GetClusterVersion(ctx, v.Reader)
+	if err != nil {
+		// The reconcile reports the detection failure through the OCPRAGCondition
+		return admission.Warnings{fmt.Sprintf("unable to detect the OCP version: %s", err)}, nil
+	}
+
+	_, isFallback, err := ocpversion.Resolve(detectedVersion, spec.OCPRAGVersionOverride, spec.EnableOCPRAG)
+	if err != nil {
+		return nil, field.ErrorList{field.Invalid(basePath.Child("enableOCPRAG"), spec.EnableOCPRAG, err.Error())}
+	} else if !isFallback {
+		return nil, nil
+	}
+
+	if spec.OCPRAGFallbackBehavior == apiv1beta1.OCPRAGFallbackBehaviorReject && enforce {
+		return nil, field.ErrorList{field.Invalid(basePath.Child("enableOCPRAG"), spec.EnableOCPRAG,
+			fmt.Sprintf("cluster version %s has no OCP documentation (supported versions: %v), "+
+				"set ocpVersionOverride or ocpRAGFallbackBehavior to Fallback",
+				detectedVersion, ocpversion.Supported))}
+	} else if spec.OCPRAGFallbackBehavior == apiv1beta1.OCPRAGFallbackBehaviorReject {
+		// The reconcile disables OCP RAG and reports it through the OCPRAGCondition
+		return admission.Warnings{fmt.Sprintf(apiv1beta1.OCPRAGVersionUnsupportedMessage,
+			detectedVersion, ocpversion.Supported)}, nil
+	}
+
+	return admission.Warnings{fmt.Sprintf(apiv1beta1.OCPRAGVersionFallbackMessage,
+		detectedVersion, ocpversion.Supported)}, nil
+}
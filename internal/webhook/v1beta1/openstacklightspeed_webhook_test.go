@@ -0,0 +1,290 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1beta1 "github.com/openstack-lightspeed/operator/api/v1beta1"
+)
+
+var testClusterVersionGVK = schema.GroupVersionKind{
+	Group:   "config.openshift.io",
+	Version: "v1",
+	Kind:    "ClusterVersion",
+}
+
+// newTestValidator returns a validator that reads the given objects.
+func newTestValidator(t *testing.T, objs ...client.Object) *OpenStackLightspeedCustomValidator {
+	t.Helper()
+
+	s := runtime.NewScheme()
+	if err := apiv1beta1.AddToScheme(s); err != nil {
+		t.Fatalf("failed to build test scheme: %v", err)
+	}
+	s.AddKnownTypeWithName(testClusterVersionGVK, &uns.Unstructured{})
+
+	return &OpenStackLightspeedCustomValidator{
+		Reader: fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build(),
+	}
+}
+
+// newTestClusterVersion returns the ClusterVersion of a cluster running version.
+func newTestClusterVersion(version string) *uns.Unstructured {
+	clusterVersion := &uns.Unstructured{}
+	clusterVersion.SetGroupVersionKind(testClusterVersionGVK)
+	clusterVersion.SetName("version")
+	_ = uns.SetNestedField(clusterVersion.Object, version, "status", "desired", "version")
+	return clusterVersion
+}
+
+// newTestInstance returns an OpenStackLightspeed instance with the given OCP RAG settings.
+func newTestInstance(enableOCPRAG bool, fallbackBehavior string, override string) *apiv1beta1.OpenStackLightspeed {
+	instance := &apiv1beta1.OpenStackLightspeed{
+		ObjectMeta: metav1.ObjectMeta{Name: "openstack-lightspeed", Namespace: "openstack"},
+	}
+	instance.Spec.EnableOCPRAG = enableOCPRAG
+	instance.Spec.OCPRAGFallbackBehavior = fallbackBehavior
+	instance.Spec.OCPRAGVersionOverride = override
+
+	return instance
+}
+
+func TestValidateOCPRAGVersion(t *testing.T) {
+	tests := []struct {
+		name           string
+		instance       *apiv1beta1.OpenStackLightspeed
+		clusterVersion string
+		shouldError    bool
+		shouldWarn     bool
+	}{
+		{
+			name:           "Unsupported version with Fallback",
+			instance:       newTestInstance(true, apiv1beta1.OCPRAGFallbackBehaviorFallback, ""),
+			clusterVersion: "4.17.3",
+			shouldWarn:     true,
+		},
+		{
+			name:           "Unsupported version with Reject",
+			instance:       newTestInstance(true, apiv1beta1.OCPRAGFallbackBehaviorReject, ""),
+			clusterVersion: "4.17.3",
+			shouldError:    true,
+		},
+		{
+			name:           "Supported version with Reject",
+			instance:       newTestInstance(true, apiv1beta1.OCPRAGFallbackBehaviorReject, ""),
+			clusterVersion: "4.18.1",
+		},
+		{
+			name:           "Unsupported version with override",
+			instance:       newTestInstance(true, apiv1beta1.OCPRAGFallbackBehaviorReject, "4.18"),
+			clusterVersion: "4.17.3",
+		},
+		{
+			name:           "OCP RAG disabled",
+			instance:       newTestInstance(false, apiv1beta1.OCPRAGFallbackBehaviorReject, ""),
+			clusterVersion: "4.17.3",
+		},
+		{
+			name:       "Undetectable version",
+			instance:   newTestInstance(true, apiv1beta1.OCPRAGFallbackBehaviorReject, ""),
+			shouldWarn: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objs []client.Object
+			if tt.clusterVersion != "" {
+				objs = append(objs, newTestClusterVersion(tt.clusterVersion))
+			}
+			validator := newTestValidator(t, objs...)
+
+			for op, validate := range map[string]func() ([]string, error){
+				"create": func() ([]string, error) {
+					return validator.ValidateCreate(context.Background(), tt.instance)
+				},
+				"update": func() ([]string, error) {
+					// The OCP RAG settings are only enforced when the update changes them
+					oldInstance := tt.instance.DeepCopy()
+					oldInstance.Spec.EnableOCPRAG = !oldInstance.Spec.EnableOCPRAG
+					return validator.ValidateUpdate(context.Background(), oldInstance, tt.instance)
+				},
+			} {
+				warnings, err := validate()
+				if tt.shouldError && err == nil {
+					t.Errorf("%s: expected error, got nil", op)
+				} else if !tt.shouldError && err != nil {
+					t.Errorf("%s: unexpected error: %v", op, err)
+				}
+
+				if tt.shouldWarn && len(warnings) == 0 {
+					t.Errorf("%s: expected a warning, got none", op)
+				} else if !tt.shouldWarn && len(warnings) != 0 {
+					t.Errorf("%s: unexpected warnings: %v", op, warnings)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateUpdateUnchangedOCPRAGSettings(t *testing.T) {
+	instance := newTestInstance(true, apiv1beta1.OCPRAGFallbackBehaviorReject, "")
+	validator := newTestValidator(t, newTestClusterVersion("4.17.3"))
+
+	// The cluster was upgraded to an unsupported version after the instance was created
+	updated := instance.DeepCopy()
+	updated.Spec.ModelName = "granite"
+	warnings, err := validator.ValidateUpdate(context.Background(), instance, updated)
+	if err != nil {
+		t.Errorf("expected the update to be accepted, got %v", err)
+	}
+	if len(warnings) == 0 {
+		t.Errorf("expected a warning about the unsupported OCP version, got none")
+	}
+
+	// Enabling OCP RAG on the unsupported version is still rejected
+	instance.Spec.EnableOCPRAG = false
+	if _, err := validator.ValidateUpdate(context.Background(), instance, updated); err == nil {
+		t.Errorf("expected the update enabling OCP RAG to be rejected, got nil")
+	}
+}
+
+func TestValidateUpdateDeletingInstance(t *testing.T) {
+	instance := newTestInstance(true, apiv1beta1.OCPRAGFallbackBehaviorReject, "")
+	instance.Spec.DisableRAG = true
+	instance.Spec.RAGImage = "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2"
+	validator := newTestValidator(t, newTestClusterVersion("4.17.3"))
+
+	// Removing the finalizer of a deleted instance must go through even if its spec is invalid
+	updated := instance.DeepCopy()
+	updated.DeletionTimestamp = ptr.To(metav1.Now())
+	updated.Finalizers = nil
+	if _, err := validator.ValidateUpdate(context.Background(), instance, updated); err != nil {
+		t.Errorf("expected the update of a deleted instance to be accepted, got %v", err)
+	}
+}
+
+func TestValidateMutualExclusions(t *testing.T) {
+	instance := newTestInstance(false, "", "")
+	instance.Spec.DisableRAG = true
+	validator := newTestValidator(t, newTestClusterVersion("4.18.1"))
+
+	if _, err := validator.ValidateCreate(context.Background(), instance); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	instance.Spec.RAGImage = "quay.io/openstack-lightspeed/rag-content:os-docs-2025.2"
+	if _, err := validator.ValidateCreate(context.Background(), instance); err == nil {
+		t.Errorf("expected error for disableRAG combined with ragImage, got nil")
+	}
+	if _, err := validator.ValidateUpdate(context.Background(), instance.DeepCopy(), instance); err == nil {
+		t.Errorf("expected error for disableRAG combined with ragImage on update, got nil")
+	}
+}
+
+func TestValidateAdmission(t *testing.T) {
+	tests := []struct {
+		name        string
+		mutate      func(spec *apiv1beta1.OpenStackLightspeedSpec)
+		shouldError bool
+	}{
+		{
+			name: "Valid spec",
+			mutate: func(spec *apiv1beta1.OpenStackLightspeedSpec) {
+				spec.LLMEndpoint = "https://llm.example.com/v1"
+				spec.LLMEndpointType = "openai"
+				spec.MaxTokensForResponse = 2048
+				spec.OCPRAGVersionOverride = "4.18"
+			},
+		},
+		{
+			name: "Latest OCP version override",
+			mutate: func(spec *apiv1beta1.OpenStackLightspeedSpec) {
+				spec.OCPRAGVersionOverride = "latest"
+			},
+		},
+		{
+			name: "LLM endpoint without scheme",
+			mutate: func(spec *apiv1beta1.OpenStackLightspeedSpec) {
+				spec.LLMEndpoint = "llm.example.com/v1"
+			},
+			shouldError: true,
+		},
+		{
+			name: "Unknown LLM endpoint type",
+			mutate: func(spec *apiv1beta1.OpenStackLightspeedSpec) {
+				spec.LLMEndpointType = "ollama"
+			},
+			shouldError: true,
+		},
+		{
+			name: "Negative max tokens for response",
+			mutate: func(spec *apiv1beta1.OpenStackLightspeedSpec) {
+				spec.MaxTokensForResponse = -1
+			},
+			shouldError: true,
+		},
+		{
+			name: "OCP version override with patch version",
+			mutate: func(spec *apiv1beta1.OpenStackLightspeedSpec) {
+				spec.OCPRAGVersionOverride = "4.18.1"
+			},
+			shouldError: true,
+		},
+		{
+			name: "Unknown OCP version override",
+			mutate: func(spec *apiv1beta1.OpenStackLightspeedSpec) {
+				spec.OCPRAGVersionOverride = "newest"
+			},
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance(false, "", "")
+			tt.mutate(&instance.Spec)
+			validator := newTestValidator(t)
+
+			for op, validate := range map[string]func() ([]string, error){
+				"create": func() ([]string, error) {
+					return validator.ValidateCreate(context.Background(), instance)
+				},
+				"update": func() ([]string, error) {
+					return validator.ValidateUpdate(context.Background(), instance.DeepCopy(), instance)
+				},
+			} {
+				_, err := validate()
+				if tt.shouldError && err == nil {
+					t.Errorf("%s: expected error, got nil", op)
+				} else if !tt.shouldError && err != nil {
+					t.Errorf("%s: unexpected error: %v", op, err)
+				}
+			}
+		})
+	}
+}
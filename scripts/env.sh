@@ -1,3 +1,4 @@
 #!/bin/bash
 export OPENSHIFT_LIGHTSPEED_OPERATOR_VERSION="latest"
 export WATCH_NAMESPACE="openshift-lightspeed"
+export ENABLE_WEBHOOKS="false"